@@ -9,6 +9,8 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const defaultSearchLanguage = "simple"
+
 func GetPostgressConnetion() (*sql.DB, error) {
 	db, err := sql.Open(getEnvValue("DB_PROTOCOL"), getConnectionString())
 	if err != nil {
@@ -19,6 +21,16 @@ func GetPostgressConnetion() (*sql.DB, error) {
 	return db, err
 }
 
+func GetSearchLanguage() string {
+	searchLanguage := os.Getenv("SEARCH_LANGUAGE")
+	if searchLanguage == "" {
+		log.Printf("%s in config is not set. %s will be used.", "SEARCH_LANGUAGE", defaultSearchLanguage)
+		searchLanguage = defaultSearchLanguage
+	}
+
+	return searchLanguage
+}
+
 func getConnectionString() string {
 	user := getEnvValue("DB_USER")
 	password := getEnvValue("DB_PASSWORD")
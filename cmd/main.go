@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"log"
+	"os"
 
 	"github.com/C-4KE/simple-posts-service/cmd/dbconnection"
 	"github.com/C-4KE/simple-posts-service/cmd/server"
+	"github.com/C-4KE/simple-posts-service/cmd/transfer"
 	"github.com/C-4KE/simple-posts-service/internal/storage"
 	"github.com/C-4KE/simple-posts-service/internal/storage/database"
 	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
@@ -35,13 +38,51 @@ func main() {
 		storageType = defaultStorageType
 	}
 
+	// migrate-storage opens the storages named by its own flags.
+	if flag.Arg(0) == "migrate-storage" {
+		transfer.MigrateStorage(createDatabaseStorage, flag.Args()[1:])
+		return
+	}
+
 	storageAccessor, err := createStorageAccessor(storageType)
 
 	if err != nil {
 		log.Fatalf("Error while initializing storage: %s", err)
 	}
 
-	server.PostsServer(storageAccessor)
+	switch command := flag.Arg(0); command {
+	case "":
+		server.PostsServer(storageAccessor)
+	case "reconcile-counters":
+		reconcileCounters(storageAccessor)
+	case "export":
+		transfer.Export(storageAccessor, flag.Args()[1:])
+	case "import":
+		transfer.Import(storageAccessor, flag.Args()[1:])
+	default:
+		log.Fatalf("Unknown command: %s", command)
+	}
+}
+
+// reconcileCounters repairs denormalized comment counters. Only the database storage keeps such counters,
+// the in-memory storage updates them in place.
+func reconcileCounters(storageAccessor storage.Accessor) {
+	defer storageAccessor.CloseStorage()
+
+	reconciler, ok := storageAccessor.(interface {
+		ReconcileCommentCounters(ctx context.Context) (int64, error)
+	})
+	if !ok {
+		log.Printf("Storage does not keep denormalized counters, nothing to reconcile.")
+		return
+	}
+
+	repaired, err := reconciler.ReconcileCommentCounters(context.Background())
+	if err != nil {
+		log.Fatalf("Error while reconciling comment counters: %s", err)
+	}
+
+	log.Printf("Comment counters were reconciled, %d rows repaired.", repaired)
 }
 
 func createStorageAccessor(storageType string) (storage.Accessor, error) {
@@ -55,12 +96,19 @@ func createStorageAccessor(storageType string) (storage.Accessor, error) {
 	}
 }
 
+// createInMemoryStorage restores the storage from the memory snapshot in MEMORY_SNAPSHOT if it is set.
+// The snapshot only seeds the storage, changes made while the service runs are not written back to it.
 func createInMemoryStorage() (storage.Accessor, error) {
+	if snapshot := os.Getenv("MEMORY_SNAPSHOT"); snapshot != "" {
+		log.Printf("Posts and comments are restored from the memory snapshot %s, changes will not be saved to it.", snapshot)
+		return transfer.LoadSnapshot(snapshot)
+	}
+
 	inMemoryStorage := inmemory.NewInMemoryStorage()
 	return inmemory.NewInMemoryAccessor(inMemoryStorage), nil
 }
 
 func createDatabaseStorage() (storage.Accessor, error) {
 	databaseStorage, err := dbconnection.GetPostgressConnetion()
-	return database.NewDatabaseAccessor(databaseStorage), err
+	return database.NewDatabaseAccessor(databaseStorage, dbconnection.GetSearchLanguage()), err
 }
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"log"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// getAdminIDs reads users, who are admins regardless of their stored role, from ADMIN_USER_IDS, separated by commas.
+func getAdminIDs() []uuid.UUID {
+	adminIDs := make([]uuid.UUID, 0)
+	if value := os.Getenv("ADMIN_USER_IDS"); value != "" {
+		for _, rawID := range strings.Split(value, ",") {
+			adminID, err := uuid.Parse(strings.TrimSpace(rawID))
+			if err != nil {
+				log.Printf("Incorrect user ID in %s: %s. It will be skipped.", "ADMIN_USER_IDS", rawID)
+				continue
+			}
+
+			adminIDs = append(adminIDs, adminID)
+		}
+	}
+
+	return adminIDs
+}
+
+// getTrustedProxies reads addresses of the authenticating gateway, whose X-User-ID header is trusted, from TRUSTED_PROXIES.
+// Networks in CIDR notation and single addresses are separated by commas. Without them all requests are anonymous.
+func getTrustedProxies() []netip.Prefix {
+	trustedProxies := make([]netip.Prefix, 0)
+	if value := os.Getenv("TRUSTED_PROXIES"); value != "" {
+		for _, rawProxy := range strings.Split(value, ",") {
+			rawProxy = strings.TrimSpace(rawProxy)
+			if prefix, err := netip.ParsePrefix(rawProxy); err == nil {
+				trustedProxies = append(trustedProxies, prefix.Masked())
+				continue
+			}
+
+			addr, err := netip.ParseAddr(rawProxy)
+			if err != nil {
+				log.Printf("Incorrect address in %s: %s. It will be skipped.", "TRUSTED_PROXIES", rawProxy)
+				continue
+			}
+
+			trustedProxies = append(trustedProxies, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+	}
+
+	if len(trustedProxies) == 0 {
+		log.Printf("%s in config is not set. Header %s will be ignored and all requests will be anonymous.", "TRUSTED_PROXIES", "X-User-ID")
+	}
+
+	return trustedProxies
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/internal/service"
+)
+
+const defaultMaxPinnedComments = 3
+
+// getReplyDepthPolicy reads the maximum reply depth from MAX_REPLY_DEPTH (unlimited if it is not set)
+// and what happens to deeper replies from REPLY_DEPTH_MODE: REJECT or FLATTEN.
+func getReplyDepthPolicy() service.ReplyDepthPolicy {
+	replyDepthPolicy := service.ReplyDepthPolicy{
+		MaxDepth: service.UnlimitedReplyDepth,
+		Mode:     service.DepthOverflowReject,
+	}
+
+	if value := os.Getenv("MAX_REPLY_DEPTH"); value != "" {
+		maxDepth, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || maxDepth < 0 {
+			log.Printf("Incorrect %s: %s. Unlimited depth will be used.", "MAX_REPLY_DEPTH", value)
+		} else {
+			replyDepthPolicy.MaxDepth = int32(maxDepth)
+		}
+	}
+
+	if value := os.Getenv("REPLY_DEPTH_MODE"); value != "" {
+		mode := service.DepthOverflowMode(value)
+		if mode != service.DepthOverflowReject && mode != service.DepthOverflowFlatten {
+			log.Printf("Incorrect %s: %s. %s will be used.", "REPLY_DEPTH_MODE", value, service.DepthOverflowReject)
+		} else {
+			replyDepthPolicy.Mode = mode
+		}
+	}
+
+	return replyDepthPolicy
+}
+
+// getPinPolicy reads the number of comments the author of a post may pin from MAX_PINNED_COMMENTS.
+func getPinPolicy() service.PinPolicy {
+	pinPolicy := service.PinPolicy{MaxPinned: defaultMaxPinnedComments}
+
+	if value := os.Getenv("MAX_PINNED_COMMENTS"); value != "" {
+		maxPinned, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || maxPinned < 0 {
+			log.Printf("Incorrect %s: %s. %d will be used.", "MAX_PINNED_COMMENTS", value, defaultMaxPinnedComments)
+		} else {
+			pinPolicy.MaxPinned = int32(maxPinned)
+		}
+	}
+
+	return pinPolicy
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
+)
+
+const (
+	defaultEventsPollInterval = time.Second
+	eventsChannelBuffer       = 100
+	eventsWebhookTimeout      = 10 * time.Second
+	defaultWebhookMaxAttempts = 5
+	defaultWebhookRetryDelay  = 10 * time.Second
+)
+
+func getEventsPollInterval() time.Duration {
+	value := os.Getenv("EVENTS_POLL_INTERVAL")
+	if value == "" {
+		return defaultEventsPollInterval
+	}
+
+	pollInterval, err := time.ParseDuration(value)
+	if err != nil || pollInterval <= 0 {
+		log.Printf("Incorrect %s: %s. %s will be used.", "EVENTS_POLL_INTERVAL", value, defaultEventsPollInterval)
+		return defaultEventsPollInterval
+	}
+
+	return pollInterval
+}
+
+func getWebhookMaxAttempts() int32 {
+	value := os.Getenv("WEBHOOK_MAX_ATTEMPTS")
+	if value == "" {
+		return defaultWebhookMaxAttempts
+	}
+
+	maxAttempts, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || maxAttempts <= 0 {
+		log.Printf("Incorrect %s: %s. %d will be used.", "WEBHOOK_MAX_ATTEMPTS", value, defaultWebhookMaxAttempts)
+		return defaultWebhookMaxAttempts
+	}
+
+	return int32(maxAttempts)
+}
+
+func getWebhookRetryDelay() time.Duration {
+	value := os.Getenv("WEBHOOK_RETRY_DELAY")
+	if value == "" {
+		return defaultWebhookRetryDelay
+	}
+
+	retryDelay, err := time.ParseDuration(value)
+	if err != nil || retryDelay <= 0 {
+		log.Printf("Incorrect %s: %s. %s will be used.", "WEBHOOK_RETRY_DELAY", value, defaultWebhookRetryDelay)
+		return defaultWebhookRetryDelay
+	}
+
+	return retryDelay
+}
+
+func createWebhookWorker(storageAccessor storage.Accessor) *webhooks.Worker {
+	return webhooks.NewWorker(storageAccessor,
+		webhooks.NewClient(eventsWebhookTimeout),
+		getWebhookMaxAttempts(),
+		getWebhookRetryDelay(),
+		getEventsPollInterval())
+}
+
+// createEventSinks returns sinks enabled in config. The channel sink and the sink of webhook subscriptions
+// are always enabled, because they feed GraphQL subscriptions and subscribed webhooks.
+func createEventSinks(storageAccessor storage.Accessor, channelSink *events.ChannelSink) []events.Sink {
+	sinks := []events.Sink{channelSink, webhooks.NewSink(storageAccessor)}
+
+	if url := os.Getenv("EVENTS_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, events.NewWebhookSink(url, &http.Client{Timeout: eventsWebhookTimeout}))
+	}
+
+	if path := os.Getenv("EVENTS_FILE"); path != "" {
+		fileSink, err := events.NewFileSink(path)
+		if err != nil {
+			log.Fatalf("Error while opening events file %s: %s", path, err)
+		}
+
+		sinks = append(sinks, fileSink)
+	}
+
+	return sinks
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/internal/feeds"
+)
+
+const (
+	defaultFeedLimit    = 20
+	defaultFeedMaxLimit = 100
+)
+
+func getPositiveInt32(name string, defaultValue int32) int32 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || parsed <= 0 {
+		log.Printf("Incorrect %s: %s. %d will be used.", name, value, defaultValue)
+		return defaultValue
+	}
+
+	return int32(parsed)
+}
+
+// getFeedLimits reads the number of entries of a feed from FEED_LIMIT and the number clients may ask for
+// with the limit parameter from FEED_MAX_LIMIT.
+func getFeedLimits() feeds.Limits {
+	limits := feeds.Limits{
+		Default: getPositiveInt32("FEED_LIMIT", defaultFeedLimit),
+		Max:     getPositiveInt32("FEED_MAX_LIMIT", defaultFeedMaxLimit),
+	}
+
+	if limits.Max < limits.Default {
+		log.Printf("%s (%d) is less than %s (%d), %d will be used.", "FEED_MAX_LIMIT", limits.Max, "FEED_LIMIT", limits.Default, limits.Default)
+		limits.Max = limits.Default
+	}
+
+	return limits
+}
@@ -0,0 +1,37 @@
+package server
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/C-4KE/simple-posts-service/internal/markdown"
+)
+
+const defaultMarkdownCacheSize = 10000
+
+// createMarkdownRenderer allows images only from hosts listed in MARKDOWN_IMAGE_HOSTS, separated by commas.
+func createMarkdownRenderer() *markdown.Renderer {
+	var imageHosts []string
+	if value := os.Getenv("MARKDOWN_IMAGE_HOSTS"); value != "" {
+		imageHosts = strings.Split(value, ",")
+	}
+
+	cacheSize := defaultMarkdownCacheSize
+	if value := os.Getenv("MARKDOWN_CACHE_SIZE"); value != "" {
+		parsedSize, err := strconv.Atoi(value)
+		if err != nil || parsedSize <= 0 {
+			log.Printf("Incorrect %s: %s. %d will be used.", "MARKDOWN_CACHE_SIZE", value, defaultMarkdownCacheSize)
+		} else {
+			cacheSize = parsedSize
+		}
+	}
+
+	renderer, err := markdown.NewRenderer(imageHosts, cacheSize)
+	if err != nil {
+		log.Fatalf("Error while creating markdown renderer: %s", err)
+	}
+
+	return renderer
+}
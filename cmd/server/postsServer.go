@@ -1,9 +1,11 @@
 package server
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
@@ -11,11 +13,19 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/C-4KE/simple-posts-service/graph"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/feeds"
+	"github.com/C-4KE/simple-posts-service/internal/rest"
+	"github.com/C-4KE/simple-posts-service/internal/service"
 	"github.com/C-4KE/simple-posts-service/internal/storage"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
-const defaultPort = "8080"
+const (
+	defaultPort        = "8080"
+	websocketKeepAlive = 10 * time.Second
+)
 
 func PostsServer(storageAccessor storage.Accessor) {
 	defer storageAccessor.CloseStorage()
@@ -26,12 +36,35 @@ func PostsServer(storageAccessor storage.Accessor) {
 		port = defaultPort
 	}
 
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: graph.NewResolver(storageAccessor)}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deletionPolicy := getDeletionPolicy()
+	postsService := service.NewService(storageAccessor, deletionPolicy, getReplyDepthPolicy(), getPinPolicy())
+	markdownRenderer := createMarkdownRenderer()
+	resolver := graph.NewResolver(storageAccessor, postsService, markdownRenderer)
+
+	channelSink := events.NewChannelSink(eventsChannelBuffer)
+	dispatcher := events.NewDispatcher(storageAccessor, getEventsPollInterval(), createEventSinks(storageAccessor, channelSink)...)
+	go dispatcher.Run(ctx)
+	go createWebhookWorker(storageAccessor).Run(ctx)
+	go createPurger(storageAccessor, deletionPolicy).Run(ctx)
+	go createScheduler(postsService).Run(ctx)
+	go resolver.ConsumeEvents(ctx, channelSink.Events())
+
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Directives: graph.DirectiveRoot{HasRole: graph.HasRole},
+	}))
 
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: websocketKeepAlive,
+	})
 
+	srv.SetErrorPresenter(graph.PresentError)
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
 
 	srv.Use(extension.Introspection{})
@@ -39,8 +72,13 @@ func PostsServer(storageAccessor storage.Accessor) {
 		Cache: lru.New[string](100),
 	})
 
+	adminIDs := getAdminIDs()
+	trustedProxies := getTrustedProxies()
+
 	http.Handle("/", playground.Handler("Simple posts", "/query"))
-	http.Handle("/query", srv)
+	http.Handle("/query", authorization.Middleware(storageAccessor, adminIDs, trustedProxies, graph.LoadersMiddleware(storageAccessor, srv)))
+	http.Handle("/feeds/", feeds.NewHandler(storageAccessor, markdownRenderer, getFeedLimits()))
+	http.Handle(rest.BasePath+"/", authorization.Middleware(storageAccessor, adminIDs, trustedProxies, rest.NewAPI(storageAccessor, postsService, markdownRenderer)))
 
 	log.Printf("connect to http://localhost:%s/ for Simple posts", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
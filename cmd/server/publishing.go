@@ -0,0 +1,15 @@
+package server
+
+import (
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/publishing"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+)
+
+const defaultPublishPollInterval = 30 * time.Second
+
+// createScheduler publishes scheduled posts every PUBLISH_POLL_INTERVAL.
+func createScheduler(postsService *service.Service) *publishing.Scheduler {
+	return publishing.NewScheduler(postsService, getDuration("PUBLISH_POLL_INTERVAL", defaultPublishPollInterval))
+}
@@ -0,0 +1,50 @@
+package server
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/retention"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+const (
+	defaultRestoreWindow          = 7 * 24 * time.Hour
+	defaultRetentionPurgeAge      = 30 * 24 * time.Hour
+	defaultRetentionPurgeInterval = time.Hour
+)
+
+func getDuration(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Incorrect %s: %s. %s will be used.", name, value, defaultValue)
+		return defaultValue
+	}
+
+	return duration
+}
+
+// getDeletionPolicy reads the restore window from RESTORE_WINDOW.
+func getDeletionPolicy() service.DeletionPolicy {
+	return service.DeletionPolicy{
+		RestoreWindow: getDuration("RESTORE_WINDOW", defaultRestoreWindow),
+	}
+}
+
+// createPurger removes deleted posts and comments older than RETENTION_PURGE_AGE every RETENTION_PURGE_INTERVAL.
+func createPurger(storageAccessor storage.Accessor, deletionPolicy service.DeletionPolicy) *retention.Purger {
+	purgeAge := getDuration("RETENTION_PURGE_AGE", defaultRetentionPurgeAge)
+	if purgeAge < deletionPolicy.RestoreWindow {
+		log.Printf("%s (%s) is shorter than %s (%s), deleted content may be purged before it can no longer be restored.",
+			"RETENTION_PURGE_AGE", purgeAge, "RESTORE_WINDOW", deletionPolicy.RestoreWindow)
+	}
+
+	return retention.NewPurger(storageAccessor, purgeAge, getDuration("RETENTION_PURGE_INTERVAL", defaultRetentionPurgeInterval))
+}
@@ -0,0 +1,105 @@
+package transfer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/archive"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// Export writes posts and comments of the storage as JSON Lines:
+//
+//	export [-output file] [-gzip] [-post-id id] [-created-after time] [-created-before time]
+//
+// The output is gzip-compressed if -gzip is set or the file name ends with ".gz".
+func Export(storageAccessor storage.Accessor, args []string) {
+	defer storageAccessor.CloseStorage()
+
+	var filter storage.ExportFilter
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	output := flags.String("output", "-", "Set the output file, '-' for the standard output")
+	compress := flags.Bool("gzip", false, "Compress the output with gzip")
+	flags.Func("post-id", "Export only the post with the ID", func(value string) error {
+		postID, err := strconv.ParseInt(value, 10, 64)
+		filter.PostID = &postID
+		return err
+	})
+	flags.Func("created-after", "Export only posts created after the time (RFC 3339)", func(value string) error {
+		return parseTime(value, &filter.CreatedAfter)
+	})
+	flags.Func("created-before", "Export only posts created before the time (RFC 3339)", func(value string) error {
+		return parseTime(value, &filter.CreatedBefore)
+	})
+	flags.Parse(args)
+
+	writer, closeWriter, err := createWriter(*output, *compress || strings.HasSuffix(*output, ".gz"))
+	if err != nil {
+		log.Fatalf("Error while opening the output: %s", err)
+	}
+
+	stats, err := archive.Export(context.Background(), storageAccessor, writer, &filter)
+	if err != nil {
+		log.Fatalf("Error while exporting posts and comments: %s", err)
+	}
+
+	if err = closeWriter(); err != nil {
+		log.Fatalf("Error while writing the output: %s", err)
+	}
+
+	log.Printf("%d posts and %d comments were exported.", stats.Posts, stats.Comments)
+}
+
+func parseTime(value string, target **time.Time) error {
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return err
+	}
+
+	*target = &parsed
+	return nil
+}
+
+// createWriter opens the buffered, optionally compressed output. The returned function flushes and closes it.
+func createWriter(output string, compress bool) (io.Writer, func() error, error) {
+	file := os.Stdout
+	if output != "-" {
+		var err error
+		file, err = os.Create(output)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	buffered := bufio.NewWriter(file)
+	closers := []func() error{buffered.Flush}
+	if file != os.Stdout {
+		closers = append(closers, file.Close)
+	}
+
+	var writer io.Writer = buffered
+	if compress {
+		gzipWriter := gzip.NewWriter(buffered)
+		closers = append([]func() error{gzipWriter.Close}, closers...)
+		writer = gzipWriter
+	}
+
+	closeWriter := func() error {
+		for _, closer := range closers {
+			if err := closer(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return writer, closeWriter, nil
+}
@@ -0,0 +1,91 @@
+package transfer
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"github.com/C-4KE/simple-posts-service/internal/archive"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Import adds posts and comments from JSON Lines written by export to the storage:
+//
+//	import [-input file] [-source name] [-dry-run]
+//
+// Compressed input is detected automatically. Records already imported from the same source are skipped.
+func Import(storageAccessor storage.Accessor, args []string) {
+	defer storageAccessor.CloseStorage()
+
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	input := flags.String("input", "-", "Set the input file, '-' for the standard input")
+	source := flags.String("source", "default", "Set the name of the source, which identifies already imported records")
+	dryRun := flags.Bool("dry-run", false, "Report what would be imported without changing the storage")
+	flags.Parse(args)
+
+	reader, closeReader, err := createReader(*input)
+	if err != nil {
+		log.Fatalf("Error while opening the input: %s", err)
+	}
+	defer closeReader()
+
+	stats, err := archive.Import(context.Background(), storageAccessor, reader, *source, *dryRun)
+	if err != nil {
+		log.Fatalf("Error while importing posts and comments: %s", err)
+	}
+
+	if *dryRun {
+		log.Printf("%d posts and %d comments would be imported, %d posts and %d comments were imported before.",
+			stats.Posts, stats.Comments, stats.SkippedPosts, stats.SkippedComments)
+		return
+	}
+
+	log.Printf("%d posts and %d comments were imported, %d posts and %d comments were imported before.",
+		stats.Posts, stats.Comments, stats.SkippedPosts, stats.SkippedComments)
+}
+
+// createReader opens the buffered input, decompressing it if it starts as a gzip stream. The returned function closes it.
+func createReader(input string) (io.Reader, func() error, error) {
+	file := os.Stdin
+	if input != "-" {
+		var err error
+		file, err = os.Open(input)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	closeReader := func() error {
+		if file != os.Stdin {
+			return file.Close()
+		}
+		return nil
+	}
+
+	buffered := bufio.NewReader(file)
+	magic, err := buffered.Peek(len(gzipMagic))
+	if err != nil && err != io.EOF {
+		closeReader()
+		return nil, nil, err
+	}
+
+	if !bytes.Equal(magic, gzipMagic) {
+		return buffered, closeReader, nil
+	}
+
+	gzipReader, err := gzip.NewReader(buffered)
+	if err != nil {
+		closeReader()
+		return nil, nil, err
+	}
+
+	return gzipReader, closeReader, nil
+}
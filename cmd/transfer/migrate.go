@@ -0,0 +1,105 @@
+package transfer
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/C-4KE/simple-posts-service/internal/migration"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+)
+
+const (
+	storagePostgres       = "postgres"
+	storageMemorySnapshot = "memory-snapshot"
+)
+
+// MigrateStorage copies posts and comments between Postgres and a memory snapshot, then verifies the copy:
+//
+//	migrate-storage -from memory-snapshot -to postgres -snapshot file [-source name]
+//	migrate-storage -from postgres -to memory-snapshot -snapshot file [-posts-and-comments-only]
+//
+// Only posts and comments are copied. The command fails when the source keeps other records (users, edits, reactions
+// and others) unless -posts-and-comments-only confirms that they are left behind.
+// Copying into Postgres can be resumed by running the command again with the same source name.
+// A memory snapshot is written only after the copy is complete and verified.
+func MigrateStorage(openPostgres func() (storage.Accessor, error), args []string) {
+	flags := flag.NewFlagSet("migrate-storage", flag.ExitOnError)
+	from := flags.String("from", storageMemorySnapshot, "Set the storage to copy from: 'memory-snapshot' or 'postgres'")
+	to := flags.String("to", storagePostgres, "Set the storage to copy to: 'memory-snapshot' or 'postgres'")
+	snapshot := flags.String("snapshot", "", "Set the memory snapshot file, an archive written by export")
+	source := flags.String("source", "", "Set the name copies are remembered under in the target, the -from value by default")
+	postsAndCommentsOnly := flags.Bool("posts-and-comments-only", false, "Copy posts and comments even if the source keeps other records, which are left behind")
+	flags.Parse(args)
+
+	if *snapshot == "" {
+		log.Fatalf("The memory snapshot file is not set.")
+	}
+
+	if *source == "" {
+		*source = *from
+	}
+
+	postgresAccessor, err := openPostgres()
+	if err != nil {
+		log.Fatalf("Error while initializing storage: %s", err)
+	}
+	defer postgresAccessor.CloseStorage()
+
+	var fromAccessor, toAccessor storage.Accessor
+	var snapshotAccessor *inmemory.InMemoryAccessor
+	switch {
+	case *from == storageMemorySnapshot && *to == storagePostgres:
+		snapshotAccessor, err = LoadSnapshot(*snapshot)
+		if err != nil {
+			log.Fatalf("Error while loading the memory snapshot: %s", err)
+		}
+		fromAccessor, toAccessor = snapshotAccessor, postgresAccessor
+
+	case *from == storagePostgres && *to == storageMemorySnapshot:
+		snapshotAccessor = inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		fromAccessor, toAccessor = postgresAccessor, snapshotAccessor
+
+	default:
+		log.Fatalf("Unsupported migration from %s to %s.", *from, *to)
+	}
+	defer snapshotAccessor.CloseStorage()
+
+	ctx := context.Background()
+	migrator := migration.NewMigrator(fromAccessor, toAccessor, *source, func(progress migration.Progress) {
+		log.Printf("%d posts and %d comments were copied, %d posts and %d comments were copied before.",
+			progress.Posts, progress.Comments, progress.SkippedPosts, progress.SkippedComments)
+	})
+
+	if err = migrator.CheckSource(ctx); err != nil {
+		if !*postsAndCommentsOnly {
+			log.Fatalf("%s Run the command with -posts-and-comments-only to copy posts and comments without them.", err)
+		}
+
+		log.Printf("%s They will be left behind.", err)
+	}
+
+	if _, err = migrator.Copy(ctx); err != nil {
+		log.Fatalf("Error while copying posts and comments, run the command again to resume: %s", err)
+	}
+
+	report, err := migrator.Verify(ctx)
+	if err != nil {
+		log.Fatalf("Error while verifying the copy: %s", err)
+	}
+
+	log.Printf("Source: %d posts, %d comments, checksum %s.", report.Source.Posts, report.Source.Comments, report.Source.Checksum)
+	log.Printf("Target: %d posts, %d comments, checksum %s.", report.Target.Posts, report.Target.Comments, report.Target.Checksum)
+	if !report.Matches() {
+		log.Fatalf("The copy does not match the source.")
+	}
+
+	if *to == storageMemorySnapshot {
+		if err = saveSnapshot(snapshotAccessor, *snapshot); err != nil {
+			log.Fatalf("Error while saving the memory snapshot: %s", err)
+		}
+	}
+
+	log.Printf("Migration from %s to %s was completed.", *from, *to)
+}
@@ -0,0 +1,65 @@
+package transfer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/C-4KE/simple-posts-service/internal/archive"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+)
+
+// snapshotSource names the records restored from a memory snapshot.
+const snapshotSource = "memory-snapshot"
+
+// LoadSnapshot restores the in-memory storage from the memory snapshot, which is an archive written by export.
+// Posts and comments keep their IDs from the snapshot, so every restore of the same snapshot has the same IDs.
+func LoadSnapshot(path string) (*inmemory.InMemoryAccessor, error) {
+	reader, closeReader, err := createReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeReader()
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	accessor.KeepImportedIDs(true)
+	defer accessor.KeepImportedIDs(false)
+
+	if _, err = archive.Import(context.Background(), accessor, reader, snapshotSource, false); err != nil {
+		return nil, err
+	}
+
+	return accessor, nil
+}
+
+// saveSnapshot writes the in-memory storage to the memory snapshot. The snapshot is replaced only when it is written
+// completely, so a failure keeps the previous snapshot.
+func saveSnapshot(accessor *inmemory.InMemoryAccessor, path string) error {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	temporaryPath := file.Name()
+	defer os.Remove(temporaryPath)
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	writer, closeWriter, err := createWriter(temporaryPath, strings.HasSuffix(path, ".gz"))
+	if err != nil {
+		return err
+	}
+
+	if _, err = archive.Export(context.Background(), accessor, writer, nil); err != nil {
+		closeWriter()
+		return err
+	}
+
+	if err = closeWriter(); err != nil {
+		return err
+	}
+
+	return os.Rename(temporaryPath, path)
+}
@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// HasRole implements the @hasRole directive: the field is resolved only for principals with the required role.
+func HasRole(ctx context.Context, obj any, next graphql.Resolver, role model.Role) (any, error) {
+	if err := authorization.Require(authorization.PrincipalFromContext(ctx), role); err != nil {
+		return nil, err
+	}
+
+	return next(ctx)
+}
+
+// PresentError adds the error code to errors of access checks, so clients can tell them from other errors.
+func PresentError(ctx context.Context, err error) *gqlerror.Error {
+	presented := graphql.DefaultErrorPresenter(ctx, err)
+
+	if errors.Is(err, authorization.ErrForbidden) {
+		if presented.Extensions == nil {
+			presented.Extensions = make(map[string]any)
+		}
+
+		presented.Extensions["code"] = authorization.ErrorCodeForbidden
+	}
+
+	return presented
+}
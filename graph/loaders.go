@@ -0,0 +1,90 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/loader"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+const loadersWait = 2 * time.Millisecond
+
+type loadersKey struct{}
+
+type viewerReactionKey struct {
+	targetID int64
+	viewerID uuid.UUID
+}
+
+// Loaders batches storage calls made by field resolvers of the same request.
+type Loaders struct {
+	postReactionCounts     *loader.Loader[int64, []*model.ReactionCount]
+	commentReactionCounts  *loader.Loader[int64, []*model.ReactionCount]
+	postViewerReactions    *loader.Loader[viewerReactionKey, []model.ReactionKind]
+	commentViewerReactions *loader.Loader[viewerReactionKey, []model.ReactionKind]
+	users                  *loader.Loader[uuid.UUID, *model.User]
+	postTags               *loader.Loader[int64, []string]
+	commentsPinned         *loader.Loader[int64, bool]
+}
+
+func NewLoaders(accessor storage.Accessor) *Loaders {
+	return &Loaders{
+		postReactionCounts:     loader.NewLoader(reactionCountsBatch(accessor, model.ReactionTargetPost), loadersWait),
+		commentReactionCounts:  loader.NewLoader(reactionCountsBatch(accessor, model.ReactionTargetComment), loadersWait),
+		postViewerReactions:    loader.NewLoader(viewerReactionsBatch(accessor, model.ReactionTargetPost), loadersWait),
+		commentViewerReactions: loader.NewLoader(viewerReactionsBatch(accessor, model.ReactionTargetComment), loadersWait),
+		users:                  loader.NewLoader(accessor.GetUsers, loadersWait),
+		postTags:               loader.NewLoader(accessor.GetPostsTags, loadersWait),
+		commentsPinned:         loader.NewLoader(accessor.GetCommentsPinned, loadersWait),
+	}
+}
+
+// LoadersMiddleware attaches a fresh set of loaders to every request, so cached values never outlive it.
+func LoadersMiddleware(accessor storage.Accessor, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersKey{}, NewLoaders(accessor))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (r *Resolver) loaders(ctx context.Context) *Loaders {
+	loaders, ok := ctx.Value(loadersKey{}).(*Loaders)
+	if !ok {
+		return NewLoaders(r.storageAccessor)
+	}
+
+	return loaders
+}
+
+func reactionCountsBatch(accessor storage.Accessor, targetType model.ReactionTarget) loader.BatchFunc[int64, []*model.ReactionCount] {
+	return func(ctx context.Context, targetIDs []int64) (map[int64][]*model.ReactionCount, error) {
+		return accessor.GetReactionCounts(ctx, targetType, targetIDs)
+	}
+}
+
+func viewerReactionsBatch(accessor storage.Accessor, targetType model.ReactionTarget) loader.BatchFunc[viewerReactionKey, []model.ReactionKind] {
+	return func(ctx context.Context, keys []viewerReactionKey) (map[viewerReactionKey][]model.ReactionKind, error) {
+		targetIDsByViewer := make(map[uuid.UUID][]int64)
+		for _, key := range keys {
+			targetIDsByViewer[key.viewerID] = append(targetIDsByViewer[key.viewerID], key.targetID)
+		}
+
+		viewerReactions := make(map[viewerReactionKey][]model.ReactionKind, len(keys))
+		for viewerID, targetIDs := range targetIDsByViewer {
+			kinds, err := accessor.GetViewerReactions(ctx, targetType, targetIDs, viewerID)
+			if err != nil {
+				return nil, err
+			}
+
+			for targetID, targetKinds := range kinds {
+				viewerReactions[viewerReactionKey{targetID: targetID, viewerID: viewerID}] = targetKinds
+			}
+		}
+
+		return viewerReactions, nil
+	}
+}
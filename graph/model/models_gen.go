@@ -3,19 +3,50 @@
 package model
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Ban struct {
+	ID          int64      `json:"id"`
+	UserID      uuid.UUID  `json:"userID"`
+	PostID      *int64     `json:"postID,omitempty"`
+	ModeratorID uuid.UUID  `json:"moderatorID"`
+	Reason      *string    `json:"reason,omitempty"`
+	CreateDate  time.Time  `json:"createDate"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty"`
+}
+
+type BanInput struct {
+	UserID    uuid.UUID  `json:"userID"`
+	PostID    *int64     `json:"postID,omitempty"`
+	Reason    *string    `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
 type Comment struct {
-	ID         int64               `json:"id"`
-	AuthorID   uuid.UUID           `json:"authorID"`
-	PostID     int64               `json:"postID"`
-	ParentID   *int64              `json:"parentID,omitempty"`
-	Text       string              `json:"text"`
-	CreateDate time.Time           `json:"createDate"`
-	Replies    *CommentsConnection `json:"replies"`
+	ID             int64                `json:"id"`
+	AuthorID       uuid.UUID            `json:"authorID"`
+	Author         *User                `json:"author,omitempty"`
+	PostID         int64                `json:"postID"`
+	ParentID       *int64               `json:"parentID,omitempty"`
+	Text           string               `json:"text"`
+	TextHTML       string               `json:"textHtml"`
+	CreateDate     time.Time            `json:"createDate"`
+	Status         CommentStatus        `json:"status"`
+	ReplyCount     int32                `json:"replyCount"`
+	DeletedAt      *time.Time           `json:"deletedAt,omitempty"`
+	IsPinned       bool                 `json:"isPinned"`
+	Replies        *CommentsConnection  `json:"replies"`
+	ReactionCounts []*ReactionCount     `json:"reactionCounts"`
+	ViewerReaction []ReactionKind       `json:"viewerReaction"`
+	Revisions      *RevisionsConnection `json:"revisions"`
+	RevisionDiff   *RevisionDiff        `json:"revisionDiff,omitempty"`
 }
 
 type CommentEdge struct {
@@ -30,35 +61,877 @@ type CommentInput struct {
 	Text     string    `json:"text"`
 }
 
+type CommentSearchConnection struct {
+	Edges    []*CommentSearchEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+}
+
+type CommentSearchEdge struct {
+	Node    *Comment `json:"node"`
+	Cursor  string   `json:"cursor"`
+	Rank    float64  `json:"rank"`
+	Snippet string   `json:"snippet"`
+}
+
 type CommentsConnection struct {
-	Edges    []*CommentEdge `json:"edges"`
-	PageInfo *PageInfo      `json:"pageInfo"`
+	Edges      []*CommentEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount int32          `json:"totalCount"`
+}
+
+type DiffLine struct {
+	Operation DiffOperation `json:"operation"`
+	Text      string        `json:"text"`
 }
 
 type Mutation struct {
 }
 
+type Notification struct {
+	ID         int64            `json:"id"`
+	UserID     uuid.UUID        `json:"userID"`
+	Kind       NotificationKind `json:"kind"`
+	PostID     int64            `json:"postID"`
+	CommentID  *int64           `json:"commentID,omitempty"`
+	ActorID    uuid.UUID        `json:"actorID"`
+	CreateDate time.Time        `json:"createDate"`
+	Read       bool             `json:"read"`
+}
+
+type NotificationEdge struct {
+	Node   *Notification `json:"node"`
+	Cursor string        `json:"cursor"`
+}
+
+type NotificationsConnection struct {
+	Edges    []*NotificationEdge `json:"edges"`
+	PageInfo *PageInfo           `json:"pageInfo"`
+}
+
 type PageInfo struct {
 	HasNextPage bool    `json:"hasNextPage"`
 	EndCursor   *string `json:"endCursor,omitempty"`
 }
 
 type Post struct {
-	ID              int64               `json:"id"`
-	AuthorID        uuid.UUID           `json:"authorID"`
-	Title           string              `json:"title"`
-	Text            string              `json:"text"`
-	CreateDate      time.Time           `json:"createDate"`
-	CommentsEnabled bool                `json:"commentsEnabled"`
-	Comments        *CommentsConnection `json:"comments"`
+	ID              int64                `json:"id"`
+	AuthorID        uuid.UUID            `json:"authorID"`
+	Author          *User                `json:"author,omitempty"`
+	Title           string               `json:"title"`
+	Text            string               `json:"text"`
+	TextHTML        string               `json:"textHtml"`
+	CreateDate      time.Time            `json:"createDate"`
+	CommentsEnabled bool                 `json:"commentsEnabled"`
+	ModerationMode  ModerationMode       `json:"moderationMode"`
+	Status          PostStatus           `json:"status"`
+	PublishAt       *time.Time           `json:"publishAt,omitempty"`
+	CommentCount    int32                `json:"commentCount"`
+	DeletedAt       *time.Time           `json:"deletedAt,omitempty"`
+	Tags            []string             `json:"tags"`
+	Comments        *CommentsConnection  `json:"comments"`
+	ReactionCounts  []*ReactionCount     `json:"reactionCounts"`
+	ViewerReaction  []ReactionKind       `json:"viewerReaction"`
+	Revisions       *RevisionsConnection `json:"revisions"`
+	RevisionDiff    *RevisionDiff        `json:"revisionDiff,omitempty"`
+}
+
+type PostEdge struct {
+	Node   *Post  `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+type PostEditInput struct {
+	Title *string `json:"title,omitempty"`
+	Text  *string `json:"text,omitempty"`
 }
 
 type PostInput struct {
-	AuthorID        uuid.UUID `json:"authorID"`
-	Title           string    `json:"title"`
-	Text            string    `json:"text"`
-	CommentsEnabled bool      `json:"commentsEnabled"`
+	AuthorID        uuid.UUID       `json:"authorID"`
+	Title           string          `json:"title"`
+	Text            string          `json:"text"`
+	CommentsEnabled bool            `json:"commentsEnabled"`
+	ModerationMode  *ModerationMode `json:"moderationMode,omitempty"`
+	MaxReplyDepth   *int32          `json:"maxReplyDepth,omitempty"`
+	Status          *PostStatus     `json:"status,omitempty"`
+	PublishAt       *time.Time      `json:"publishAt,omitempty"`
+}
+
+type PostSearchConnection struct {
+	Edges    []*PostSearchEdge `json:"edges"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type PostSearchEdge struct {
+	Node    *Post   `json:"node"`
+	Cursor  string  `json:"cursor"`
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+type PostsConnection struct {
+	Edges    []*PostEdge `json:"edges"`
+	PageInfo *PageInfo   `json:"pageInfo"`
+}
+
+type PostsFilter struct {
+	AuthorID        *uuid.UUID `json:"authorID,omitempty"`
+	CreatedAfter    *time.Time `json:"createdAfter,omitempty"`
+	CreatedBefore   *time.Time `json:"createdBefore,omitempty"`
+	CommentsEnabled *bool      `json:"commentsEnabled,omitempty"`
+	Tag             *string    `json:"tag,omitempty"`
 }
 
 type Query struct {
 }
+
+type ReactionCount struct {
+	Kind  ReactionKind `json:"kind"`
+	Count int32        `json:"count"`
+}
+
+type ReactionInput struct {
+	TargetType ReactionTarget `json:"targetType"`
+	TargetID   int64          `json:"targetID"`
+	UserID     uuid.UUID      `json:"userID"`
+	Kind       ReactionKind   `json:"kind"`
+}
+
+type Revision struct {
+	ID         int64     `json:"id"`
+	Number     int32     `json:"number"`
+	EditorID   uuid.UUID `json:"editorID"`
+	Editor     *User     `json:"editor,omitempty"`
+	Title      *string   `json:"title,omitempty"`
+	Text       string    `json:"text"`
+	CreateDate time.Time `json:"createDate"`
+}
+
+type RevisionDiff struct {
+	From  int32       `json:"from"`
+	To    int32       `json:"to"`
+	Title []*DiffLine `json:"title"`
+	Text  []*DiffLine `json:"text"`
+}
+
+type RevisionEdge struct {
+	Node   *Revision `json:"node"`
+	Cursor string    `json:"cursor"`
+}
+
+type RevisionsConnection struct {
+	Edges    []*RevisionEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type Subscription struct {
+}
+
+type User struct {
+	ID          uuid.UUID           `json:"id"`
+	Username    *string             `json:"username,omitempty"`
+	DisplayName string              `json:"displayName"`
+	AvatarURL   *string             `json:"avatarURL,omitempty"`
+	Role        Role                `json:"role"`
+	CreateDate  time.Time           `json:"createDate"`
+	Posts       *PostsConnection    `json:"posts"`
+	Comments    *CommentsConnection `json:"comments"`
+}
+
+type UserInput struct {
+	ID          *uuid.UUID `json:"id,omitempty"`
+	Username    *string    `json:"username,omitempty"`
+	DisplayName string     `json:"displayName"`
+	AvatarURL   *string    `json:"avatarURL,omitempty"`
+}
+
+type UserUpdateInput struct {
+	Username    *string `json:"username,omitempty"`
+	DisplayName *string `json:"displayName,omitempty"`
+	AvatarURL   *string `json:"avatarURL,omitempty"`
+}
+
+type WebhookDeliveriesConnection struct {
+	Edges    []*WebhookDeliveryEdge `json:"edges"`
+	PageInfo *PageInfo              `json:"pageInfo"`
+}
+
+type WebhookDelivery struct {
+	ID              int64                 `json:"id"`
+	SubscriptionID  int64                 `json:"subscriptionID"`
+	EventID         int64                 `json:"eventID"`
+	EventKind       EventKind             `json:"eventKind"`
+	Status          WebhookDeliveryStatus `json:"status"`
+	Attempts        int32                 `json:"attempts"`
+	LastStatusCode  *int32                `json:"lastStatusCode,omitempty"`
+	LastError       *string               `json:"lastError,omitempty"`
+	NextAttemptDate time.Time             `json:"nextAttemptDate"`
+	CreateDate      time.Time             `json:"createDate"`
+	DeliveryDate    *time.Time            `json:"deliveryDate,omitempty"`
+}
+
+type WebhookDeliveryEdge struct {
+	Node   *WebhookDelivery `json:"node"`
+	Cursor string           `json:"cursor"`
+}
+
+type WebhookSubscription struct {
+	ID         int64       `json:"id"`
+	OwnerID    uuid.UUID   `json:"ownerID"`
+	URL        string      `json:"url"`
+	EventKinds []EventKind `json:"eventKinds"`
+	CreateDate time.Time   `json:"createDate"`
+}
+
+type WebhookSubscriptionInput struct {
+	OwnerID    uuid.UUID   `json:"ownerID"`
+	URL        string      `json:"url"`
+	EventKinds []EventKind `json:"eventKinds"`
+	Secret     string      `json:"secret"`
+}
+
+type CommentStatus string
+
+const (
+	CommentStatusPending  CommentStatus = "PENDING"
+	CommentStatusApproved CommentStatus = "APPROVED"
+	CommentStatusRejected CommentStatus = "REJECTED"
+)
+
+var AllCommentStatus = []CommentStatus{
+	CommentStatusPending,
+	CommentStatusApproved,
+	CommentStatusRejected,
+}
+
+func (e CommentStatus) IsValid() bool {
+	switch e {
+	case CommentStatusPending, CommentStatusApproved, CommentStatusRejected:
+		return true
+	}
+	return false
+}
+
+func (e CommentStatus) String() string {
+	return string(e)
+}
+
+func (e *CommentStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentStatus", str)
+	}
+	return nil
+}
+
+func (e CommentStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CommentStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CommentStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type CommentsOrder string
+
+const (
+	CommentsOrderOldest CommentsOrder = "OLDEST"
+	CommentsOrderNewest CommentsOrder = "NEWEST"
+	CommentsOrderTop    CommentsOrder = "TOP"
+)
+
+var AllCommentsOrder = []CommentsOrder{
+	CommentsOrderOldest,
+	CommentsOrderNewest,
+	CommentsOrderTop,
+}
+
+func (e CommentsOrder) IsValid() bool {
+	switch e {
+	case CommentsOrderOldest, CommentsOrderNewest, CommentsOrderTop:
+		return true
+	}
+	return false
+}
+
+func (e CommentsOrder) String() string {
+	return string(e)
+}
+
+func (e *CommentsOrder) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CommentsOrder(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CommentsOrder", str)
+	}
+	return nil
+}
+
+func (e CommentsOrder) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CommentsOrder) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CommentsOrder) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type DiffOperation string
+
+const (
+	DiffOperationEqual  DiffOperation = "EQUAL"
+	DiffOperationInsert DiffOperation = "INSERT"
+	DiffOperationDelete DiffOperation = "DELETE"
+)
+
+var AllDiffOperation = []DiffOperation{
+	DiffOperationEqual,
+	DiffOperationInsert,
+	DiffOperationDelete,
+}
+
+func (e DiffOperation) IsValid() bool {
+	switch e {
+	case DiffOperationEqual, DiffOperationInsert, DiffOperationDelete:
+		return true
+	}
+	return false
+}
+
+func (e DiffOperation) String() string {
+	return string(e)
+}
+
+func (e *DiffOperation) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiffOperation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiffOperation", str)
+	}
+	return nil
+}
+
+func (e DiffOperation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiffOperation) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiffOperation) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type EventKind string
+
+const (
+	EventKindPostCreated          EventKind = "POST_CREATED"
+	EventKindPostEdited           EventKind = "POST_EDITED"
+	EventKindCommentAdded         EventKind = "COMMENT_ADDED"
+	EventKindCommentStatusChanged EventKind = "COMMENT_STATUS_CHANGED"
+	EventKindCommentsToggled      EventKind = "COMMENTS_TOGGLED"
+	EventKindCommentEdited        EventKind = "COMMENT_EDITED"
+	EventKindPostDeleted          EventKind = "POST_DELETED"
+	EventKindPostRestored         EventKind = "POST_RESTORED"
+	EventKindCommentDeleted       EventKind = "COMMENT_DELETED"
+	EventKindCommentRestored      EventKind = "COMMENT_RESTORED"
+	EventKindCommentPinToggled    EventKind = "COMMENT_PIN_TOGGLED"
+	EventKindPostPublished        EventKind = "POST_PUBLISHED"
+	EventKindReactionToggled      EventKind = "REACTION_TOGGLED"
+)
+
+var AllEventKind = []EventKind{
+	EventKindPostCreated,
+	EventKindPostEdited,
+	EventKindCommentAdded,
+	EventKindCommentStatusChanged,
+	EventKindCommentsToggled,
+	EventKindCommentEdited,
+	EventKindPostDeleted,
+	EventKindPostRestored,
+	EventKindCommentDeleted,
+	EventKindCommentRestored,
+	EventKindCommentPinToggled,
+	EventKindPostPublished,
+	EventKindReactionToggled,
+}
+
+func (e EventKind) IsValid() bool {
+	switch e {
+	case EventKindPostCreated, EventKindPostEdited, EventKindCommentAdded, EventKindCommentStatusChanged, EventKindCommentsToggled, EventKindCommentEdited, EventKindPostDeleted, EventKindPostRestored, EventKindCommentDeleted, EventKindCommentRestored, EventKindCommentPinToggled, EventKindPostPublished, EventKindReactionToggled:
+		return true
+	}
+	return false
+}
+
+func (e EventKind) String() string {
+	return string(e)
+}
+
+func (e *EventKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EventKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EventKind", str)
+	}
+	return nil
+}
+
+func (e EventKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *EventKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e EventKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ModerationMode string
+
+const (
+	ModerationModeOpen         ModerationMode = "OPEN"
+	ModerationModePremoderated ModerationMode = "PREMODERATED"
+	ModerationModeClosed       ModerationMode = "CLOSED"
+)
+
+var AllModerationMode = []ModerationMode{
+	ModerationModeOpen,
+	ModerationModePremoderated,
+	ModerationModeClosed,
+}
+
+func (e ModerationMode) IsValid() bool {
+	switch e {
+	case ModerationModeOpen, ModerationModePremoderated, ModerationModeClosed:
+		return true
+	}
+	return false
+}
+
+func (e ModerationMode) String() string {
+	return string(e)
+}
+
+func (e *ModerationMode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ModerationMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ModerationMode", str)
+	}
+	return nil
+}
+
+func (e ModerationMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ModerationMode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ModerationMode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type NotificationKind string
+
+const (
+	NotificationKindReply   NotificationKind = "REPLY"
+	NotificationKindMention NotificationKind = "MENTION"
+)
+
+var AllNotificationKind = []NotificationKind{
+	NotificationKindReply,
+	NotificationKindMention,
+}
+
+func (e NotificationKind) IsValid() bool {
+	switch e {
+	case NotificationKindReply, NotificationKindMention:
+		return true
+	}
+	return false
+}
+
+func (e NotificationKind) String() string {
+	return string(e)
+}
+
+func (e *NotificationKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = NotificationKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid NotificationKind", str)
+	}
+	return nil
+}
+
+func (e NotificationKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *NotificationKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e NotificationKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PostStatus string
+
+const (
+	PostStatusDraft     PostStatus = "DRAFT"
+	PostStatusPublished PostStatus = "PUBLISHED"
+)
+
+var AllPostStatus = []PostStatus{
+	PostStatusDraft,
+	PostStatusPublished,
+}
+
+func (e PostStatus) IsValid() bool {
+	switch e {
+	case PostStatusDraft, PostStatusPublished:
+		return true
+	}
+	return false
+}
+
+func (e PostStatus) String() string {
+	return string(e)
+}
+
+func (e *PostStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PostStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PostStatus", str)
+	}
+	return nil
+}
+
+func (e PostStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PostStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PostStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReactionKind string
+
+const (
+	ReactionKindThumbsUp   ReactionKind = "THUMBS_UP"
+	ReactionKindThumbsDown ReactionKind = "THUMBS_DOWN"
+	ReactionKindHeart      ReactionKind = "HEART"
+	ReactionKindLaugh      ReactionKind = "LAUGH"
+	ReactionKindSurprised  ReactionKind = "SURPRISED"
+	ReactionKindSad        ReactionKind = "SAD"
+)
+
+var AllReactionKind = []ReactionKind{
+	ReactionKindThumbsUp,
+	ReactionKindThumbsDown,
+	ReactionKindHeart,
+	ReactionKindLaugh,
+	ReactionKindSurprised,
+	ReactionKindSad,
+}
+
+func (e ReactionKind) IsValid() bool {
+	switch e {
+	case ReactionKindThumbsUp, ReactionKindThumbsDown, ReactionKindHeart, ReactionKindLaugh, ReactionKindSurprised, ReactionKindSad:
+		return true
+	}
+	return false
+}
+
+func (e ReactionKind) String() string {
+	return string(e)
+}
+
+func (e *ReactionKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReactionKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReactionKind", str)
+	}
+	return nil
+}
+
+func (e ReactionKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReactionKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReactionKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ReactionTarget string
+
+const (
+	ReactionTargetPost    ReactionTarget = "POST"
+	ReactionTargetComment ReactionTarget = "COMMENT"
+)
+
+var AllReactionTarget = []ReactionTarget{
+	ReactionTargetPost,
+	ReactionTargetComment,
+}
+
+func (e ReactionTarget) IsValid() bool {
+	switch e {
+	case ReactionTargetPost, ReactionTargetComment:
+		return true
+	}
+	return false
+}
+
+func (e ReactionTarget) String() string {
+	return string(e)
+}
+
+func (e *ReactionTarget) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ReactionTarget(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ReactionTarget", str)
+	}
+	return nil
+}
+
+func (e ReactionTarget) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ReactionTarget) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ReactionTarget) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleUser      Role = "USER"
+	RoleModerator Role = "MODERATOR"
+	RoleAdmin     Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleUser,
+	RoleModerator,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleUser, RoleModerator, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "SUCCEEDED"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "DEAD"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusSucceeded,
+	WebhookDeliveryStatusDead,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusSucceeded, WebhookDeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,86 @@
+package graph
+
+import (
+	"context"
+	"log"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+)
+
+// ConsumeEvents publishes notifications to subscribers when a post or a comment becomes visible or is edited.
+// Events come from the outbox, so subscribers receive notifications of every committed change exactly once.
+func (r *Resolver) ConsumeEvents(ctx context.Context, envelopes <-chan *events.Envelope) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case envelope := <-envelopes:
+			event, err := envelope.Decode()
+			if err != nil {
+				log.Printf("Error while decoding the event with ID %d: %s", envelope.ID, err)
+				continue
+			}
+
+			switch event := event.(type) {
+			case *events.PostCreated:
+				// Published drafts are announced with POST_CREATED as well, so POST_PUBLISHED needs no notifications.
+				r.publishPostNotifications(ctx, event.PostID)
+			case *events.CommentAdded:
+				if event.Status == model.CommentStatusApproved {
+					r.publishNotifications(ctx, event.CommentID)
+				}
+			case *events.CommentStatusChanged:
+				if event.Status == model.CommentStatusApproved {
+					r.publishNotifications(ctx, event.CommentID)
+				}
+			case *events.PostEdited:
+				r.publishRevisionNotifications(ctx, event.PostID, nil, event.RevisionNumber)
+			case *events.CommentEdited:
+				r.publishRevisionNotifications(ctx, event.PostID, &event.CommentID, event.RevisionNumber)
+			}
+		}
+	}
+}
+
+// publishNotifications sends notifications created for the comment to subscribers.
+// The comment is already stored, so a failure here is only logged: notifications can still be queried.
+func (r *Resolver) publishNotifications(ctx context.Context, commentID int64) {
+	notifications, err := r.storageAccessor.GetCommentNotifications(ctx, commentID)
+	if err != nil {
+		log.Printf("Error while loading notifications for the comment with ID %d: %s", commentID, err)
+		return
+	}
+
+	for _, notification := range notifications {
+		r.notifications.Publish(notification)
+	}
+}
+
+// publishPostNotifications sends notifications about mentions in the text of the post to subscribers.
+func (r *Resolver) publishPostNotifications(ctx context.Context, postID int64) {
+	notifications, err := r.storageAccessor.GetPostNotifications(ctx, postID)
+	if err != nil {
+		log.Printf("Error while loading notifications for the post with ID %d: %s", postID, err)
+		return
+	}
+
+	for _, notification := range notifications {
+		r.notifications.Publish(notification)
+	}
+}
+
+// publishRevisionNotifications sends notifications about users mentioned by the edit to subscribers.
+// Users mentioned before the edit were already notified, so only notifications of the revision are loaded.
+func (r *Resolver) publishRevisionNotifications(ctx context.Context, postID int64, commentID *int64, revisionNumber int32) {
+	notifications, err := r.storageAccessor.GetRevisionNotifications(ctx, postID, commentID, revisionNumber)
+	if err != nil {
+		log.Printf("Error while loading notifications for the revision %d of the post with ID %d: %s", revisionNumber, postID, err)
+		return
+	}
+
+	for _, notification := range notifications {
+		r.notifications.Publish(notification)
+	}
+}
@@ -0,0 +1,408 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/google/uuid"
+)
+
+func (r *Resolver) getCommentsConnection(ctx context.Context, postID int64, commentsPath string, order model.CommentsOrder, first *int32, after *string) (*model.CommentsConnection, error) {
+	page, err := r.service.GetCommentsLevel(ctx, postID, commentsPath, order, after, first)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newCommentsConnection(ctx, postID, commentsPath, page)
+}
+
+// getPostCommentsConnection returns the first level of comments of the post, opened by pinned comments.
+func (r *Resolver) getPostCommentsConnection(ctx context.Context, postID int64, order model.CommentsOrder, first *int32, after *string) (*model.CommentsConnection, error) {
+	page, err := r.service.GetPostComments(ctx, postID, order, after, first)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newCommentsConnection(ctx, postID, strconv.FormatInt(postID, 10), page)
+}
+
+func (r *Resolver) newCommentsConnection(ctx context.Context, postID int64, commentsPath string, page *service.CommentsPage) (*model.CommentsConnection, error) {
+	edges := make([]*model.CommentEdge, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		edges = append(edges, &model.CommentEdge{
+			Node:   edge.Comment,
+			Cursor: edge.Cursor,
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	var totalCount int32
+	if isFieldRequested(ctx, "totalCount") {
+		var err error
+		totalCount, err = r.storageAccessor.GetCommentsCount(ctx, postID, commentsPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.CommentsConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: page.HasNextPage,
+			EndCursor:   &endCursor,
+		},
+		TotalCount: totalCount,
+	}, nil
+}
+
+// isFieldRequested reports whether the query selects the field of the object returned by the current resolver.
+func isFieldRequested(ctx context.Context, name string) bool {
+	for _, field := range graphql.CollectFieldsCtx(ctx, nil) {
+		if field.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Resolver) getPostsConnection(ctx context.Context, filter *model.PostsFilter, first *int32, after *string) (*model.PostsConnection, error) {
+	var afterCursor *cursor.PostCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParsePost(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := r.storageAccessor.GetPosts(ctx, filter, authorization.PrincipalFromContext(ctx).UserID, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, hasNextPage := trimPage(posts, first)
+
+	edges := make([]*model.PostEdge, 0, len(posts))
+	for _, post := range posts {
+		edges = append(edges, &model.PostEdge{
+			Node:   post,
+			Cursor: cursor.CreatePost(post.CreateDate.UnixNano(), post.ID),
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &model.PostsConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+func (r *Resolver) getAuthorCommentsConnection(ctx context.Context, authorID uuid.UUID, first *int32, after *string) (*model.CommentsConnection, error) {
+	var afterCursor *cursor.AuthorCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParseAuthor(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := r.storageAccessor.GetCommentsByAuthor(ctx, authorID, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, hasNextPage := trimPage(comments, first)
+
+	edges := make([]*model.CommentEdge, 0, len(comments))
+	for _, comment := range comments {
+		edges = append(edges, &model.CommentEdge{
+			Node:   comment,
+			Cursor: cursor.CreateAuthor(comment.CreateDate.UnixNano(), comment.ID),
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	var totalCount int32
+	if isFieldRequested(ctx, "totalCount") {
+		totalCount, err = r.storageAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &model.CommentsConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+		TotalCount: totalCount,
+	}, nil
+}
+
+func (r *Resolver) getNotificationsConnection(ctx context.Context, userID uuid.UUID, unreadOnly bool, first *int32, after *string) (*model.NotificationsConnection, error) {
+	var afterCursor *cursor.NotificationCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParseNotification(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := r.storageAccessor.GetNotifications(ctx, userID, unreadOnly, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, hasNextPage := trimPage(notifications, first)
+
+	edges := make([]*model.NotificationEdge, 0, len(notifications))
+	for _, notification := range notifications {
+		edges = append(edges, &model.NotificationEdge{
+			Node:   notification,
+			Cursor: cursor.CreateNotification(notification.ID),
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &model.NotificationsConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+func (r *Resolver) getWebhookDeliveriesConnection(ctx context.Context, subscriptionID int64, ownerID uuid.UUID, status *model.WebhookDeliveryStatus, first *int32, after *string) (*model.WebhookDeliveriesConnection, error) {
+	var afterCursor *cursor.DeliveryCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParseDelivery(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, err := r.storageAccessor.GetWebhookDeliveries(ctx, subscriptionID, ownerID, status, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries, hasNextPage := trimPage(deliveries, first)
+
+	edges := make([]*model.WebhookDeliveryEdge, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		edges = append(edges, &model.WebhookDeliveryEdge{
+			Node:   delivery,
+			Cursor: cursor.CreateDelivery(delivery.ID),
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &model.WebhookDeliveriesConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+func (r *Resolver) getPostSearchConnection(ctx context.Context, query string, first *int32, after *string) (*model.PostSearchConnection, error) {
+	afterCursor, limit, err := getSearchPagination(query, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := r.storageAccessor.SearchPosts(ctx, query, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, hasNextPage := trimPage(edges, first)
+
+	var endCursor string
+	for _, edge := range edges {
+		edge.Cursor = cursor.CreateSearch(edge.Rank, edge.Node.ID)
+		endCursor = edge.Cursor
+	}
+
+	return &model.PostSearchConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+func (r *Resolver) getCommentSearchConnection(ctx context.Context, postID int64, query string, first *int32, after *string) (*model.CommentSearchConnection, error) {
+	afterCursor, limit, err := getSearchPagination(query, first, after)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := r.storageAccessor.SearchComments(ctx, postID, query, afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, hasNextPage := trimPage(edges, first)
+
+	var endCursor string
+	for _, edge := range edges {
+		edge.Cursor = cursor.CreateSearch(edge.Rank, edge.Node.ID)
+		endCursor = edge.Cursor
+	}
+
+	return &model.CommentSearchConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+func getSearchPagination(query string, first *int32, after *string) (*cursor.SearchCursor, *int32, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, errors.New("Search query must not be empty.")
+	}
+
+	var afterCursor *cursor.SearchCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParseSearch(*after)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return afterCursor, limit, nil
+}
+
+// getRevisionsConnection builds a page of the history of a post or a comment loaded by loadRevisions.
+func getRevisionsConnection(first *int32, after *string, loadRevisions func(afterCursor *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error)) (*model.RevisionsConnection, error) {
+	var afterCursor *cursor.RevisionCursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.ParseRevision(*after)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := getPageLimit(first)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := loadRevisions(afterCursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, hasNextPage := trimPage(revisions, first)
+
+	edges := make([]*model.RevisionEdge, 0, len(revisions))
+	for _, revision := range revisions {
+		edges = append(edges, &model.RevisionEdge{
+			Node:   revision,
+			Cursor: cursor.CreateRevision(revision.Number),
+		})
+	}
+
+	var endCursor string
+	if len(edges) > 0 {
+		endCursor = edges[len(edges)-1].Cursor
+	}
+
+	return &model.RevisionsConnection{
+		Edges: edges,
+		PageInfo: &model.PageInfo{
+			HasNextPage: hasNextPage,
+			EndCursor:   &endCursor,
+		},
+	}, nil
+}
+
+// getPageLimit asks storage for one extra item, which shows whether there is a next page.
+func getPageLimit(first *int32) (*int32, error) {
+	if first == nil {
+		return nil, nil
+	}
+
+	if *first < 0 {
+		return nil, errors.New("Amount of items must not be negative, got " + strconv.FormatInt(int64(*first), 10) + ".")
+	}
+
+	limit := *first + 1
+	return &limit, nil
+}
+
+func trimPage[itemType any](items []itemType, first *int32) ([]itemType, bool) {
+	if first != nil && len(items) > int(*first) {
+		return items[:*first], true
+	}
+
+	return items, false
+}
@@ -1,6 +1,11 @@
 package graph
 
-import "github.com/C-4KE/simple-posts-service/internal/storage"
+import (
+	"github.com/C-4KE/simple-posts-service/internal/markdown"
+	"github.com/C-4KE/simple-posts-service/internal/notify"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
 
 // This file will not be regenerated automatically.
 //
@@ -9,10 +14,16 @@ import "github.com/C-4KE/simple-posts-service/internal/storage"
 
 type Resolver struct {
 	storageAccessor storage.Accessor
+	service         *service.Service
+	notifications   *notify.Broker
+	markdown        *markdown.Renderer
 }
 
-func NewResolver(accessor storage.Accessor) *Resolver {
+func NewResolver(accessor storage.Accessor, postsService *service.Service, renderer *markdown.Renderer) *Resolver {
 	return &Resolver{
 		storageAccessor: accessor,
+		service:         postsService,
+		notifications:   notify.NewBroker(),
+		markdown:        renderer,
 	}
 }
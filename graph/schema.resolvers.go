@@ -7,66 +7,233 @@ package graph
 
 import (
 	"context"
-	"errors"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
 	"github.com/C-4KE/simple-posts-service/internal/cursor"
 	"github.com/google/uuid"
 )
 
+// Author is the resolver for the author field.
+func (r *commentResolver) Author(ctx context.Context, obj *model.Comment) (*model.User, error) {
+	if obj.AuthorID == uuid.Nil {
+		return nil, nil
+	}
+
+	return r.loaders(ctx).users.Load(ctx, obj.AuthorID)
+}
+
+// TextHTML is the resolver for the textHtml field.
+func (r *commentResolver) TextHTML(ctx context.Context, obj *model.Comment) (string, error) {
+	return r.markdown.RenderHTML(obj.Text), nil
+}
+
+// IsPinned is the resolver for the isPinned field.
+func (r *commentResolver) IsPinned(ctx context.Context, obj *model.Comment) (bool, error) {
+	return r.loaders(ctx).commentsPinned.Load(ctx, obj.ID)
+}
+
 // Replies is the resolver for the replies field.
-func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, first *int32, after *string) (*model.CommentsConnection, error) {
-	var commentsPath string
-	parentPath, err := r.storageAccessor.GetCommentPath(ctx, obj.PostID, &obj.ID)
+func (r *commentResolver) Replies(ctx context.Context, obj *model.Comment, first *int32, after *string, orderBy model.CommentsOrder) (*model.CommentsConnection, error) {
+	commentsPath, err := r.storageAccessor.GetCommentPath(ctx, obj.PostID, &obj.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	if after != nil {
-		err := cursor.Validate(*after)
-		if err != nil {
-			return nil, err
-		}
-
-		commentsPath, err = cursor.GetPath(*after)
-		if err != nil {
-			return nil, err
-		}
+	return r.getCommentsConnection(ctx, obj.PostID, commentsPath, orderBy, first, after)
+}
 
-		if !strings.Contains(commentsPath, parentPath) {
-			return nil, errors.New("Comment with cursor " + *after + "is not a reply to the comment with ID " + strconv.FormatInt(obj.ID, 10) + ".")
-		}
-	} else {
-		commentsPath = parentPath
-	}
+// ReactionCounts is the resolver for the reactionCounts field.
+func (r *commentResolver) ReactionCounts(ctx context.Context, obj *model.Comment) ([]*model.ReactionCount, error) {
+	return r.loaders(ctx).commentReactionCounts.Load(ctx, obj.ID)
+}
 
-	comments, err := r.storageAccessor.GetCommentsLevel(ctx, obj.ID, commentsPath)
-	if err != nil {
+// ViewerReaction is the resolver for the viewerReaction field.
+func (r *commentResolver) ViewerReaction(ctx context.Context, obj *model.Comment, viewerID uuid.UUID) ([]model.ReactionKind, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), viewerID); err != nil {
 		return nil, err
 	}
 
-	return getCommentsConnection(ctx, comments, commentsPath, first, after)
+	return r.loaders(ctx).commentViewerReactions.Load(ctx, viewerReactionKey{targetID: obj.ID, viewerID: viewerID})
+}
+
+// Revisions is the resolver for the revisions field.
+func (r *commentResolver) Revisions(ctx context.Context, obj *model.Comment, first *int32, after *string) (*model.RevisionsConnection, error) {
+	return getRevisionsConnection(first, after, func(afterCursor *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+		return r.service.GetCommentRevisions(ctx, obj, afterCursor, limit)
+	})
+}
+
+// RevisionDiff is the resolver for the revisionDiff field.
+func (r *commentResolver) RevisionDiff(ctx context.Context, obj *model.Comment, from int32, to int32) (*model.RevisionDiff, error) {
+	return r.service.GetCommentRevisionDiff(ctx, obj, from, to)
 }
 
 // AddPost is the resolver for the addPost field.
 func (r *mutationResolver) AddPost(ctx context.Context, newPost model.PostInput) (*model.Post, error) {
-	return r.storageAccessor.AddPost(ctx, &newPost)
+	return r.service.AddPost(ctx, &newPost)
 }
 
 // AddComment is the resolver for the addComment field.
 func (r *mutationResolver) AddComment(ctx context.Context, newComment model.CommentInput) (*model.Comment, error) {
-	return r.storageAccessor.AddComment(ctx, &newComment)
+	return r.service.AddComment(ctx, &newComment)
+}
+
+// EditPost is the resolver for the editPost field.
+func (r *mutationResolver) EditPost(ctx context.Context, postID int64, editorID uuid.UUID, changes model.PostEditInput) (*model.Post, error) {
+	return r.service.EditPost(ctx, postID, editorID, &changes)
+}
+
+// EditComment is the resolver for the editComment field.
+func (r *mutationResolver) EditComment(ctx context.Context, commentID int64, editorID uuid.UUID, text string) (*model.Comment, error) {
+	return r.service.EditComment(ctx, commentID, editorID, text)
+}
+
+// DeletePost is the resolver for the deletePost field.
+func (r *mutationResolver) DeletePost(ctx context.Context, postID int64, userID uuid.UUID) (*model.Post, error) {
+	return r.service.DeletePost(ctx, postID, userID)
+}
+
+// RestorePost is the resolver for the restorePost field.
+func (r *mutationResolver) RestorePost(ctx context.Context, postID int64, userID uuid.UUID) (*model.Post, error) {
+	return r.service.RestorePost(ctx, postID, userID)
+}
+
+// PublishPost is the resolver for the publishPost field.
+func (r *mutationResolver) PublishPost(ctx context.Context, postID int64, authorID uuid.UUID, publishAt *time.Time) (*model.Post, error) {
+	return r.service.PublishPost(ctx, postID, authorID, publishAt)
+}
+
+// DeleteComment is the resolver for the deleteComment field.
+func (r *mutationResolver) DeleteComment(ctx context.Context, commentID int64, userID uuid.UUID) (*model.Comment, error) {
+	return r.service.DeleteComment(ctx, commentID, userID)
+}
+
+// RestoreComment is the resolver for the restoreComment field.
+func (r *mutationResolver) RestoreComment(ctx context.Context, commentID int64, userID uuid.UUID) (*model.Comment, error) {
+	return r.service.RestoreComment(ctx, commentID, userID)
 }
 
 // UpdateCommentsEnabled is the resolver for the updateCommentsEnabled field.
 func (r *mutationResolver) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, newCommentsEnabled bool) (*model.Post, error) {
-	return r.storageAccessor.UpdateCommentsEnabled(ctx, postID, authorID, newCommentsEnabled)
+	return r.service.UpdateCommentsEnabled(ctx, postID, authorID, newCommentsEnabled)
+}
+
+// UpdateModerationMode is the resolver for the updateModerationMode field.
+func (r *mutationResolver) UpdateModerationMode(ctx context.Context, postID int64, authorID uuid.UUID, newModerationMode model.ModerationMode) (*model.Post, error) {
+	return r.service.UpdateModerationMode(ctx, postID, authorID, newModerationMode)
+}
+
+// LockComments is the resolver for the lockComments field.
+func (r *mutationResolver) LockComments(ctx context.Context, postID int64) (*model.Post, error) {
+	return r.service.UpdateCommentsEnabled(ctx, postID, authorization.PrincipalFromContext(ctx).UserID, false)
+}
+
+// RemoveComment is the resolver for the removeComment field.
+func (r *mutationResolver) RemoveComment(ctx context.Context, commentID int64) (*model.Comment, error) {
+	return r.service.DeleteComment(ctx, commentID, authorization.PrincipalFromContext(ctx).UserID)
+}
+
+// ApproveComment is the resolver for the approveComment field.
+func (r *mutationResolver) ApproveComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	return r.service.UpdateCommentStatus(ctx, commentID, authorID, model.CommentStatusApproved)
+}
+
+// RejectComment is the resolver for the rejectComment field.
+func (r *mutationResolver) RejectComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	return r.service.UpdateCommentStatus(ctx, commentID, authorID, model.CommentStatusRejected)
+}
+
+// PinComment is the resolver for the pinComment field.
+func (r *mutationResolver) PinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	return r.service.PinComment(ctx, commentID, authorID)
+}
+
+// UnpinComment is the resolver for the unpinComment field.
+func (r *mutationResolver) UnpinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	return r.service.UnpinComment(ctx, commentID, authorID)
+}
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, newUser model.UserInput) (*model.User, error) {
+	return r.service.CreateUser(ctx, &newUser)
+}
+
+// UpdateUser is the resolver for the updateUser field.
+func (r *mutationResolver) UpdateUser(ctx context.Context, userID uuid.UUID, changes model.UserUpdateInput) (*model.User, error) {
+	return r.service.UpdateUser(ctx, userID, &changes)
+}
+
+// SetUserRole is the resolver for the setUserRole field.
+func (r *mutationResolver) SetUserRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error) {
+	return r.service.SetUserRole(ctx, userID, role)
+}
+
+// BanUser is the resolver for the banUser field.
+func (r *mutationResolver) BanUser(ctx context.Context, ban model.BanInput) (*model.Ban, error) {
+	return r.service.BanUser(ctx, &ban)
+}
+
+// UnbanUser is the resolver for the unbanUser field.
+func (r *mutationResolver) UnbanUser(ctx context.Context, userID uuid.UUID, postID *int64) (bool, error) {
+	if err := r.service.UnbanUser(ctx, userID, postID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// MarkNotificationsRead is the resolver for the markNotificationsRead field.
+func (r *mutationResolver) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, notificationIDs []int64) (int32, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), userID); err != nil {
+		return 0, err
+	}
+
+	return r.storageAccessor.MarkNotificationsRead(ctx, userID, notificationIDs)
+}
+
+// CreateWebhookSubscription is the resolver for the createWebhookSubscription field.
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, newSubscription model.WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	return r.service.CreateWebhookSubscription(ctx, &newSubscription)
+}
+
+// DeleteWebhookSubscription is the resolver for the deleteWebhookSubscription field.
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) (bool, error) {
+	if err := r.service.DeleteWebhookSubscription(ctx, subscriptionID, ownerID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// React is the resolver for the react field.
+func (r *mutationResolver) React(ctx context.Context, reaction model.ReactionInput) ([]*model.ReactionCount, error) {
+	return r.service.React(ctx, &reaction)
+}
+
+// Unreact is the resolver for the unreact field.
+func (r *mutationResolver) Unreact(ctx context.Context, reaction model.ReactionInput) ([]*model.ReactionCount, error) {
+	return r.service.Unreact(ctx, &reaction)
+}
+
+// Author is the resolver for the author field.
+func (r *postResolver) Author(ctx context.Context, obj *model.Post) (*model.User, error) {
+	return r.loaders(ctx).users.Load(ctx, obj.AuthorID)
+}
+
+// TextHTML is the resolver for the textHtml field.
+func (r *postResolver) TextHTML(ctx context.Context, obj *model.Post) (string, error) {
+	return r.markdown.RenderHTML(obj.Text), nil
+}
+
+// Tags is the resolver for the tags field.
+func (r *postResolver) Tags(ctx context.Context, obj *model.Post) ([]string, error) {
+	return r.loaders(ctx).postTags.Load(ctx, obj.ID)
 }
 
 // Comments is the resolver for the comments field.
-func (r *postResolver) Comments(ctx context.Context, obj *model.Post, first *int32, after *string) (*model.CommentsConnection, error) {
+func (r *postResolver) Comments(ctx context.Context, obj *model.Post, first *int32, after *string, orderBy model.CommentsOrder) (*model.CommentsConnection, error) {
 	if !obj.CommentsEnabled {
 		obj.Comments = &model.CommentsConnection{
 			Edges:    []*model.CommentEdge{},
@@ -76,101 +243,138 @@ func (r *postResolver) Comments(ctx context.Context, obj *model.Post, first *int
 		return obj.Comments, nil
 	}
 
-	var commentsPath string
-	if after != nil {
-		err := cursor.Validate(*after)
-		if err != nil {
-			return nil, err
-		}
+	return r.getPostCommentsConnection(ctx, obj.ID, orderBy, first, after)
+}
 
-		commentsPath, err = cursor.GetPath(*after)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		commentsPath = strconv.FormatInt(obj.ID, 10)
-	}
+// ReactionCounts is the resolver for the reactionCounts field.
+func (r *postResolver) ReactionCounts(ctx context.Context, obj *model.Post) ([]*model.ReactionCount, error) {
+	return r.loaders(ctx).postReactionCounts.Load(ctx, obj.ID)
+}
 
-	comments, err := r.storageAccessor.GetCommentsLevel(ctx, obj.ID, commentsPath)
-	if err != nil {
+// ViewerReaction is the resolver for the viewerReaction field.
+func (r *postResolver) ViewerReaction(ctx context.Context, obj *model.Post, viewerID uuid.UUID) ([]model.ReactionKind, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), viewerID); err != nil {
 		return nil, err
 	}
 
-	return getCommentsConnection(ctx, comments, commentsPath, first, after)
+	return r.loaders(ctx).postViewerReactions.Load(ctx, viewerReactionKey{targetID: obj.ID, viewerID: viewerID})
 }
 
-func getCommentsConnection(ctx context.Context, comments []*model.Comment, commentsPath string, first *int32, after *string) (*model.CommentsConnection, error) {
-	edges := make([]*model.CommentEdge, 0)
+// Revisions is the resolver for the revisions field.
+func (r *postResolver) Revisions(ctx context.Context, obj *model.Post, first *int32, after *string) (*model.RevisionsConnection, error) {
+	return getRevisionsConnection(first, after, func(afterCursor *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+		return r.service.GetPostRevisions(ctx, obj, afterCursor, limit)
+	})
+}
 
-	var startID int64
-	start := false
-	if after != nil {
-		var err error
-		startID, err = cursor.GetCommentID(*after)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		start = true
-	}
+// RevisionDiff is the resolver for the revisionDiff field.
+func (r *postResolver) RevisionDiff(ctx context.Context, obj *model.Post, from int32, to int32) (*model.RevisionDiff, error) {
+	return r.service.GetPostRevisionDiff(ctx, obj, from, to)
+}
+
+// Posts is the resolver for the posts field.
+func (r *queryResolver) Posts(ctx context.Context) ([]*model.Post, error) {
+	return r.storageAccessor.GetPosts(ctx, nil, authorization.PrincipalFromContext(ctx).UserID, nil, nil)
+}
+
+// PostsConnection is the resolver for the postsConnection field.
+func (r *queryResolver) PostsConnection(ctx context.Context, filter *model.PostsFilter, first *int32, after *string) (*model.PostsConnection, error) {
+	return r.getPostsConnection(ctx, filter, first, after)
+}
+
+// Post is the resolver for the post field.
+func (r *queryResolver) Post(ctx context.Context, postID int64) (*model.Post, error) {
+	return r.storageAccessor.GetPost(ctx, postID, authorization.PrincipalFromContext(ctx).UserID)
+}
+
+// PostsByTag is the resolver for the postsByTag field.
+func (r *queryResolver) PostsByTag(ctx context.Context, tag string, first *int32, after *string) (*model.PostsConnection, error) {
+	return r.getPostsConnection(ctx, &model.PostsFilter{Tag: &tag}, first, after)
+}
 
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	return r.storageAccessor.GetUser(ctx, userID)
+}
+
+// CommentsByAuthor is the resolver for the commentsByAuthor field.
+func (r *queryResolver) CommentsByAuthor(ctx context.Context, authorID uuid.UUID, first *int32, after *string) (*model.CommentsConnection, error) {
+	return r.getAuthorCommentsConnection(ctx, authorID, first, after)
+}
 
-	default:
+// Notifications is the resolver for the notifications field.
+func (r *queryResolver) Notifications(ctx context.Context, userID uuid.UUID, first *int32, after *string, unreadOnly bool) (*model.NotificationsConnection, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), userID); err != nil {
+		return nil, err
 	}
 
-	var commentsAmount = int32(len(comments))
-	if first != nil {
-		commentsAmount = *first
+	return r.getNotificationsConnection(ctx, userID, unreadOnly, first, after)
+}
+
+// PendingComments is the resolver for the pendingComments field.
+func (r *queryResolver) PendingComments(ctx context.Context, postID int64, viewerID uuid.UUID) ([]*model.Comment, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), viewerID); err != nil {
+		return nil, err
 	}
 
-	hasNextPage := false
-	counter := int32(0)
-	for idx, comment := range comments {
-		if comment.ID == startID && !start {
-			start = true
-			continue
-		}
+	return r.storageAccessor.GetPendingComments(ctx, postID, viewerID, authorization.Overrides(authorization.PrincipalFromContext(ctx), authorization.ActionViewPending))
+}
 
-		if start {
-			edges = append(edges, &model.CommentEdge{
-				Node:   comment,
-				Cursor: cursor.Create(comment.ID, commentsPath),
-			})
-			counter++
-		}
+// WebhookSubscriptions is the resolver for the webhookSubscriptions field.
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]*model.WebhookSubscription, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), ownerID); err != nil {
+		return nil, err
+	}
 
-		if counter == commentsAmount {
-			if idx+1 < len(comments) {
-				hasNextPage = true
-			}
-		}
+	return r.storageAccessor.GetWebhookSubscriptions(ctx, ownerID)
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, subscriptionID int64, ownerID uuid.UUID, status *model.WebhookDeliveryStatus, first *int32, after *string) (*model.WebhookDeliveriesConnection, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), ownerID); err != nil {
+		return nil, err
 	}
 
-	var endCursor string
-	if len(edges) > 0 {
-		endCursor = edges[len(edges)-1].Cursor
+	return r.getWebhookDeliveriesConnection(ctx, subscriptionID, ownerID, status, first, after)
+}
+
+// SearchPosts is the resolver for the searchPosts field.
+func (r *queryResolver) SearchPosts(ctx context.Context, query string, first *int32, after *string) (*model.PostSearchConnection, error) {
+	return r.getPostSearchConnection(ctx, query, first, after)
+}
+
+// SearchComments is the resolver for the searchComments field.
+func (r *queryResolver) SearchComments(ctx context.Context, postID int64, query string, first *int32, after *string) (*model.CommentSearchConnection, error) {
+	return r.getCommentSearchConnection(ctx, postID, query, first, after)
+}
+
+// Bans is the resolver for the bans field.
+func (r *queryResolver) Bans(ctx context.Context, userID *uuid.UUID, postID *int64, activeOnly bool) ([]*model.Ban, error) {
+	return r.storageAccessor.GetBans(ctx, userID, postID, activeOnly)
+}
+
+// Editor is the resolver for the editor field.
+func (r *revisionResolver) Editor(ctx context.Context, obj *model.Revision) (*model.User, error) {
+	return r.loaders(ctx).users.Load(ctx, obj.EditorID)
+}
+
+// NotificationAdded is the resolver for the notificationAdded field.
+func (r *subscriptionResolver) NotificationAdded(ctx context.Context, userID uuid.UUID) (<-chan *model.Notification, error) {
+	if err := authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), userID); err != nil {
+		return nil, err
 	}
 
-	return &model.CommentsConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			HasNextPage: hasNextPage,
-			EndCursor:   &endCursor,
-		},
-	}, nil
+	return r.notifications.Subscribe(ctx, userID), nil
 }
 
 // Posts is the resolver for the posts field.
-func (r *queryResolver) Posts(ctx context.Context) ([]*model.Post, error) {
-	return r.storageAccessor.GetAllPosts(ctx)
+func (r *userResolver) Posts(ctx context.Context, obj *model.User, first *int32, after *string) (*model.PostsConnection, error) {
+	return r.getPostsConnection(ctx, &model.PostsFilter{AuthorID: &obj.ID}, first, after)
 }
 
-// Post is the resolver for the post field.
-func (r *queryResolver) Post(ctx context.Context, postID int64) (*model.Post, error) {
-	return r.storageAccessor.GetPost(ctx, postID)
+// Comments is the resolver for the comments field.
+func (r *userResolver) Comments(ctx context.Context, obj *model.User, first *int32, after *string) (*model.CommentsConnection, error) {
+	return r.getAuthorCommentsConnection(ctx, obj.ID, first, after)
 }
 
 // Comment returns CommentResolver implementation.
@@ -185,7 +389,19 @@ func (r *Resolver) Post() PostResolver { return &postResolver{r} }
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+// Revision returns RevisionResolver implementation.
+func (r *Resolver) Revision() RevisionResolver { return &revisionResolver{r} }
+
+// Subscription returns SubscriptionResolver implementation.
+func (r *Resolver) Subscription() SubscriptionResolver { return &subscriptionResolver{r} }
+
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
 type commentResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type postResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type revisionResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
@@ -0,0 +1,132 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+type RecordType string
+
+const (
+	RecordTypePost    RecordType = "post"
+	RecordTypeComment RecordType = "comment"
+)
+
+// Record is a line of the archive. Every post is followed by its comments, and every comment follows its parent,
+// so the archive can be restored in one pass.
+type Record struct {
+	Type    RecordType `json:"type"`
+	Post    *Post      `json:"post,omitempty"`
+	Comment *Comment   `json:"comment,omitempty"`
+}
+
+type Post struct {
+	ID              int64                `json:"id"`
+	AuthorID        uuid.UUID            `json:"authorID"`
+	Title           string               `json:"title"`
+	Text            string               `json:"text"`
+	CreateDate      time.Time            `json:"createDate"`
+	CommentsEnabled bool                 `json:"commentsEnabled"`
+	ModerationMode  model.ModerationMode `json:"moderationMode"`
+	Status          model.PostStatus     `json:"status"`
+	PublishAt       *time.Time           `json:"publishAt,omitempty"`
+	DeletedAt       *time.Time           `json:"deletedAt,omitempty"`
+	MaxReplyDepth   *int32               `json:"maxReplyDepth,omitempty"`
+}
+
+// Comment keeps IDs of the source storage. Path is the ID of the post followed by the IDs of the ancestors
+// of the comment, separated by dots. PinnedAt is set for pinned comments.
+type Comment struct {
+	ID         int64               `json:"id"`
+	PostID     int64               `json:"postID"`
+	ParentID   *int64              `json:"parentID,omitempty"`
+	Path       string              `json:"path"`
+	AuthorID   uuid.UUID           `json:"authorID"`
+	Text       string              `json:"text"`
+	CreateDate time.Time           `json:"createDate"`
+	Status     model.CommentStatus `json:"status"`
+	DeletedAt  *time.Time          `json:"deletedAt,omitempty"`
+	PinnedAt   *time.Time          `json:"pinnedAt,omitempty"`
+}
+
+func NewPostRecord(exportPost *storage.ExportPost) *Record {
+	post := exportPost.Post
+	return &Record{
+		Type: RecordTypePost,
+		Post: &Post{
+			ID:              post.ID,
+			AuthorID:        post.AuthorID,
+			Title:           post.Title,
+			Text:            post.Text,
+			CreateDate:      post.CreateDate,
+			CommentsEnabled: post.CommentsEnabled,
+			ModerationMode:  post.ModerationMode,
+			Status:          post.Status,
+			PublishAt:       post.PublishAt,
+			DeletedAt:       post.DeletedAt,
+			MaxReplyDepth:   exportPost.MaxReplyDepth,
+		},
+	}
+}
+
+func NewCommentRecord(treeComment *storage.TreeComment) *Record {
+	comment := treeComment.Comment
+	return &Record{
+		Type: RecordTypeComment,
+		Comment: &Comment{
+			ID:         comment.ID,
+			PostID:     comment.PostID,
+			ParentID:   comment.ParentID,
+			Path:       treeComment.Path,
+			AuthorID:   comment.AuthorID,
+			Text:       comment.Text,
+			CreateDate: comment.CreateDate,
+			Status:     comment.Status,
+			DeletedAt:  comment.DeletedAt,
+			PinnedAt:   treeComment.PinnedAt,
+		},
+	}
+}
+
+// Stats counts records written to or read from the archive.
+type Stats struct {
+	Posts    int64
+	Comments int64
+}
+
+// Export streams posts selected by the filter, each followed by its comments, to the writer as JSON Lines.
+func Export(ctx context.Context, accessor storage.Accessor, writer io.Writer, filter *storage.ExportFilter) (Stats, error) {
+	var stats Stats
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+
+	for post, err := range accessor.IteratePosts(ctx, filter) {
+		if err != nil {
+			return stats, err
+		}
+
+		if err = encoder.Encode(NewPostRecord(post)); err != nil {
+			return stats, err
+		}
+		stats.Posts++
+
+		for comment, err := range accessor.IteratePostComments(ctx, post.Post.ID) {
+			if err != nil {
+				return stats, err
+			}
+
+			if err = encoder.Encode(NewCommentRecord(comment)); err != nil {
+				return stats, err
+			}
+			stats.Comments++
+		}
+	}
+
+	return stats, nil
+}
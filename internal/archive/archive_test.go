@@ -0,0 +1,108 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+
+	post, err := accessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "<b>Text</b>", CommentsEnabled: true})
+	assertions.NoError(err)
+	root, err := accessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Root"})
+	assertions.NoError(err)
+	_, err = accessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+	assertions.NoError(err)
+
+	t.Run("Successful Export", func(t *testing.T) {
+		var buffer bytes.Buffer
+		stats, err := Export(ctx, accessor, &buffer, nil)
+		assertions.NoError(err)
+		assertions.Equal(Stats{Posts: 1, Comments: 2}, stats)
+
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		assertions.Len(lines, 3)
+		assertions.Contains(lines[0], "<b>Text</b>")
+
+		records := make([]Record, 0, len(lines))
+		for _, line := range lines {
+			var record Record
+			assertions.NoError(json.Unmarshal([]byte(line), &record))
+			records = append(records, record)
+		}
+
+		assertions.Equal(RecordTypePost, records[0].Type)
+		assertions.Equal(post.ID, records[0].Post.ID)
+		assertions.Equal(model.PostStatusPublished, records[0].Post.Status)
+		assertions.Equal(RecordTypeComment, records[1].Type)
+		assertions.Equal("0", records[1].Comment.Path)
+		assertions.Nil(records[1].Comment.ParentID)
+		assertions.Equal("0.0", records[2].Comment.Path)
+		assertions.Equal(root.ID, *records[2].Comment.ParentID)
+	})
+}
+
+func TestRoundTrip(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	source := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+
+	maxReplyDepth := int32(2)
+	post, err := source.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true, MaxReplyDepth: &maxReplyDepth})
+	assertions.NoError(err)
+	first, err := source.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "First"})
+	assertions.NoError(err)
+	second, err := source.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Second"})
+	assertions.NoError(err)
+	_, err = source.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Third"})
+	assertions.NoError(err)
+	_, err = source.PinComment(ctx, second.ID, authorID, 3)
+	assertions.NoError(err)
+	_, err = source.PinComment(ctx, first.ID, authorID, 3)
+	assertions.NoError(err)
+
+	t.Run("Successful Round Trip", func(t *testing.T) {
+		var buffer bytes.Buffer
+		_, err := Export(ctx, source, &buffer, nil)
+		assertions.NoError(err)
+
+		target := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		_, err = target.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Existing", Text: "Text", CommentsEnabled: true})
+		assertions.NoError(err)
+
+		stats, err := Import(ctx, target, &buffer, "test", false)
+		assertions.NoError(err)
+		assertions.Equal(ImportStats{Posts: 1, Comments: 3}, stats)
+
+		postIDs, err := target.GetImportedIDs(ctx, "test", storage.ImportKindPost, []int64{post.ID})
+		assertions.NoError(err)
+		commentIDs, err := target.GetImportedIDs(ctx, "test", storage.ImportKindComment, []int64{first.ID, second.ID})
+		assertions.NoError(err)
+
+		importedDepth, err := target.GetPostMaxReplyDepth(ctx, postIDs[post.ID])
+		assertions.NoError(err)
+		assertions.Equal(maxReplyDepth, *importedDepth)
+
+		pinned, err := target.GetPinnedComments(ctx, postIDs[post.ID])
+		assertions.NoError(err)
+		assertions.Len(pinned, 2)
+		assertions.Equal(commentIDs[second.ID], pinned[0].ID)
+		assertions.Equal(commentIDs[first.ID], pinned[1].ID)
+	})
+}
@@ -0,0 +1,210 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// importBatchSize is the maximum number of posts or comments added to the storage at once.
+const importBatchSize = 500
+
+// ImportStats counts imported records and records skipped because they were imported from the same source before.
+// In a dry run it counts records that would be imported.
+type ImportStats struct {
+	Posts           int64
+	Comments        int64
+	SkippedPosts    int64
+	SkippedComments int64
+}
+
+type importer struct {
+	accessor storage.Accessor
+	source   string
+	dryRun   bool
+	posts    []*storage.ExportPost
+	comments []*storage.TreeComment
+	stats    ImportStats
+}
+
+// Import reads an archive written by Export and adds its posts and comments to the storage under new IDs.
+// Records are remembered by their IDs in the source, so importing the same archive again adds only new records.
+// With dryRun the storage is not changed.
+func Import(ctx context.Context, accessor storage.Accessor, reader io.Reader, source string, dryRun bool) (ImportStats, error) {
+	importer := &importer{
+		accessor: accessor,
+		source:   source,
+		dryRun:   dryRun,
+		posts:    make([]*storage.ExportPost, 0, importBatchSize),
+		comments: make([]*storage.TreeComment, 0, importBatchSize),
+	}
+	decoder := json.NewDecoder(reader)
+
+	for line := 1; ; line++ {
+		var record Record
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return importer.stats, errors.New("Record " + strconv.Itoa(line) + " is invalid: " + err.Error())
+		}
+
+		switch {
+		case record.Type == RecordTypePost && record.Post != nil:
+			err = importer.addPost(ctx, record.Post)
+
+		case record.Type == RecordTypeComment && record.Comment != nil:
+			err = importer.addComment(ctx, record.Comment)
+
+		default:
+			err = errors.New("Record " + strconv.Itoa(line) + " has unknown type " + string(record.Type))
+		}
+
+		if err != nil {
+			return importer.stats, err
+		}
+	}
+
+	if err := importer.flushPosts(ctx); err != nil {
+		return importer.stats, err
+	}
+
+	return importer.stats, importer.flushComments(ctx)
+}
+
+func (importer *importer) addPost(ctx context.Context, post *Post) error {
+	if len(importer.posts) == importBatchSize {
+		if err := importer.flushPosts(ctx); err != nil {
+			return err
+		}
+	}
+
+	importer.posts = append(importer.posts, &storage.ExportPost{
+		Post: &model.Post{
+			ID:              post.ID,
+			AuthorID:        post.AuthorID,
+			Title:           post.Title,
+			Text:            post.Text,
+			CreateDate:      post.CreateDate,
+			CommentsEnabled: post.CommentsEnabled,
+			ModerationMode:  post.ModerationMode,
+			Status:          post.Status,
+			PublishAt:       post.PublishAt,
+			DeletedAt:       post.DeletedAt,
+		},
+		MaxReplyDepth: post.MaxReplyDepth,
+	})
+
+	return nil
+}
+
+func (importer *importer) addComment(ctx context.Context, comment *Comment) error {
+	// Comments reference their posts, so pending posts are added first.
+	if err := importer.flushPosts(ctx); err != nil {
+		return err
+	}
+
+	if len(importer.comments) == importBatchSize {
+		if err := importer.flushComments(ctx); err != nil {
+			return err
+		}
+	}
+
+	importer.comments = append(importer.comments, &storage.TreeComment{
+		Comment: &model.Comment{
+			ID:         comment.ID,
+			AuthorID:   comment.AuthorID,
+			PostID:     comment.PostID,
+			ParentID:   comment.ParentID,
+			Text:       comment.Text,
+			CreateDate: comment.CreateDate,
+			Status:     comment.Status,
+			DeletedAt:  comment.DeletedAt,
+		},
+		Path:     comment.Path,
+		PinnedAt: comment.PinnedAt,
+	})
+
+	return nil
+}
+
+func (importer *importer) flushPosts(ctx context.Context) error {
+	if len(importer.posts) == 0 {
+		return nil
+	}
+
+	var imported int64
+	var err error
+	if importer.dryRun {
+		imported, err = importer.countNew(ctx, storage.ImportKindPost, getPostIDs(importer.posts))
+	} else {
+		imported, err = importer.accessor.ImportPosts(ctx, importer.source, importer.posts)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	importer.stats.Posts += imported
+	importer.stats.SkippedPosts += int64(len(importer.posts)) - imported
+	importer.posts = importer.posts[:0]
+	return nil
+}
+
+func (importer *importer) flushComments(ctx context.Context) error {
+	if len(importer.comments) == 0 {
+		return nil
+	}
+
+	var imported int64
+	var err error
+	if importer.dryRun {
+		imported, err = importer.countNew(ctx, storage.ImportKindComment, getCommentIDs(importer.comments))
+	} else {
+		imported, err = importer.accessor.ImportComments(ctx, importer.source, importer.comments)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	importer.stats.Comments += imported
+	importer.stats.SkippedComments += int64(len(importer.comments)) - imported
+	importer.comments = importer.comments[:0]
+	return nil
+}
+
+// countNew returns the number of records that were not imported from the source yet.
+func (importer *importer) countNew(ctx context.Context, kind storage.ImportKind, sourceIDs []int64) (int64, error) {
+	importedIDs, err := importer.accessor.GetImportedIDs(ctx, importer.source, kind, sourceIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(len(sourceIDs) - len(importedIDs)), nil
+}
+
+func getPostIDs(posts []*storage.ExportPost) []int64 {
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.Post.ID)
+	}
+
+	return postIDs
+}
+
+func getCommentIDs(comments []*storage.TreeComment) []int64 {
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.Comment.ID)
+	}
+
+	return commentIDs
+}
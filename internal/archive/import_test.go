@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	source := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+
+	_, err := source.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Empty", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+	post, err := source.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+	root, err := source.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Root"})
+	assertions.NoError(err)
+	_, err = source.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+	assertions.NoError(err)
+
+	var archive bytes.Buffer
+	_, err = Export(ctx, source, &archive, nil)
+	assertions.NoError(err)
+
+	target := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	_, err = target.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Existing", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+
+	t.Run("Successful Dry Run", func(t *testing.T) {
+		stats, err := Import(ctx, target, bytes.NewReader(archive.Bytes()), "test", true)
+		assertions.NoError(err)
+		assertions.Equal(ImportStats{Posts: 2, Comments: 2}, stats)
+
+		posts, err := target.GetAllPosts(ctx, authorID)
+		assertions.NoError(err)
+		assertions.Len(posts, 1)
+	})
+
+	t.Run("Successful Import", func(t *testing.T) {
+		stats, err := Import(ctx, target, bytes.NewReader(archive.Bytes()), "test", false)
+		assertions.NoError(err)
+		assertions.Equal(ImportStats{Posts: 2, Comments: 2}, stats)
+
+		var imported bytes.Buffer
+		_, err = Export(ctx, target, &imported, nil)
+		assertions.NoError(err)
+
+		lines := strings.Split(strings.TrimSpace(imported.String()), "\n")
+		assertions.Len(lines, 5)
+		assertions.Contains(lines[2], `"id":2`)
+		assertions.Contains(lines[3], `"path":"2"`)
+		assertions.Contains(lines[4], `"path":"2.0"`)
+		assertions.Contains(lines[4], `"parentID":0`)
+		assertions.Contains(lines[4], `"createDate":"`+root.CreateDate.Format("2006-01-02"))
+	})
+
+	t.Run("Successful Repeated Import", func(t *testing.T) {
+		stats, err := Import(ctx, target, bytes.NewReader(archive.Bytes()), "test", false)
+		assertions.NoError(err)
+		assertions.Equal(ImportStats{SkippedPosts: 2, SkippedComments: 2}, stats)
+	})
+
+	t.Run("Unsuccessful Import Invalid Record", func(t *testing.T) {
+		_, err := Import(ctx, target, strings.NewReader(`{"type":"reaction"}`), "test", false)
+		assertions.Error(err)
+	})
+}
@@ -0,0 +1,126 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+// ErrorCodeForbidden marks errors of access checks in responses, so clients can tell them from other errors.
+const ErrorCodeForbidden = "FORBIDDEN"
+
+// ErrForbidden is returned when the user may not perform the operation, because of the role or a ban.
+var ErrForbidden = errors.New("Access denied.")
+
+// forbiddenError explains why the operation is not allowed and matches ErrForbidden in errors.Is.
+type forbiddenError struct {
+	message string
+}
+
+func (err *forbiddenError) Error() string {
+	return err.message
+}
+
+func (err *forbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// Forbidden returns an error with the message, which is treated as ErrForbidden.
+func Forbidden(message string) error {
+	return &forbiddenError{message: message}
+}
+
+// Action is an operation on posts, comments or users of other users, which needs a privileged role.
+type Action string
+
+const (
+	ActionLockComments   Action = "LOCK_COMMENTS"
+	ActionViewPending    Action = "VIEW_PENDING"
+	ActionRemoveComment  Action = "REMOVE_COMMENT"
+	ActionRestoreComment Action = "RESTORE_COMMENT"
+	ActionRemovePost     Action = "REMOVE_POST"
+	ActionRestorePost    Action = "RESTORE_POST"
+	ActionManageRoles    Action = "MANAGE_ROLES"
+)
+
+// requiredRoles sets the least role which may perform each action on content of other users.
+var requiredRoles = map[Action]model.Role{
+	ActionLockComments:   model.RoleModerator,
+	ActionViewPending:    model.RoleModerator,
+	ActionRemoveComment:  model.RoleModerator,
+	ActionRestoreComment: model.RoleModerator,
+	ActionRemovePost:     model.RoleAdmin,
+	ActionRestorePost:    model.RoleAdmin,
+	ActionManageRoles:    model.RoleAdmin,
+}
+
+// roleRanks orders roles so that every role has all privileges of the lower ones.
+var roleRanks = map[model.Role]int{
+	model.RoleUser:      0,
+	model.RoleModerator: 1,
+	model.RoleAdmin:     2,
+}
+
+// Principal is the user on whose behalf the request is made.
+type Principal struct {
+	UserID uuid.UUID
+	Role   model.Role
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal of the request or an anonymous user if the request has none.
+func PrincipalFromContext(ctx context.Context) Principal {
+	principal, ok := ctx.Value(principalKey{}).(Principal)
+	if !ok {
+		return Principal{Role: model.RoleUser}
+	}
+
+	return principal
+}
+
+// HasRole reports whether the role has all privileges of the required role. Unknown roles have none.
+func HasRole(role model.Role, required model.Role) bool {
+	rank, ok := roleRanks[role]
+	if !ok {
+		return false
+	}
+
+	return rank >= roleRanks[required]
+}
+
+// Require returns ErrForbidden unless the principal has the required role.
+func Require(principal Principal, required model.Role) error {
+	if !HasRole(principal.Role, required) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// CheckActingUser returns a forbidden error unless the user passed by the client is the principal of the request.
+// Storage checks ownership by IDs from arguments, so they must name the authenticated user, not any user.
+func CheckActingUser(principal Principal, userID uuid.UUID) error {
+	if principal.UserID == uuid.Nil || principal.UserID != userID {
+		return Forbidden("User with ID " + userID.String() + " is not the user of the request.")
+	}
+
+	return nil
+}
+
+// Overrides reports whether the principal may perform the action on content of other users,
+// so ownership checks of storage must be skipped.
+func Overrides(principal Principal, action Action) bool {
+	required, ok := requiredRoles[action]
+	if !ok {
+		return false
+	}
+
+	return HasRole(principal.Role, required)
+}
@@ -0,0 +1,118 @@
+package authorization
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasRole(t *testing.T) {
+	assertions := assert.New(t)
+
+	testCases := []struct {
+		role     model.Role
+		required model.Role
+		expected bool
+	}{
+		{model.RoleUser, model.RoleUser, true},
+		{model.RoleUser, model.RoleModerator, false},
+		{model.RoleUser, model.RoleAdmin, false},
+		{model.RoleModerator, model.RoleUser, true},
+		{model.RoleModerator, model.RoleModerator, true},
+		{model.RoleModerator, model.RoleAdmin, false},
+		{model.RoleAdmin, model.RoleUser, true},
+		{model.RoleAdmin, model.RoleModerator, true},
+		{model.RoleAdmin, model.RoleAdmin, true},
+		{model.Role("OWNER"), model.RoleUser, false},
+		{model.Role(""), model.RoleUser, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("HasRole "+testCase.role.String()+" "+testCase.required.String(), func(t *testing.T) {
+			assertions.Equal(testCase.expected, HasRole(testCase.role, testCase.required))
+
+			err := Require(Principal{UserID: uuid.New(), Role: testCase.role}, testCase.required)
+			if testCase.expected {
+				assertions.Nil(err)
+			} else {
+				assertions.ErrorIs(err, ErrForbidden)
+			}
+		})
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	assertions := assert.New(t)
+
+	testCases := []struct {
+		action    Action
+		user      bool
+		moderator bool
+		admin     bool
+	}{
+		{ActionLockComments, false, true, true},
+		{ActionViewPending, false, true, true},
+		{ActionRemoveComment, false, true, true},
+		{ActionRestoreComment, false, true, true},
+		{ActionRemovePost, false, false, true},
+		{ActionRestorePost, false, false, true},
+		{ActionManageRoles, false, false, true},
+		{Action("UNKNOWN"), false, false, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Overrides "+string(testCase.action), func(t *testing.T) {
+			assertions.Equal(testCase.user, Overrides(Principal{Role: model.RoleUser}, testCase.action))
+			assertions.Equal(testCase.moderator, Overrides(Principal{Role: model.RoleModerator}, testCase.action))
+			assertions.Equal(testCase.admin, Overrides(Principal{Role: model.RoleAdmin}, testCase.action))
+		})
+	}
+}
+
+func TestCheckActingUser(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+
+	t.Run("Successful CheckActingUser", func(t *testing.T) {
+		assertions.Nil(CheckActingUser(Principal{UserID: userID, Role: model.RoleUser}, userID))
+	})
+
+	t.Run("Unsuccessful CheckActingUser other user", func(t *testing.T) {
+		assertions.ErrorIs(CheckActingUser(Principal{UserID: uuid.New(), Role: model.RoleAdmin}, userID), ErrForbidden)
+	})
+
+	t.Run("Unsuccessful CheckActingUser anonymous", func(t *testing.T) {
+		assertions.ErrorIs(CheckActingUser(Principal{Role: model.RoleUser}, uuid.Nil), ErrForbidden)
+	})
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+
+	testCases := []struct {
+		name     string
+		ctx      context.Context
+		expected Principal
+	}{
+		{"Without principal", context.Background(), Principal{Role: model.RoleUser}},
+		{"With principal", WithPrincipal(context.Background(), Principal{UserID: userID, Role: model.RoleModerator}), Principal{UserID: userID, Role: model.RoleModerator}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Successful PrincipalFromContext "+testCase.name, func(t *testing.T) {
+			assertions.Equal(testCase.expected, PrincipalFromContext(testCase.ctx))
+		})
+	}
+}
+
+func TestForbidden(t *testing.T) {
+	assertions := assert.New(t)
+
+	err := Forbidden("User is banned.")
+	assertions.ErrorIs(err, ErrForbidden)
+	assertions.Equal("User is banned.", err.Error())
+}
@@ -0,0 +1,59 @@
+package authorization
+
+import (
+	"context"
+	"net/http"
+	"net/netip"
+	"slices"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+// UserHeader carries the ID of the user making the request. The service does not authenticate users itself,
+// so the header must be set by an authenticating gateway, and it is read only from its trusted addresses.
+const UserHeader = "X-User-ID"
+
+// Users provides stored users with their roles.
+type Users interface {
+	GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error)
+}
+
+// Middleware attaches the principal of the request to its context. The user is read from UserHeader only if the request
+// comes from one of trustedProxies, otherwise clients could act on behalf of any user, as IDs of users are public.
+// The role is read from the stored user, users from adminIDs are admins regardless of it,
+// and requests without a known user get the USER role.
+func Middleware(users Users, adminIDs []uuid.UUID, trustedProxies []netip.Prefix, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := Principal{Role: model.RoleUser}
+
+		if !isTrustedProxy(r.RemoteAddr, trustedProxies) {
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+			return
+		}
+
+		if userID, err := uuid.Parse(r.Header.Get(UserHeader)); err == nil {
+			principal.UserID = userID
+
+			if slices.Contains(adminIDs, userID) {
+				principal.Role = model.RoleAdmin
+			} else if user, err := users.GetUser(r.Context(), userID); err == nil {
+				principal.Role = user.Role
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+	})
+}
+
+// isTrustedProxy reports whether the remote address of the request belongs to one of the trusted networks.
+func isTrustedProxy(remoteAddr string, trustedProxies []netip.Prefix) bool {
+	addrPort, err := netip.ParseAddrPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+
+	return slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool {
+		return prefix.Contains(addrPort.Addr().Unmap())
+	})
+}
@@ -0,0 +1,71 @@
+package authorization
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testUsers map[uuid.UUID]model.Role
+
+func (users testUsers) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	role, ok := users[userID]
+	if !ok {
+		return nil, errors.New("User with ID " + userID.String() + " was not found")
+	}
+
+	return &model.User{ID: userID, Role: role}, nil
+}
+
+func TestMiddleware(t *testing.T) {
+	assertions := assert.New(t)
+
+	moderatorID := uuid.New()
+	adminID := uuid.New()
+	configuredAdminID := uuid.New()
+	unknownID := uuid.New()
+	users := testUsers{moderatorID: model.RoleModerator, adminID: model.RoleAdmin, configuredAdminID: model.RoleUser}
+
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("::1/128")}
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		header     string
+		expected   Principal
+	}{
+		{"Without header", "10.0.0.1:1234", "", Principal{Role: model.RoleUser}},
+		{"Incorrect header", "10.0.0.1:1234", "not-a-uuid", Principal{Role: model.RoleUser}},
+		{"Unknown user", "10.0.0.1:1234", unknownID.String(), Principal{UserID: unknownID, Role: model.RoleUser}},
+		{"Stored moderator", "10.0.0.1:1234", moderatorID.String(), Principal{UserID: moderatorID, Role: model.RoleModerator}},
+		{"Stored admin", "[::1]:1234", adminID.String(), Principal{UserID: adminID, Role: model.RoleAdmin}},
+		{"Configured admin", "10.0.0.1:1234", configuredAdminID.String(), Principal{UserID: configuredAdminID, Role: model.RoleAdmin}},
+		{"Untrusted proxy", "192.0.2.1:1234", adminID.String(), Principal{Role: model.RoleUser}},
+		{"Untrusted configured admin", "192.0.2.1:1234", configuredAdminID.String(), Principal{Role: model.RoleUser}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Successful Middleware "+testCase.name, func(t *testing.T) {
+			var principal Principal
+			handler := Middleware(users, []uuid.UUID{configuredAdminID}, trustedProxies, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal = PrincipalFromContext(r.Context())
+			}))
+
+			request := httptest.NewRequest(http.MethodPost, "/query", nil)
+			request.RemoteAddr = testCase.remoteAddr
+			if testCase.header != "" {
+				request.Header.Set(UserHeader, testCase.header)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), request)
+			assertions.Equal(testCase.expected, principal)
+		})
+	}
+}
@@ -8,57 +8,333 @@ import (
 	"unicode"
 )
 
-func Create(commentID int64, parentPath string) string {
-	return encodeCursor(strings.Join([]string{parentPath, strconv.FormatInt(commentID, 10)}, "."))
+const (
+	searchPrefix       = "SEARCH"
+	postPrefix         = "POST"
+	authorPrefix       = "AUTHOR"
+	notificationPrefix = "NOTIFICATION"
+	deliveryPrefix     = "DELIVERY"
+	revisionPrefix     = "REVISION"
+	pinnedPrefix       = "PINNED"
+)
+
+// Cursor points at a comment inside one level of the comments tree.
+// SortKey holds the value the level is ordered by, so pagination can continue from the exact position.
+type Cursor struct {
+	Order     string
+	SortKey   int64
+	Path      string
+	CommentID int64
+}
+
+// SearchCursor points at a search result. Results are ordered by rank and then by ID.
+type SearchCursor struct {
+	Rank float64
+	ID   int64
+}
+
+// PostCursor points at a post in the list of posts ordered from the newest to the oldest.
+type PostCursor struct {
+	SortKey int64
+	PostID  int64
+}
+
+// AuthorCursor points at a comment in the list of comments of one author ordered from the newest to the oldest.
+type AuthorCursor struct {
+	SortKey   int64
+	CommentID int64
 }
 
-func Validate(cursor string) error {
+// NotificationCursor points at a notification in the list of notifications ordered from the newest to the oldest.
+type NotificationCursor struct {
+	NotificationID int64
+}
+
+// DeliveryCursor points at a webhook delivery in the list of deliveries ordered from the newest to the oldest.
+type DeliveryCursor struct {
+	DeliveryID int64
+}
+
+// RevisionCursor points at a revision in the history of a post or a comment ordered from the newest to the oldest.
+type RevisionCursor struct {
+	Number int32
+}
+
+// PinnedCursor points at a pinned comment. Pinned comments precede the ordered comments of the first level,
+// so the page after a pinned cursor continues with the rest of pinned comments and then with the ordered ones.
+type PinnedCursor struct {
+	CommentID int64
+}
+
+func Create(order string, sortKey int64, commentID int64, parentPath string) string {
+	return encodeCursor(strings.Join([]string{
+		order,
+		strconv.FormatInt(sortKey, 10),
+		strings.Join([]string{parentPath, strconv.FormatInt(commentID, 10)}, "."),
+	}, ":"))
+}
+
+func Parse(cursor string) (*Cursor, error) {
 	cursorString, err := decodeCursor(cursor)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	for _, char := range parts[0] {
+		if !unicode.IsUpper(char) {
+			return nil, errors.New("Cursor " + cursor + " is not valid.")
+		}
+	}
+
+	sortKey, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting sort key from cursor: " + err.Error())
 	}
 
-	cursorString = strings.ReplaceAll(cursorString, ".", "")
-	for _, char := range cursorString {
+	for _, char := range strings.ReplaceAll(parts[2], ".", "") {
 		if !unicode.IsDigit(char) {
-			return errors.New("Cursor " + cursor + " is not valid.")
+			return nil, errors.New("Cursor " + cursor + " is not valid.")
 		}
 	}
 
-	return nil
+	lastDotIndex := strings.LastIndex(parts[2], ".")
+	if lastDotIndex == -1 {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	commentID, err := strconv.ParseInt(parts[2][lastDotIndex+1:], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting commentID from cursor: " + err.Error())
+	}
+
+	return &Cursor{
+		Order:     parts[0],
+		SortKey:   sortKey,
+		Path:      parts[2][:lastDotIndex],
+		CommentID: commentID,
+	}, nil
+}
+
+func CreateSearch(rank float64, id int64) string {
+	return encodeCursor(strings.Join([]string{
+		searchPrefix,
+		strconv.FormatFloat(rank, 'g', -1, 64),
+		strconv.FormatInt(id, 10),
+	}, ":"))
 }
 
-func GetPath(cursor string) (string, error) {
+func ParseSearch(cursor string) (*SearchCursor, error) {
 	cursorString, err := decodeCursor(cursor)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	lastDotIndex := strings.LastIndex(cursorString, ".")
-	if lastDotIndex == -1 {
-		return "", errors.New("Cursor " + cursor + " is not valid.")
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 3 || parts[0] != searchPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
 	}
 
-	return cursorString[:lastDotIndex], nil
+	rank, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil {
+		return nil, errors.New("Error while getting rank from cursor: " + err.Error())
+	}
+
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting ID from cursor: " + err.Error())
+	}
+
+	return &SearchCursor{
+		Rank: rank,
+		ID:   id,
+	}, nil
 }
 
-func GetCommentID(cursor string) (int64, error) {
+func CreatePost(sortKey int64, postID int64) string {
+	return encodeCursor(strings.Join([]string{
+		postPrefix,
+		strconv.FormatInt(sortKey, 10),
+		strconv.FormatInt(postID, 10),
+	}, ":"))
+}
+
+func ParsePost(cursor string) (*PostCursor, error) {
 	cursorString, err := decodeCursor(cursor)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 
-	lastDotIndex := strings.LastIndex(cursorString, ".")
-	if lastDotIndex == -1 {
-		return -1, errors.New("Cursor " + cursor + " is not valid.")
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 3 || parts[0] != postPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	sortKey, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting sort key from cursor: " + err.Error())
+	}
+
+	postID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting postID from cursor: " + err.Error())
+	}
+
+	return &PostCursor{
+		SortKey: sortKey,
+		PostID:  postID,
+	}, nil
+}
+
+func CreateAuthor(sortKey int64, commentID int64) string {
+	return encodeCursor(strings.Join([]string{
+		authorPrefix,
+		strconv.FormatInt(sortKey, 10),
+		strconv.FormatInt(commentID, 10),
+	}, ":"))
+}
+
+func ParseAuthor(cursor string) (*AuthorCursor, error) {
+	cursorString, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 3 || parts[0] != authorPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	sortKey, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting sort key from cursor: " + err.Error())
+	}
+
+	commentID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting commentID from cursor: " + err.Error())
+	}
+
+	return &AuthorCursor{
+		SortKey:   sortKey,
+		CommentID: commentID,
+	}, nil
+}
+
+func CreateNotification(notificationID int64) string {
+	return encodeCursor(strings.Join([]string{
+		notificationPrefix,
+		strconv.FormatInt(notificationID, 10),
+	}, ":"))
+}
+
+func ParseNotification(cursor string) (*NotificationCursor, error) {
+	cursorString, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 2 || parts[0] != notificationPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	notificationID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting notificationID from cursor: " + err.Error())
+	}
+
+	return &NotificationCursor{
+		NotificationID: notificationID,
+	}, nil
+}
+
+func CreateDelivery(deliveryID int64) string {
+	return encodeCursor(strings.Join([]string{
+		deliveryPrefix,
+		strconv.FormatInt(deliveryID, 10),
+	}, ":"))
+}
+
+func ParseDelivery(cursor string) (*DeliveryCursor, error) {
+	cursorString, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 2 || parts[0] != deliveryPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	deliveryID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, errors.New("Error while getting deliveryID from cursor: " + err.Error())
+	}
+
+	return &DeliveryCursor{
+		DeliveryID: deliveryID,
+	}, nil
+}
+
+func CreateRevision(number int32) string {
+	return encodeCursor(strings.Join([]string{
+		revisionPrefix,
+		strconv.FormatInt(int64(number), 10),
+	}, ":"))
+}
+
+func ParseRevision(cursor string) (*RevisionCursor, error) {
+	cursorString, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 2 || parts[0] != revisionPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
+	}
+
+	number, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return nil, errors.New("Error while getting revision number from cursor: " + err.Error())
+	}
+
+	return &RevisionCursor{
+		Number: int32(number),
+	}, nil
+}
+
+func CreatePinned(commentID int64) string {
+	return encodeCursor(strings.Join([]string{
+		pinnedPrefix,
+		strconv.FormatInt(commentID, 10),
+	}, ":"))
+}
+
+func ParsePinned(cursor string) (*PinnedCursor, error) {
+	cursorString, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := strings.Split(cursorString, ":")
+	if len(parts) != 2 || parts[0] != pinnedPrefix {
+		return nil, errors.New("Cursor " + cursor + " is not valid.")
 	}
 
-	commentID, err := strconv.ParseInt(cursorString[lastDotIndex:], 10, 64)
+	commentID, err := strconv.ParseInt(parts[1], 10, 64)
 	if err != nil {
-		return -1, errors.New("Error while getting commentID from cursor: " + err.Error())
+		return nil, errors.New("Error while getting commentID from cursor: " + err.Error())
 	}
 
-	return commentID, nil
+	return &PinnedCursor{
+		CommentID: commentID,
+	}, nil
 }
 
 func encodeCursor(cursorString string) string {
@@ -0,0 +1,159 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Cursor", func(t *testing.T) {
+		parsedCursor, err := Parse(Create("NEWEST", 1700000000000000000, 12, "3.5"))
+		assertions.Nil(err)
+		assertions.Equal(&Cursor{
+			Order:     "NEWEST",
+			SortKey:   1700000000000000000,
+			Path:      "3.5",
+			CommentID: 12,
+		}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Old Format", func(t *testing.T) {
+		parsedCursor, err := Parse(base64.RawStdEncoding.EncodeToString([]byte("3.5.12")))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Incorrect Path", func(t *testing.T) {
+		parsedCursor, err := Parse(base64.RawStdEncoding.EncodeToString([]byte("TOP:2:3.a.12")))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Not Base64", func(t *testing.T) {
+		parsedCursor, err := Parse("!!!")
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParseSearch(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Search Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseSearch(CreateSearch(0.0607927, 42))
+		assertions.Nil(err)
+		assertions.Equal(&SearchCursor{Rank: 0.0607927, ID: 42}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Comments Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseSearch(Create("OLDEST", 1, 2, "3"))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParsePost(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Post Cursor", func(t *testing.T) {
+		parsedCursor, err := ParsePost(CreatePost(1700000000000000000, 7))
+		assertions.Nil(err)
+		assertions.Equal(&PostCursor{SortKey: 1700000000000000000, PostID: 7}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Search Cursor", func(t *testing.T) {
+		parsedCursor, err := ParsePost(CreateSearch(0.5, 7))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParseAuthor(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Author Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseAuthor(CreateAuthor(1700000000000000000, 12))
+		assertions.Nil(err)
+		assertions.Equal(&AuthorCursor{SortKey: 1700000000000000000, CommentID: 12}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Post Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseAuthor(CreatePost(1700000000000000000, 12))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParseNotification(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Notification Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseNotification(CreateNotification(42))
+		assertions.Nil(err)
+		assertions.Equal(&NotificationCursor{NotificationID: 42}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Author Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseNotification(CreateAuthor(1700000000000000000, 42))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParseDelivery(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Delivery Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseDelivery(CreateDelivery(42))
+		assertions.Nil(err)
+		assertions.Equal(&DeliveryCursor{DeliveryID: 42}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Notification Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseDelivery(CreateNotification(42))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParseRevision(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Revision Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseRevision(CreateRevision(3))
+		assertions.Nil(err)
+		assertions.Equal(&RevisionCursor{Number: 3}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Delivery Cursor", func(t *testing.T) {
+		parsedCursor, err := ParseRevision(CreateDelivery(3))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
+
+func TestParsePinned(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Parse Created Pinned Cursor", func(t *testing.T) {
+		parsedCursor, err := ParsePinned(CreatePinned(5))
+		assertions.Nil(err)
+		assertions.Equal(&PinnedCursor{CommentID: 5}, parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Comment Cursor", func(t *testing.T) {
+		parsedCursor, err := ParsePinned(Create("OLDEST", 10, 5, "1"))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+
+	t.Run("Unsuccessful Parse Pinned Cursor As Comment Cursor", func(t *testing.T) {
+		parsedCursor, err := Parse(CreatePinned(5))
+		assertions.NotNil(err)
+		assertions.Nil(parsedCursor)
+	})
+}
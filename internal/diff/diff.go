@@ -0,0 +1,117 @@
+package diff
+
+import (
+	"strings"
+)
+
+type Operation string
+
+const (
+	OperationEqual  Operation = "EQUAL"
+	OperationInsert Operation = "INSERT"
+	OperationDelete Operation = "DELETE"
+)
+
+// Line is a line of the compared texts with the operation that turns the old text into the new one.
+type Line struct {
+	Operation Operation
+	Text      string
+}
+
+// maxTableCells bounds the table of the longest common subsequence, so comparing long texts takes limited memory and time.
+// If the changed part of the texts needs a bigger table, its old lines are shown as deleted and the new ones as inserted.
+const maxTableCells = 1 << 20
+
+// Lines compares two texts line by line. The result is built from the longest common subsequence of lines,
+// so unchanged lines are kept as they are and every other line is either deleted or inserted.
+// Deleted lines go before inserted ones at the same position.
+func Lines(oldText string, newText string) []Line {
+	oldLines := splitLines(oldText)
+	newLines := splitLines(newText)
+
+	// Edits usually change a small part of the text, so the common beginning and end are not compared line by line.
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]Line, 0, max(len(oldLines), len(newLines)))
+	for _, line := range oldLines[:prefix] {
+		lines = append(lines, Line{Operation: OperationEqual, Text: line})
+	}
+
+	lines = appendChanges(lines, oldLines[prefix:len(oldLines)-suffix], newLines[prefix:len(newLines)-suffix])
+
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		lines = append(lines, Line{Operation: OperationEqual, Text: line})
+	}
+
+	return lines
+}
+
+// appendChanges appends the comparison of the changed parts of the texts to the lines.
+func appendChanges(lines []Line, oldLines []string, newLines []string) []Line {
+	if len(oldLines)*len(newLines) > maxTableCells {
+		return appendReplacement(lines, oldLines, newLines)
+	}
+
+	// common[i*width+j] is the length of the longest common subsequence of oldLines[i:] and newLines[j:].
+	width := len(newLines) + 1
+	common := make([]int32, (len(oldLines)+1)*width)
+
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				common[i*width+j] = common[(i+1)*width+j+1] + 1
+			} else {
+				common[i*width+j] = max(common[(i+1)*width+j], common[i*width+j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(oldLines) && j < len(newLines) {
+		switch {
+		case oldLines[i] == newLines[j]:
+			lines = append(lines, Line{Operation: OperationEqual, Text: oldLines[i]})
+			i++
+			j++
+		case common[(i+1)*width+j] >= common[i*width+j+1]:
+			lines = append(lines, Line{Operation: OperationDelete, Text: oldLines[i]})
+			i++
+		default:
+			lines = append(lines, Line{Operation: OperationInsert, Text: newLines[j]})
+			j++
+		}
+	}
+
+	return appendReplacement(lines, oldLines[i:], newLines[j:])
+}
+
+// appendReplacement appends the old lines as deleted and the new lines as inserted.
+func appendReplacement(lines []Line, oldLines []string, newLines []string) []Line {
+	for _, line := range oldLines {
+		lines = append(lines, Line{Operation: OperationDelete, Text: line})
+	}
+
+	for _, line := range newLines {
+		lines = append(lines, Line{Operation: OperationInsert, Text: line})
+	}
+
+	return lines
+}
+
+// splitLines splits the text by line breaks. An empty text has no lines.
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+
+	return strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+}
@@ -0,0 +1,59 @@
+package diff
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLines(t *testing.T) {
+	assertions := assert.New(t)
+
+	testCases := []struct {
+		name     string
+		oldText  string
+		newText  string
+		expected []Line
+	}{
+		{"Equal texts", "a\nb", "a\nb", []Line{{OperationEqual, "a"}, {OperationEqual, "b"}}},
+		{"Empty texts", "", "", []Line{}},
+		{"Added text", "", "a", []Line{{OperationInsert, "a"}}},
+		{"Removed text", "a", "", []Line{{OperationDelete, "a"}}},
+		{"Changed line", "a\nb\nc", "a\nB\nc", []Line{{OperationEqual, "a"}, {OperationDelete, "b"}, {OperationInsert, "B"}, {OperationEqual, "c"}}},
+		{"Appended line", "a\nb", "a\nb\nc", []Line{{OperationEqual, "a"}, {OperationEqual, "b"}, {OperationInsert, "c"}}},
+		{"Removed first line", "a\nb\nc", "b\nc", []Line{{OperationDelete, "a"}, {OperationEqual, "b"}, {OperationEqual, "c"}}},
+		{"Windows line breaks", "a\r\nb", "a\nb", []Line{{OperationEqual, "a"}, {OperationEqual, "b"}}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Successful Lines "+testCase.name, func(t *testing.T) {
+			assertions.Equal(testCase.expected, Lines(testCase.oldText, testCase.newText))
+		})
+	}
+
+	t.Run("Successful Lines Long texts replaced as a whole", func(t *testing.T) {
+		oldLines := make([]string, 0, 2000)
+		newLines := make([]string, 0, 2000)
+		for idx := range 2000 {
+			oldLines = append(oldLines, "old "+strconv.Itoa(idx))
+			newLines = append(newLines, "new "+strconv.Itoa(idx))
+		}
+
+		lines := Lines("first\n"+strings.Join(oldLines, "\n")+"\nlast", "first\n"+strings.Join(newLines, "\n")+"\nlast")
+		assertions.Len(lines, 4002)
+		assertions.Equal(Line{OperationEqual, "first"}, lines[0])
+		assertions.Equal(Line{OperationDelete, "old 0"}, lines[1])
+		assertions.Equal(Line{OperationInsert, "new 0"}, lines[2001])
+		assertions.Equal(Line{OperationEqual, "last"}, lines[4001])
+	})
+
+	t.Run("Successful Lines Long texts with a small change", func(t *testing.T) {
+		text := strings.Repeat("\n", 5000)
+		lines := Lines(text, "changed"+text)
+		assertions.Len(lines, 5002)
+		assertions.Equal(Line{OperationDelete, ""}, lines[0])
+		assertions.Equal(Line{OperationInsert, "changed"}, lines[1])
+	})
+}
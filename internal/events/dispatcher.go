@@ -0,0 +1,129 @@
+package events
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultBatchSize     = 100
+	defaultLeaseDuration = 5 * time.Minute
+)
+
+// Outbox keeps events that are stored but not yet delivered to every sink. Pending events are claimed
+// by one dispatcher at a time, so instances sharing the outbox do not deliver the same event twice.
+type Outbox interface {
+	ClaimPendingEvents(ctx context.Context, ownerID uuid.UUID, now time.Time, leaseUntil time.Time, limit int32) ([]*Envelope, error)
+	MarkEventsDispatched(ctx context.Context, eventIDs []int64) error
+}
+
+// Dispatcher relays events from the outbox to sinks in the order of their IDs.
+// An event is marked as dispatched only after every sink accepted it. A failed sink stops the batch
+// and the rest is retried on the next poll. Sinks that have already accepted an event
+// do not receive it again on retry. Claimed events are leased for leaseDuration, after which another
+// dispatcher may take over the events of a stopped one.
+type Dispatcher struct {
+	outbox        Outbox
+	sinks         []Sink
+	pollInterval  time.Duration
+	batchSize     int32
+	leaseDuration time.Duration
+	ownerID       uuid.UUID
+	delivered     []map[int64]struct{}
+}
+
+func NewDispatcher(outbox Outbox, pollInterval time.Duration, sinks ...Sink) *Dispatcher {
+	delivered := make([]map[int64]struct{}, len(sinks))
+	for idx := range delivered {
+		delivered[idx] = make(map[int64]struct{})
+	}
+
+	return &Dispatcher{
+		outbox:        outbox,
+		sinks:         sinks,
+		pollInterval:  pollInterval,
+		batchSize:     defaultBatchSize,
+		leaseDuration: defaultLeaseDuration,
+		ownerID:       uuid.New(),
+		delivered:     delivered,
+	}
+}
+
+// Run polls the outbox until the context is cancelled.
+func (dispatcher *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(dispatcher.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		for {
+			dispatched, err := dispatcher.DispatchPending(ctx)
+			if err != nil {
+				log.Printf("Error while dispatching events: %s", err)
+				break
+			}
+
+			if dispatched < int(dispatcher.batchSize) {
+				break
+			}
+		}
+	}
+}
+
+// DispatchPending delivers one batch of pending events and returns the number of dispatched ones.
+func (dispatcher *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	now := time.Now()
+	envelopes, err := dispatcher.outbox.ClaimPendingEvents(ctx, dispatcher.ownerID, now, now.Add(dispatcher.leaseDuration), dispatcher.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatchedIDs := make([]int64, 0, len(envelopes))
+	var deliveryErr error
+	for _, envelope := range envelopes {
+		if deliveryErr = dispatcher.deliver(ctx, envelope); deliveryErr != nil {
+			break
+		}
+
+		dispatchedIDs = append(dispatchedIDs, envelope.ID)
+	}
+
+	if len(dispatchedIDs) > 0 {
+		if err = dispatcher.outbox.MarkEventsDispatched(ctx, dispatchedIDs); err != nil {
+			return 0, err
+		}
+
+		for _, sinkDelivered := range dispatcher.delivered {
+			for _, eventID := range dispatchedIDs {
+				delete(sinkDelivered, eventID)
+			}
+		}
+	}
+
+	return len(dispatchedIDs), deliveryErr
+}
+
+func (dispatcher *Dispatcher) deliver(ctx context.Context, envelope *Envelope) error {
+	for idx, sink := range dispatcher.sinks {
+		if _, ok := dispatcher.delivered[idx][envelope.ID]; ok {
+			continue
+		}
+
+		if err := sink.Deliver(ctx, envelope); err != nil {
+			return err
+		}
+
+		dispatcher.delivered[idx][envelope.ID] = struct{}{}
+	}
+
+	return nil
+}
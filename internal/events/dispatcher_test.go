@@ -0,0 +1,98 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testOutbox struct {
+	pending []*Envelope
+}
+
+func (outbox *testOutbox) ClaimPendingEvents(ctx context.Context, ownerID uuid.UUID, now time.Time, leaseUntil time.Time, limit int32) ([]*Envelope, error) {
+	return outbox.pending[:min(int(limit), len(outbox.pending))], nil
+}
+
+func (outbox *testOutbox) MarkEventsDispatched(ctx context.Context, eventIDs []int64) error {
+	outbox.pending = slices.DeleteFunc(outbox.pending, func(envelope *Envelope) bool {
+		return slices.Contains(eventIDs, envelope.ID)
+	})
+	return nil
+}
+
+type testSink struct {
+	received []int64
+	failOn   int64
+}
+
+func (sink *testSink) Deliver(ctx context.Context, envelope *Envelope) error {
+	if envelope.ID == sink.failOn {
+		return errors.New("Sink is unavailable")
+	}
+
+	sink.received = append(sink.received, envelope.ID)
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("Successful DispatchPending", func(t *testing.T) {
+		outbox := &testOutbox{pending: []*Envelope{{ID: 1}, {ID: 2}, {ID: 3}}}
+		first := &testSink{}
+		second := &testSink{}
+		dispatcher := NewDispatcher(outbox, time.Second, first, second)
+
+		dispatched, err := dispatcher.DispatchPending(ctx)
+		assertions.NoError(err)
+		assertions.Equal(3, dispatched)
+		assertions.Empty(outbox.pending)
+		assertions.Equal([]int64{1, 2, 3}, first.received)
+		assertions.Equal([]int64{1, 2, 3}, second.received)
+	})
+
+	t.Run("Successful DispatchPending retry after failure", func(t *testing.T) {
+		outbox := &testOutbox{pending: []*Envelope{{ID: 1}, {ID: 2}, {ID: 3}}}
+		first := &testSink{}
+		second := &testSink{failOn: 2}
+		dispatcher := NewDispatcher(outbox, time.Second, first, second)
+
+		dispatched, err := dispatcher.DispatchPending(ctx)
+		assertions.Error(err)
+		assertions.Equal(1, dispatched)
+		assertions.Equal([]int64{1, 2}, first.received)
+		assertions.Equal([]int64{1}, second.received)
+
+		second.failOn = 0
+		dispatched, err = dispatcher.DispatchPending(ctx)
+		assertions.NoError(err)
+		assertions.Equal(2, dispatched)
+		assertions.Empty(outbox.pending)
+		assertions.Equal([]int64{1, 2, 3}, first.received)
+		assertions.Equal([]int64{1, 2, 3}, second.received)
+	})
+
+	t.Run("Successful Run", func(t *testing.T) {
+		outbox := &testOutbox{pending: []*Envelope{{ID: 1}}}
+		sink := NewChannelSink(1)
+		dispatcher := NewDispatcher(outbox, time.Millisecond, sink)
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go dispatcher.Run(ctx)
+
+		select {
+		case envelope := <-sink.Events():
+			assertions.Equal(int64(1), envelope.ID)
+		case <-time.After(time.Second):
+			assertions.Fail("Event was not dispatched")
+		}
+	})
+}
@@ -0,0 +1,381 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+type Kind string
+
+const (
+	KindPostCreated          Kind = "POST_CREATED"
+	KindPostEdited           Kind = "POST_EDITED"
+	KindCommentAdded         Kind = "COMMENT_ADDED"
+	KindCommentStatusChanged Kind = "COMMENT_STATUS_CHANGED"
+	KindCommentsToggled      Kind = "COMMENTS_TOGGLED"
+	KindCommentEdited        Kind = "COMMENT_EDITED"
+	KindPostDeleted          Kind = "POST_DELETED"
+	KindPostRestored         Kind = "POST_RESTORED"
+	KindCommentDeleted       Kind = "COMMENT_DELETED"
+	KindCommentRestored      Kind = "COMMENT_RESTORED"
+	KindCommentPinToggled    Kind = "COMMENT_PIN_TOGGLED"
+	KindPostPublished        Kind = "POST_PUBLISHED"
+	KindReactionToggled      Kind = "REACTION_TOGGLED"
+)
+
+// Event is a state change of posts or comments. Events are stored in the outbox together with the change
+// and then relayed to sinks by the dispatcher.
+type Event interface {
+	Kind() Kind
+}
+
+type PostCreated struct {
+	PostID          int64                `json:"postID"`
+	AuthorID        uuid.UUID            `json:"authorID"`
+	Title           string               `json:"title"`
+	Text            string               `json:"text"`
+	CommentsEnabled bool                 `json:"commentsEnabled"`
+	ModerationMode  model.ModerationMode `json:"moderationMode"`
+	CreateDate      time.Time            `json:"createDate"`
+}
+
+func (PostCreated) Kind() Kind {
+	return KindPostCreated
+}
+
+func NewPostCreated(post *model.Post) *PostCreated {
+	return &PostCreated{
+		PostID:          post.ID,
+		AuthorID:        post.AuthorID,
+		Title:           post.Title,
+		Text:            post.Text,
+		CommentsEnabled: post.CommentsEnabled,
+		ModerationMode:  post.ModerationMode,
+		CreateDate:      post.CreateDate,
+	}
+}
+
+type PostEdited struct {
+	PostID         int64     `json:"postID"`
+	EditorID       uuid.UUID `json:"editorID"`
+	Title          string    `json:"title"`
+	Text           string    `json:"text"`
+	EditDate       time.Time `json:"editDate"`
+	RevisionNumber int32     `json:"revisionNumber"`
+}
+
+func (PostEdited) Kind() Kind {
+	return KindPostEdited
+}
+
+func NewPostEdited(post *model.Post, revision *model.Revision) *PostEdited {
+	return &PostEdited{
+		PostID:         post.ID,
+		EditorID:       revision.EditorID,
+		Title:          post.Title,
+		Text:           post.Text,
+		EditDate:       revision.CreateDate,
+		RevisionNumber: revision.Number,
+	}
+}
+
+type PostDeleted struct {
+	PostID     int64     `json:"postID"`
+	ActorID    uuid.UUID `json:"actorID"`
+	DeleteDate time.Time `json:"deleteDate"`
+}
+
+func (PostDeleted) Kind() Kind {
+	return KindPostDeleted
+}
+
+func NewPostDeleted(post *model.Post, actorID uuid.UUID) *PostDeleted {
+	return &PostDeleted{
+		PostID:     post.ID,
+		ActorID:    actorID,
+		DeleteDate: *post.DeletedAt,
+	}
+}
+
+// PostPublished is added when a draft becomes visible to everyone, either on request of its author
+// or by the scheduler.
+type PostPublished struct {
+	PostID      int64     `json:"postID"`
+	AuthorID    uuid.UUID `json:"authorID"`
+	Title       string    `json:"title"`
+	Text        string    `json:"text"`
+	PublishDate time.Time `json:"publishDate"`
+}
+
+func (PostPublished) Kind() Kind {
+	return KindPostPublished
+}
+
+func NewPostPublished(post *model.Post) *PostPublished {
+	return &PostPublished{
+		PostID:      post.ID,
+		AuthorID:    post.AuthorID,
+		Title:       post.Title,
+		Text:        post.Text,
+		PublishDate: *post.PublishAt,
+	}
+}
+
+type PostRestored struct {
+	PostID  int64     `json:"postID"`
+	ActorID uuid.UUID `json:"actorID"`
+}
+
+func (PostRestored) Kind() Kind {
+	return KindPostRestored
+}
+
+func NewPostRestored(post *model.Post, actorID uuid.UUID) *PostRestored {
+	return &PostRestored{
+		PostID:  post.ID,
+		ActorID: actorID,
+	}
+}
+
+type CommentAdded struct {
+	CommentID  int64               `json:"commentID"`
+	PostID     int64               `json:"postID"`
+	ParentID   *int64              `json:"parentID,omitempty"`
+	AuthorID   uuid.UUID           `json:"authorID"`
+	Text       string              `json:"text"`
+	Status     model.CommentStatus `json:"status"`
+	CreateDate time.Time           `json:"createDate"`
+}
+
+func (CommentAdded) Kind() Kind {
+	return KindCommentAdded
+}
+
+func NewCommentAdded(comment *model.Comment) *CommentAdded {
+	return &CommentAdded{
+		CommentID:  comment.ID,
+		PostID:     comment.PostID,
+		ParentID:   comment.ParentID,
+		AuthorID:   comment.AuthorID,
+		Text:       comment.Text,
+		Status:     comment.Status,
+		CreateDate: comment.CreateDate,
+	}
+}
+
+// CommentStatusChanged is added when a comment awaiting premoderation is approved or rejected. Pending comments
+// are not announced, so on approval the event carries the text and the author of the comment.
+type CommentStatusChanged struct {
+	CommentID int64               `json:"commentID"`
+	PostID    int64               `json:"postID"`
+	ParentID  *int64              `json:"parentID,omitempty"`
+	AuthorID  *uuid.UUID          `json:"authorID,omitempty"`
+	Text      *string             `json:"text,omitempty"`
+	Status    model.CommentStatus `json:"status"`
+}
+
+func (CommentStatusChanged) Kind() Kind {
+	return KindCommentStatusChanged
+}
+
+func NewCommentStatusChanged(comment *model.Comment) *CommentStatusChanged {
+	event := &CommentStatusChanged{
+		CommentID: comment.ID,
+		PostID:    comment.PostID,
+		Status:    comment.Status,
+	}
+
+	if comment.Status == model.CommentStatusApproved {
+		event.ParentID = comment.ParentID
+		event.AuthorID = &comment.AuthorID
+		event.Text = &comment.Text
+	}
+
+	return event
+}
+
+type CommentEdited struct {
+	CommentID      int64     `json:"commentID"`
+	PostID         int64     `json:"postID"`
+	EditorID       uuid.UUID `json:"editorID"`
+	Text           string    `json:"text"`
+	EditDate       time.Time `json:"editDate"`
+	RevisionNumber int32     `json:"revisionNumber"`
+}
+
+func (CommentEdited) Kind() Kind {
+	return KindCommentEdited
+}
+
+func NewCommentEdited(comment *model.Comment, revision *model.Revision) *CommentEdited {
+	return &CommentEdited{
+		CommentID:      comment.ID,
+		PostID:         comment.PostID,
+		EditorID:       revision.EditorID,
+		Text:           comment.Text,
+		EditDate:       revision.CreateDate,
+		RevisionNumber: revision.Number,
+	}
+}
+
+type CommentDeleted struct {
+	CommentID  int64     `json:"commentID"`
+	PostID     int64     `json:"postID"`
+	ActorID    uuid.UUID `json:"actorID"`
+	DeleteDate time.Time `json:"deleteDate"`
+}
+
+func (CommentDeleted) Kind() Kind {
+	return KindCommentDeleted
+}
+
+func NewCommentDeleted(comment *model.Comment, actorID uuid.UUID) *CommentDeleted {
+	return &CommentDeleted{
+		CommentID:  comment.ID,
+		PostID:     comment.PostID,
+		ActorID:    actorID,
+		DeleteDate: *comment.DeletedAt,
+	}
+}
+
+type CommentRestored struct {
+	CommentID int64     `json:"commentID"`
+	PostID    int64     `json:"postID"`
+	ActorID   uuid.UUID `json:"actorID"`
+}
+
+func (CommentRestored) Kind() Kind {
+	return KindCommentRestored
+}
+
+func NewCommentRestored(comment *model.Comment, actorID uuid.UUID) *CommentRestored {
+	return &CommentRestored{
+		CommentID: comment.ID,
+		PostID:    comment.PostID,
+		ActorID:   actorID,
+	}
+}
+
+type CommentsToggled struct {
+	PostID          int64                `json:"postID"`
+	CommentsEnabled bool                 `json:"commentsEnabled"`
+	ModerationMode  model.ModerationMode `json:"moderationMode"`
+}
+
+func (CommentsToggled) Kind() Kind {
+	return KindCommentsToggled
+}
+
+func NewCommentsToggled(post *model.Post) *CommentsToggled {
+	return &CommentsToggled{
+		PostID:          post.ID,
+		CommentsEnabled: post.CommentsEnabled,
+		ModerationMode:  post.ModerationMode,
+	}
+}
+
+type CommentPinToggled struct {
+	CommentID int64 `json:"commentID"`
+	PostID    int64 `json:"postID"`
+	Pinned    bool  `json:"pinned"`
+}
+
+func (CommentPinToggled) Kind() Kind {
+	return KindCommentPinToggled
+}
+
+func NewCommentPinToggled(comment *model.Comment, pinned bool) *CommentPinToggled {
+	return &CommentPinToggled{
+		CommentID: comment.ID,
+		PostID:    comment.PostID,
+		Pinned:    pinned,
+	}
+}
+
+type ReactionToggled struct {
+	TargetType   model.ReactionTarget `json:"targetType"`
+	TargetID     int64                `json:"targetID"`
+	UserID       uuid.UUID            `json:"userID"`
+	ReactionKind model.ReactionKind   `json:"reactionKind"`
+	Added        bool                 `json:"added"`
+}
+
+func (ReactionToggled) Kind() Kind {
+	return KindReactionToggled
+}
+
+func NewReactionToggled(reaction *model.ReactionInput, added bool) *ReactionToggled {
+	return &ReactionToggled{
+		TargetType:   reaction.TargetType,
+		TargetID:     reaction.TargetID,
+		UserID:       reaction.UserID,
+		ReactionKind: reaction.Kind,
+		Added:        added,
+	}
+}
+
+// Envelope is the serialized form of an event, which is stored in the outbox and delivered to sinks.
+// ID is assigned by the storage and grows with every event, so receivers can use it to drop duplicates.
+type Envelope struct {
+	ID         int64           `json:"id"`
+	Kind       Kind            `json:"kind"`
+	Payload    json.RawMessage `json:"payload"`
+	CreateDate time.Time       `json:"createDate"`
+}
+
+func NewEnvelope(event Event) (*Envelope, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Envelope{
+		Kind:    event.Kind(),
+		Payload: payload,
+	}, nil
+}
+
+// Decode restores the typed event from the payload.
+func (envelope *Envelope) Decode() (Event, error) {
+	var event Event
+	switch envelope.Kind {
+	case KindPostCreated:
+		event = &PostCreated{}
+	case KindPostEdited:
+		event = &PostEdited{}
+	case KindCommentAdded:
+		event = &CommentAdded{}
+	case KindCommentStatusChanged:
+		event = &CommentStatusChanged{}
+	case KindCommentsToggled:
+		event = &CommentsToggled{}
+	case KindCommentEdited:
+		event = &CommentEdited{}
+	case KindPostDeleted:
+		event = &PostDeleted{}
+	case KindPostRestored:
+		event = &PostRestored{}
+	case KindCommentDeleted:
+		event = &CommentDeleted{}
+	case KindCommentRestored:
+		event = &CommentRestored{}
+	case KindCommentPinToggled:
+		event = &CommentPinToggled{}
+	case KindPostPublished:
+		event = &PostPublished{}
+	case KindReactionToggled:
+		event = &ReactionToggled{}
+	default:
+		return nil, errors.New("Unknown event kind: " + string(envelope.Kind) + ".")
+	}
+
+	if err := json.Unmarshal(envelope.Payload, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
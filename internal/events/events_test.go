@@ -0,0 +1,51 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnvelope(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Decode", func(t *testing.T) {
+		parentID := int64(3)
+		comment := &model.Comment{
+			ID:         4,
+			AuthorID:   uuid.New(),
+			PostID:     1,
+			ParentID:   &parentID,
+			Text:       "Comment",
+			CreateDate: time.Now().UTC(),
+			Status:     model.CommentStatusApproved,
+		}
+
+		envelope, err := NewEnvelope(NewCommentAdded(comment))
+		assertions.NoError(err)
+		assertions.Equal(KindCommentAdded, envelope.Kind)
+
+		event, err := envelope.Decode()
+		assertions.NoError(err)
+		assertions.Equal(NewCommentAdded(comment), event)
+	})
+
+	t.Run("Unsuccessful Decode unknown kind", func(t *testing.T) {
+		envelope := &Envelope{Kind: "UNKNOWN", Payload: []byte(`{}`)}
+
+		event, err := envelope.Decode()
+		assertions.Error(err)
+		assertions.Nil(event)
+	})
+
+	t.Run("Unsuccessful Decode broken payload", func(t *testing.T) {
+		envelope := &Envelope{Kind: KindPostCreated, Payload: []byte(`{`)}
+
+		event, err := envelope.Decode()
+		assertions.Error(err)
+		assertions.Nil(event)
+	})
+}
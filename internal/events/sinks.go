@@ -0,0 +1,131 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Sink receives events from the dispatcher. Deliver must return an error if the event was not accepted,
+// then the dispatcher will retry it.
+type Sink interface {
+	Deliver(ctx context.Context, envelope *Envelope) error
+}
+
+// ChannelSink passes events to consumers inside the process.
+type ChannelSink struct {
+	events chan *Envelope
+}
+
+func NewChannelSink(bufferSize int) *ChannelSink {
+	return &ChannelSink{
+		events: make(chan *Envelope, bufferSize),
+	}
+}
+
+func (channelSink *ChannelSink) Events() <-chan *Envelope {
+	return channelSink.events
+}
+
+// Deliver waits for free space in the buffer, so a slow consumer holds back the dispatcher instead of losing events.
+func (channelSink *ChannelSink) Deliver(ctx context.Context, envelope *Envelope) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	case channelSink.events <- envelope:
+		return nil
+	}
+}
+
+// WebhookSink posts events as JSON to the configured URL.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSink(url string, client *http.Client) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		client: client,
+	}
+}
+
+func (webhookSink *WebhookSink) Deliver(ctx context.Context, envelope *Envelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookSink.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-ID", strconv.FormatInt(envelope.ID, 10))
+	request.Header.Set("X-Event-Kind", string(envelope.Kind))
+
+	response, err := webhookSink.client.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return &DeliveryError{URL: webhookSink.url, StatusCode: response.StatusCode}
+	}
+
+	return nil
+}
+
+type DeliveryError struct {
+	URL        string
+	StatusCode int
+}
+
+func (deliveryError *DeliveryError) Error() string {
+	return "Webhook " + deliveryError.URL + " responded with status " + strconv.Itoa(deliveryError.StatusCode) + "."
+}
+
+// FileSink appends events to a file, one JSON object per line.
+type FileSink struct {
+	file  *os.File
+	mutex sync.Mutex
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{
+		file: file,
+	}, nil
+}
+
+func (fileSink *FileSink) Deliver(ctx context.Context, envelope *Envelope) error {
+	line, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	defer fileSink.mutex.Unlock()
+	fileSink.mutex.Lock()
+
+	if _, err = fileSink.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return fileSink.file.Sync()
+}
+
+func (fileSink *FileSink) Close() error {
+	return fileSink.file.Close()
+}
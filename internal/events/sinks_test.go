@@ -0,0 +1,102 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelSink(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Deliver", func(t *testing.T) {
+		sink := NewChannelSink(1)
+		envelope := &Envelope{ID: 1, Kind: KindPostCreated}
+
+		assertions.NoError(sink.Deliver(context.Background(), envelope))
+		assertions.Equal(envelope, <-sink.Events())
+	})
+
+	t.Run("Unsuccessful Deliver full buffer", func(t *testing.T) {
+		sink := NewChannelSink(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assertions.ErrorIs(sink.Deliver(ctx, &Envelope{ID: 1}), context.Canceled)
+	})
+}
+
+func TestWebhookSink(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Deliver", func(t *testing.T) {
+		var received Envelope
+		var headers http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &received)
+		}))
+		defer server.Close()
+
+		envelope := &Envelope{ID: 7, Kind: KindCommentsToggled, Payload: []byte(`{"postID":1}`)}
+		sink := NewWebhookSink(server.URL, server.Client())
+
+		assertions.NoError(sink.Deliver(context.Background(), envelope))
+		assertions.Equal("7", headers.Get("X-Event-ID"))
+		assertions.Equal(string(KindCommentsToggled), headers.Get("X-Event-Kind"))
+		assertions.Equal(envelope.ID, received.ID)
+		assertions.JSONEq(string(envelope.Payload), string(received.Payload))
+	})
+
+	t.Run("Unsuccessful Deliver error status", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		sink := NewWebhookSink(server.URL, server.Client())
+
+		err := sink.Deliver(context.Background(), &Envelope{ID: 1, Kind: KindPostCreated, Payload: []byte(`{}`)})
+		assertions.Equal(&DeliveryError{URL: server.URL, StatusCode: http.StatusServiceUnavailable}, err)
+	})
+}
+
+func TestFileSink(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Deliver", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		sink, err := NewFileSink(path)
+		assertions.NoError(err)
+
+		assertions.NoError(sink.Deliver(context.Background(), &Envelope{ID: 1, Kind: KindPostCreated, Payload: []byte(`{}`)}))
+		assertions.NoError(sink.Deliver(context.Background(), &Envelope{ID: 2, Kind: KindCommentAdded, Payload: []byte(`{}`)}))
+		assertions.NoError(sink.Close())
+
+		content, err := os.ReadFile(path)
+		assertions.NoError(err)
+
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		assertions.Len(lines, 2)
+
+		var envelope Envelope
+		assertions.NoError(json.Unmarshal([]byte(lines[1]), &envelope))
+		assertions.Equal(int64(2), envelope.ID)
+		assertions.Equal(KindCommentAdded, envelope.Kind)
+	})
+
+	t.Run("Unsuccessful NewFileSink", func(t *testing.T) {
+		sink, err := NewFileSink(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+		assertions.Error(err)
+		assertions.Nil(sink)
+	})
+}
@@ -0,0 +1,76 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+const (
+	atomNamespace   = "http://www.w3.org/2005/Atom"
+	atomContentType = "application/atom+xml; charset=utf-8"
+)
+
+// Feed is an Atom feed document (RFC 4287).
+type Feed struct {
+	XMLName xml.Name  `xml:"feed"`
+	XMLNS   string    `xml:"xmlns,attr"`
+	ID      string    `xml:"id"`
+	Title   string    `xml:"title"`
+	Updated time.Time `xml:"updated"`
+	Links   []Link    `xml:"link"`
+	Entries []*Entry  `xml:"entry"`
+}
+
+type Link struct {
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type Person struct {
+	Name string `xml:"name"`
+}
+
+type Content struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+type Entry struct {
+	ID        string    `xml:"id"`
+	Title     string    `xml:"title"`
+	Updated   time.Time `xml:"updated"`
+	Published time.Time `xml:"published"`
+	Author    Person    `xml:"author"`
+	Content   Content   `xml:"content"`
+}
+
+// newFeed creates a feed whose updated time is the latest updated time of its entries, or fallback
+// when the feed is empty.
+func newFeed(id string, title string, selfURL string, fallback time.Time, entries []*Entry) *Feed {
+	updated := fallback.UTC()
+	for _, entry := range entries {
+		if entry.Updated.After(updated) {
+			updated = entry.Updated
+		}
+	}
+
+	return &Feed{
+		XMLNS:   atomNamespace,
+		ID:      id,
+		Title:   title,
+		Updated: updated,
+		Links:   []Link{{Rel: "self", Type: atomContentType, Href: selfURL}},
+		Entries: entries,
+	}
+}
+
+// Encode returns the feed document with the XML declaration.
+func (feed *Feed) Encode() ([]byte, error) {
+	body, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,310 @@
+package feeds
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+const idPrefix = "urn:simple-posts:"
+
+// Renderer turns Markdown texts of posts and comments into HTML.
+type Renderer interface {
+	RenderHTML(text string) string
+}
+
+// Limits bounds the number of entries of a feed. Clients may ask for up to Max entries with the limit parameter.
+type Limits struct {
+	Default int32
+	Max     int32
+}
+
+// Handler serves Atom feeds of published posts, of posts of an author and of comments of a post.
+// Feeds are public, so drafts, deleted records and comments awaiting moderation are never included.
+type Handler struct {
+	accessor storage.Accessor
+	renderer Renderer
+	limits   Limits
+	mux      *http.ServeMux
+}
+
+func NewHandler(accessor storage.Accessor, renderer Renderer, limits Limits) *Handler {
+	handler := &Handler{
+		accessor: accessor,
+		renderer: renderer,
+		limits:   limits,
+		mux:      http.NewServeMux(),
+	}
+
+	handler.mux.HandleFunc("GET /feeds/posts.atom", handler.serve(handler.postsFeed))
+	handler.mux.HandleFunc("GET /feeds/posts/{id}/comments.atom", handler.serve(handler.commentsFeed))
+	handler.mux.HandleFunc("GET /feeds/authors/{id}/posts.atom", handler.serve(handler.authorFeed))
+
+	return handler
+}
+
+func (handler *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	handler.mux.ServeHTTP(writer, request)
+}
+
+// feedBuilder builds the feed for the request. It returns the HTTP status and the error shown to the client
+// when the feed cannot be built.
+type feedBuilder func(request *http.Request, limit int32) (*Feed, int, error)
+
+// serve writes the feed with its ETag, or only the status Not Modified when the client has the same feed.
+func (handler *Handler) serve(build feedBuilder) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		limit, err := handler.getLimit(request)
+		if err != nil {
+			http.Error(writer, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		feed, status, err := build(request, limit)
+		if err != nil {
+			http.Error(writer, err.Error(), status)
+			return
+		}
+
+		body, err := feed.Encode()
+		if err != nil {
+			log.Printf("Error while encoding feed %s: %s", feed.ID, err)
+			http.Error(writer, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		hash := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+		writer.Header().Set("ETag", etag)
+		writer.Header().Set("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+
+		if matchesETag(request.Header.Get("If-None-Match"), etag) {
+			writer.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		writer.Header().Set("Content-Type", atomContentType)
+		writer.Write(body)
+	}
+}
+
+// getLimit reads the number of entries from the limit parameter.
+func (handler *Handler) getLimit(request *http.Request) (int32, error) {
+	value := request.URL.Query().Get("limit")
+	if value == "" {
+		return handler.limits.Default, nil
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || limit <= 0 || limit > int64(handler.limits.Max) {
+		return 0, errors.New("Limit must be a number from 1 to " + strconv.FormatInt(int64(handler.limits.Max), 10) + ".")
+	}
+
+	return int32(limit), nil
+}
+
+// matchesETag reports whether the If-None-Match header lists the ETag. Weak validators match as well.
+func matchesETag(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (handler *Handler) postsFeed(request *http.Request, limit int32) (*Feed, int, error) {
+	posts, err := handler.accessor.GetPosts(request.Context(), nil, uuid.Nil, nil, &limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("posts", err)
+	}
+
+	entries, err := handler.postEntries(request.Context(), posts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("posts", err)
+	}
+
+	return newFeed(idPrefix+"posts", "Posts", selfURL(request), time.Unix(0, 0), entries), http.StatusOK, nil
+}
+
+func (handler *Handler) authorFeed(request *http.Request, limit int32) (*Feed, int, error) {
+	authorID, err := uuid.Parse(request.PathValue("id"))
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("Author was not found.")
+	}
+
+	// Authors are not required to have a profile, the feed then names them by ID.
+	var author *model.User
+	emptyFeedUpdated := time.Unix(0, 0)
+	if user, err := handler.accessor.GetUser(request.Context(), authorID); err == nil {
+		author = user
+		emptyFeedUpdated = user.CreateDate
+	}
+
+	posts, err := handler.accessor.GetPosts(request.Context(), &model.PostsFilter{AuthorID: &authorID}, uuid.Nil, nil, &limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("posts of the author", err)
+	}
+
+	entries, err := handler.postEntries(request.Context(), posts)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("posts of the author", err)
+	}
+
+	return newFeed(idPrefix+"author:"+authorID.String()+":posts", "Posts by "+getAuthorName(author, authorID), selfURL(request),
+		emptyFeedUpdated, entries), http.StatusOK, nil
+}
+
+func (handler *Handler) commentsFeed(request *http.Request, limit int32) (*Feed, int, error) {
+	postID, err := strconv.ParseInt(request.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, http.StatusNotFound, errors.New("Post was not found.")
+	}
+
+	post, err := handler.accessor.GetPost(request.Context(), postID, uuid.Nil)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, http.StatusNotFound, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found.")
+	} else if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("post", err)
+	}
+
+	comments, err := handler.accessor.GetRecentComments(request.Context(), postID, limit)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("comments", err)
+	}
+
+	entries, err := handler.commentEntries(request.Context(), comments)
+	if err != nil {
+		return nil, http.StatusInternalServerError, handler.internalError("comments", err)
+	}
+
+	return newFeed(idPrefix+"post:"+strconv.FormatInt(postID, 10)+":comments", "Comments on "+post.Title, selfURL(request),
+		getPublishDate(post), entries), http.StatusOK, nil
+}
+
+func (handler *Handler) postEntries(ctx context.Context, posts []*model.Post) ([]*Entry, error) {
+	postIDs := make([]int64, 0, len(posts))
+	authorIDs := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+		authorIDs = append(authorIDs, post.AuthorID)
+	}
+
+	editDates, err := handler.accessor.GetPostsEditDates(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	authors, err := handler.accessor.GetUsers(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(posts))
+	for _, post := range posts {
+		published := getPublishDate(post)
+		entries = append(entries, &Entry{
+			ID:        idPrefix + "post:" + strconv.FormatInt(post.ID, 10),
+			Title:     post.Title,
+			Updated:   getUpdateDate(published, editDates[post.ID]),
+			Published: published,
+			Author:    Person{Name: getAuthorName(authors[post.AuthorID], post.AuthorID)},
+			Content:   Content{Type: "html", Body: handler.renderer.RenderHTML(post.Text)},
+		})
+	}
+
+	return entries, nil
+}
+
+func (handler *Handler) commentEntries(ctx context.Context, comments []*model.Comment) ([]*Entry, error) {
+	commentIDs := make([]int64, 0, len(comments))
+	authorIDs := make([]uuid.UUID, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.ID)
+		authorIDs = append(authorIDs, comment.AuthorID)
+	}
+
+	editDates, err := handler.accessor.GetCommentsEditDates(ctx, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	authors, err := handler.accessor.GetUsers(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*Entry, 0, len(comments))
+	for _, comment := range comments {
+		authorName := getAuthorName(authors[comment.AuthorID], comment.AuthorID)
+		published := comment.CreateDate.UTC()
+		entries = append(entries, &Entry{
+			ID:        idPrefix + "comment:" + strconv.FormatInt(comment.ID, 10),
+			Title:     "Comment by " + authorName,
+			Updated:   getUpdateDate(published, editDates[comment.ID]),
+			Published: published,
+			Author:    Person{Name: authorName},
+			Content:   Content{Type: "html", Body: handler.renderer.RenderHTML(comment.Text)},
+		})
+	}
+
+	return entries, nil
+}
+
+// internalError logs the error and hides it from the client.
+func (handler *Handler) internalError(records string, err error) error {
+	log.Printf("Error while reading %s for a feed: %s", records, err)
+	return errors.New(http.StatusText(http.StatusInternalServerError))
+}
+
+// getPublishDate returns the date the post became visible: its publication date for scheduled posts.
+func getPublishDate(post *model.Post) time.Time {
+	if post.PublishAt != nil {
+		return post.PublishAt.UTC()
+	}
+
+	return post.CreateDate.UTC()
+}
+
+// getUpdateDate returns the date of the last edit, or the publish date if the record was edited before it was published.
+func getUpdateDate(published time.Time, edited time.Time) time.Time {
+	if edited.After(published) {
+		return edited.UTC()
+	}
+
+	return published
+}
+
+func getAuthorName(author *model.User, authorID uuid.UUID) string {
+	if author == nil || author.DisplayName == "" {
+		return authorID.String()
+	}
+
+	return author.DisplayName
+}
+
+// selfURL restores the absolute URL of the feed from the request.
+func selfURL(request *http.Request) string {
+	scheme := "http"
+	if request.TLS != nil {
+		scheme = "https"
+	}
+
+	if forwarded := request.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+
+	return scheme + "://" + request.Host + request.URL.RequestURI()
+}
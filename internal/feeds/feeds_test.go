@@ -0,0 +1,126 @@
+package feeds
+
+import (
+	"context"
+	"encoding/xml"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type escapingRenderer struct{}
+
+func (escapingRenderer) RenderHTML(text string) string {
+	return "<p>" + html.EscapeString(text) + "</p>"
+}
+
+func getFeed(handler http.Handler, target string, etag string) (*httptest.ResponseRecorder, *Feed) {
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	var feed Feed
+	if recorder.Code == http.StatusOK {
+		xml.Unmarshal(recorder.Body.Bytes(), &feed)
+	}
+
+	return recorder, &feed
+}
+
+func TestHandler(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+	draft := model.PostStatusDraft
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	_, err := accessor.AddUser(ctx, &model.UserInput{ID: &authorID, DisplayName: "Author"})
+	assertions.NoError(err)
+
+	first, err := accessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "First", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+	second, err := accessor.AddPost(ctx, &model.PostInput{AuthorID: uuid.New(), Title: "Second", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+	_, err = accessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Draft", Text: "Text", Status: &draft})
+	assertions.NoError(err)
+	_, err = accessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: first.ID, Text: "<b>Comment</b>"})
+	assertions.NoError(err)
+
+	handler := NewHandler(accessor, escapingRenderer{}, Limits{Default: 10, Max: 20})
+
+	t.Run("Successful Posts Feed", func(t *testing.T) {
+		recorder, feed := getFeed(handler, "/feeds/posts.atom", "")
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Equal(atomContentType, recorder.Header().Get("Content-Type"))
+		assertions.NotEmpty(recorder.Header().Get("ETag"))
+
+		assertions.Len(feed.Entries, 2)
+		assertions.Equal("Second", feed.Entries[0].Title)
+		assertions.Equal("First", feed.Entries[1].Title)
+		assertions.Equal("Author", feed.Entries[1].Author.Name)
+		assertions.Equal("<p>Text</p>", feed.Entries[1].Content.Body)
+		assertions.True(feed.Updated.Equal(feed.Entries[0].Updated))
+	})
+
+	t.Run("Successful Posts Feed With Limit", func(t *testing.T) {
+		_, feed := getFeed(handler, "/feeds/posts.atom?limit=1", "")
+		assertions.Len(feed.Entries, 1)
+		assertions.Equal(idPrefix+"post:1", feed.Entries[0].ID)
+	})
+
+	t.Run("Successful Not Modified", func(t *testing.T) {
+		recorder, _ := getFeed(handler, "/feeds/posts.atom", "")
+		etag := recorder.Header().Get("ETag")
+
+		recorder, _ = getFeed(handler, "/feeds/posts.atom", `"other", W/`+etag)
+		assertions.Equal(http.StatusNotModified, recorder.Code)
+		assertions.Empty(recorder.Body.String())
+
+		_, _, err := accessor.EditPost(ctx, first.ID, authorID, &model.PostEditInput{Text: &second.Title})
+		assertions.NoError(err)
+
+		recorder, feed := getFeed(handler, "/feeds/posts.atom", etag)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.True(feed.Entries[1].Updated.After(feed.Entries[1].Published))
+	})
+
+	t.Run("Successful Author Feed", func(t *testing.T) {
+		recorder, feed := getFeed(handler, "/feeds/authors/"+authorID.String()+"/posts.atom", "")
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Equal("Posts by Author", feed.Title)
+		assertions.Len(feed.Entries, 1)
+		assertions.Equal("First", feed.Entries[0].Title)
+	})
+
+	t.Run("Successful Comments Feed", func(t *testing.T) {
+		recorder, feed := getFeed(handler, "/feeds/posts/0/comments.atom", "")
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Equal("Comments on First", feed.Title)
+		assertions.Len(feed.Entries, 1)
+		assertions.Equal("Comment by Author", feed.Entries[0].Title)
+		assertions.Equal("<p>&lt;b&gt;Comment&lt;/b&gt;</p>", feed.Entries[0].Content.Body)
+	})
+
+	t.Run("Unsuccessful Comments Feed Draft", func(t *testing.T) {
+		recorder, _ := getFeed(handler, "/feeds/posts/2/comments.atom", "")
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+
+		recorder, _ = getFeed(handler, "/feeds/posts/post/comments.atom", "")
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("Unsuccessful Posts Feed Incorrect Limit", func(t *testing.T) {
+		recorder, _ := getFeed(handler, "/feeds/posts.atom?limit=21", "")
+		assertions.Equal(http.StatusBadRequest, recorder.Code)
+	})
+}
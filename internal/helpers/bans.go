@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+)
+
+const (
+	maxBanReasonLength = 500
+)
+
+// CheckBan validates the ban before it is stored.
+func CheckBan(newBan *model.BanInput) error {
+	if newBan.Reason != nil && utf8.RuneCountInString(*newBan.Reason) > maxBanReasonLength {
+		return errors.New("Length of the ban reason is too big (greater than " + strconv.Itoa(maxBanReasonLength) + ")")
+	}
+
+	if newBan.ExpiresAt != nil && !newBan.ExpiresAt.After(time.Now()) {
+		return errors.New("Ban expiry date must be in the future.")
+	}
+
+	return nil
+}
+
+// IsBanActive reports whether the ban is still in effect at the moment.
+func IsBanActive(ban *model.Ban, now time.Time) bool {
+	return ban.ExpiresAt == nil || ban.ExpiresAt.After(now)
+}
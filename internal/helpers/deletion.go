@@ -0,0 +1,16 @@
+package helpers
+
+import (
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+// NewCommentPlaceholder returns a copy of the deleted comment without its text and author.
+// Placeholders keep threads navigable when a deleted comment still has replies.
+func NewCommentPlaceholder(comment *model.Comment) *model.Comment {
+	placeholder := *comment
+	placeholder.AuthorID = uuid.Nil
+	placeholder.Text = ""
+
+	return &placeholder
+}
@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// CheckMaxReplyDepth validates the maximum reply depth of a post: 0 allows only root comments.
+func CheckMaxReplyDepth(maxReplyDepth *int32) error {
+	if maxReplyDepth != nil && *maxReplyDepth < 0 {
+		return errors.New("Maximum reply depth must not be negative.")
+	}
+
+	return nil
+}
+
+// GetPathCommentIDs returns IDs of the comments in the path "PostID.ParentID1.ParentID2...." from the root one.
+// Their number is the depth of a comment with the path, root comments have depth 0.
+func GetPathCommentIDs(path string) ([]int64, error) {
+	parts := strings.Split(path, ".")[1:]
+
+	commentIDs := make([]int64, len(parts))
+	for idx, part := range parts {
+		commentID, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, errors.New("Comment path " + path + " is not valid.")
+		}
+
+		commentIDs[idx] = commentID
+	}
+
+	return commentIDs, nil
+}
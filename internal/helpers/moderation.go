@@ -0,0 +1,35 @@
+package helpers
+
+import "github.com/C-4KE/simple-posts-service/graph/model"
+
+func GetModerationMode(commentsEnabled bool, moderationMode *model.ModerationMode) model.ModerationMode {
+	if moderationMode != nil {
+		return *moderationMode
+	}
+
+	if commentsEnabled {
+		return model.ModerationModeOpen
+	}
+
+	return model.ModerationModeClosed
+}
+
+func GetNewCommentStatus(moderationMode model.ModerationMode) model.CommentStatus {
+	if moderationMode == model.ModerationModePremoderated {
+		return model.CommentStatusPending
+	}
+
+	return model.CommentStatusApproved
+}
+
+func SwitchCommentsEnabled(moderationMode model.ModerationMode, newCommentsEnabled bool) model.ModerationMode {
+	if !newCommentsEnabled {
+		return model.ModerationModeClosed
+	}
+
+	if moderationMode == model.ModerationModeClosed {
+		return model.ModerationModeOpen
+	}
+
+	return moderationMode
+}
@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+// NewReplyNotification returns a notification for the author of the replied post or comment,
+// or nil when users reply to themselves.
+func NewReplyNotification(comment *model.Comment, recipientID uuid.UUID) *model.Notification {
+	if recipientID == comment.AuthorID {
+		return nil
+	}
+
+	return &model.Notification{
+		UserID:     recipientID,
+		Kind:       model.NotificationKindReply,
+		PostID:     comment.PostID,
+		CommentID:  &comment.ID,
+		ActorID:    comment.AuthorID,
+		CreateDate: time.Now(),
+	}
+}
+
+// NewMentionNotification returns a notification for the mentioned user, or nil when users mention themselves.
+// Mentions in the text of a post have no comment ID.
+func NewMentionNotification(postID int64, commentID *int64, actorID uuid.UUID, recipientID uuid.UUID) *model.Notification {
+	if recipientID == actorID {
+		return nil
+	}
+
+	return &model.Notification{
+		UserID:     recipientID,
+		Kind:       model.NotificationKindMention,
+		PostID:     postID,
+		CommentID:  commentID,
+		ActorID:    actorID,
+		CreateDate: time.Now(),
+	}
+}
@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+// GetPostPublication returns the status and the publication date of a post. A post with publishAt in the future
+// stays a draft until the scheduler publishes it, a post with publishAt in the past is published right away,
+// and a post without publishAt is either a draft or published now, depending on the requested status.
+func GetPostPublication(status *model.PostStatus, publishAt *time.Time, now time.Time) (model.PostStatus, *time.Time) {
+	if publishAt != nil {
+		if publishAt.After(now) {
+			return model.PostStatusDraft, publishAt
+		}
+
+		return model.PostStatusPublished, publishAt
+	}
+
+	if status != nil && *status == model.PostStatusDraft {
+		return model.PostStatusDraft, nil
+	}
+
+	return model.PostStatusPublished, &now
+}
+
+// IsPostVisible reports whether the viewer may see the post: drafts are visible only to their authors.
+func IsPostVisible(post *model.Post, viewerID uuid.UUID) bool {
+	return post.Status == model.PostStatusPublished || post.AuthorID == viewerID
+}
@@ -0,0 +1,17 @@
+package helpers
+
+import "github.com/C-4KE/simple-posts-service/graph/model"
+
+func GetReactionCounts(counts map[model.ReactionKind]int32) []*model.ReactionCount {
+	reactionCounts := make([]*model.ReactionCount, 0, len(counts))
+	for _, kind := range model.AllReactionKind {
+		if counts[kind] > 0 {
+			reactionCounts = append(reactionCounts, &model.ReactionCount{
+				Kind:  kind,
+				Count: counts[kind],
+			})
+		}
+	}
+
+	return reactionCounts
+}
@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/diff"
+)
+
+const (
+	maxPostTitleLength   = 200
+	maxPostTextLength    = 5000
+	maxCommentTextLength = 2000
+)
+
+// CheckPostChanges validates an edit of a post. The limits match the sizes of the columns in Postgres.
+func CheckPostChanges(changes *model.PostEditInput) error {
+	if changes.Title == nil && changes.Text == nil {
+		return errors.New("Changes of the post must contain a title or a text.")
+	}
+
+	if changes.Title != nil && utf8.RuneCountInString(*changes.Title) > maxPostTitleLength {
+		return errors.New("Length of the title is too big (greater than " + strconv.Itoa(maxPostTitleLength) + ")")
+	}
+
+	if changes.Text != nil && utf8.RuneCountInString(*changes.Text) > maxPostTextLength {
+		return errors.New("Length of the text is too big (greater than " + strconv.Itoa(maxPostTextLength) + ")")
+	}
+
+	return nil
+}
+
+// CheckCommentText validates the new text of a comment. The limit matches the size of the column in Postgres.
+func CheckCommentText(text string) error {
+	if utf8.RuneCountInString(text) > maxCommentTextLength {
+		return errors.New("Length of the text in the comment is too big (greater than " + strconv.Itoa(maxCommentTextLength) + ")")
+	}
+
+	return nil
+}
+
+// NewRevisionDiff compares two revisions of the same post or comment. Revisions of comments have no title.
+func NewRevisionDiff(from *model.Revision, to *model.Revision) *model.RevisionDiff {
+	var fromTitle, toTitle string
+	if from.Title != nil {
+		fromTitle = *from.Title
+	}
+	if to.Title != nil {
+		toTitle = *to.Title
+	}
+
+	return &model.RevisionDiff{
+		From:  from.Number,
+		To:    to.Number,
+		Title: newDiffLines(diff.Lines(fromTitle, toTitle)),
+		Text:  newDiffLines(diff.Lines(from.Text, to.Text)),
+	}
+}
+
+func newDiffLines(lines []diff.Line) []*model.DiffLine {
+	diffLines := make([]*model.DiffLine, len(lines))
+	for idx, line := range lines {
+		diffLines[idx] = &model.DiffLine{
+			Operation: model.DiffOperation(line.Operation),
+			Text:      line.Text,
+		}
+	}
+
+	return diffLines
+}
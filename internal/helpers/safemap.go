@@ -34,6 +34,13 @@ func (safeMap *SafeMap[keyType, valueType]) Set(key keyType, value valueType) {
 	safeMap.data[key] = value
 }
 
+func (safeMap *SafeMap[keyType, valueType]) Delete(key keyType) {
+	defer safeMap.mutex.Unlock()
+	safeMap.mutex.Lock()
+
+	delete(safeMap.data, key)
+}
+
 func (safeMap *SafeMap[keyType, valueType]) GetKeys() []keyType {
 	defer safeMap.mutex.RUnlock()
 	safeMap.mutex.RLock()
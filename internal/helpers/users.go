@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+)
+
+const (
+	maxDisplayNameLength = 100
+	maxAvatarURLLength   = 2000
+)
+
+func CheckDisplayName(displayName string) error {
+	if strings.TrimSpace(displayName) == "" {
+		return errors.New("Display name must not be empty.")
+	}
+
+	if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+		return errors.New("Length of the display name is too big (greater than " + strconv.Itoa(maxDisplayNameLength) + ")")
+	}
+
+	return nil
+}
+
+func CheckAvatarURL(avatarURL *string) error {
+	if avatarURL == nil {
+		return nil
+	}
+
+	if len(*avatarURL) > maxAvatarURLLength {
+		return errors.New("Length of the avatar URL is too big (greater than " + strconv.Itoa(maxAvatarURLLength) + ")")
+	}
+
+	parsedURL, err := url.Parse(*avatarURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errors.New("Avatar URL " + *avatarURL + " must be an absolute http or https URL.")
+	}
+
+	return nil
+}
+
+// CheckUsername accepts only names that can be mentioned as a whole: letters, digits and "_".
+func CheckUsername(username *string) error {
+	if username == nil {
+		return nil
+	}
+
+	if !textrefs.IsName(*username) {
+		return errors.New("Username " + *username + " must consist of letters, digits and \"_\" and be no longer than " + strconv.Itoa(textrefs.MaxMentionLength) + " characters.")
+	}
+
+	return nil
+}
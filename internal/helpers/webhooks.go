@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
+)
+
+const (
+	maxWebhookURLLength    = 2000
+	minWebhookSecretLength = 16
+	maxWebhookSecretLength = 200
+)
+
+// CheckWebhookSubscription validates the subscription before it is stored.
+func CheckWebhookSubscription(newSubscription *model.WebhookSubscriptionInput) error {
+	if len(newSubscription.URL) > maxWebhookURLLength {
+		return errors.New("Length of the webhook URL is too big (greater than " + strconv.Itoa(maxWebhookURLLength) + ")")
+	}
+
+	parsedURL, err := url.Parse(newSubscription.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return errors.New("Webhook URL " + newSubscription.URL + " must be an absolute http or https URL.")
+	}
+
+	// Host names are checked again by the client after they are resolved.
+	hostname := strings.ToLower(parsedURL.Hostname())
+	if addr, err := netip.ParseAddr(hostname); err == nil {
+		if err = webhooks.CheckAddr(addr); err != nil {
+			return err
+		}
+	} else if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return errors.New("Webhook URL " + newSubscription.URL + " must not point to the local host.")
+	}
+
+	if len(newSubscription.EventKinds) == 0 {
+		return errors.New("Webhook subscription must contain at least one event kind.")
+	}
+
+	secretLength := utf8.RuneCountInString(newSubscription.Secret)
+	if secretLength < minWebhookSecretLength || secretLength > maxWebhookSecretLength {
+		return errors.New("Length of the webhook secret must be from " + strconv.Itoa(minWebhookSecretLength) + " to " + strconv.Itoa(maxWebhookSecretLength) + ".")
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package loader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc loads values for all the keys collected during one batch.
+// Keys missing from the returned map resolve to the zero value.
+type BatchFunc[keyType comparable, valueType any] func(ctx context.Context, keys []keyType) (map[keyType]valueType, error)
+
+// Loader collects keys requested within a short time window and loads them with a single BatchFunc call.
+// Loaded values are cached for the lifetime of the loader, so a loader should be created per request.
+type Loader[keyType comparable, valueType any] struct {
+	fetch BatchFunc[keyType, valueType]
+	wait  time.Duration
+	mutex *sync.Mutex
+	cache map[keyType]*result[valueType]
+	batch []keyType
+}
+
+type result[valueType any] struct {
+	value valueType
+	err   error
+	done  chan struct{}
+}
+
+func NewLoader[keyType comparable, valueType any](fetch BatchFunc[keyType, valueType], wait time.Duration) *Loader[keyType, valueType] {
+	return &Loader[keyType, valueType]{
+		fetch: fetch,
+		wait:  wait,
+		mutex: &sync.Mutex{},
+		cache: make(map[keyType]*result[valueType]),
+	}
+}
+
+func (loader *Loader[keyType, valueType]) Load(ctx context.Context, key keyType) (valueType, error) {
+	loader.mutex.Lock()
+	loaded, ok := loader.cache[key]
+	if !ok {
+		loaded = &result[valueType]{
+			done: make(chan struct{}),
+		}
+		loader.cache[key] = loaded
+		loader.batch = append(loader.batch, key)
+
+		if len(loader.batch) == 1 {
+			go loader.dispatch(context.WithoutCancel(ctx))
+		}
+	}
+	loader.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		var empty valueType
+		return empty, ctx.Err()
+
+	case <-loaded.done:
+		return loaded.value, loaded.err
+	}
+}
+
+func (loader *Loader[keyType, valueType]) dispatch(ctx context.Context) {
+	time.Sleep(loader.wait)
+
+	loader.mutex.Lock()
+	keys := loader.batch
+	loader.batch = nil
+
+	results := make([]*result[valueType], len(keys))
+	for idx, key := range keys {
+		results[idx] = loader.cache[key]
+	}
+	loader.mutex.Unlock()
+
+	values, err := loader.fetch(ctx, keys)
+
+	for idx, key := range keys {
+		results[idx].value = values[key]
+		results[idx].err = err
+		close(results[idx].done)
+	}
+}
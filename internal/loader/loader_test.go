@@ -0,0 +1,70 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("Successful Load Batched Keys", func(t *testing.T) {
+		batches := make([][]int64, 0)
+		batchesMutex := &sync.Mutex{}
+
+		testLoader := NewLoader(func(ctx context.Context, keys []int64) (map[int64]int64, error) {
+			batchesMutex.Lock()
+			batches = append(batches, keys)
+			batchesMutex.Unlock()
+
+			values := make(map[int64]int64, len(keys))
+			for _, key := range keys {
+				values[key] = key * 10
+			}
+			return values, nil
+		}, 5*time.Millisecond)
+
+		waitGroup := &sync.WaitGroup{}
+		for key := int64(0); key < 5; key++ {
+			waitGroup.Go(func() {
+				value, err := testLoader.Load(ctx, key)
+				assertions.Nil(err)
+				assertions.Equal(key*10, value)
+			})
+		}
+		waitGroup.Wait()
+
+		assertions.Len(batches, 1)
+		assertions.ElementsMatch([]int64{0, 1, 2, 3, 4}, batches[0])
+	})
+
+	t.Run("Successful Load Cached Key", func(t *testing.T) {
+		calls := 0
+		testLoader := NewLoader(func(ctx context.Context, keys []int64) (map[int64]int64, error) {
+			calls++
+			return map[int64]int64{1: 1}, nil
+		}, time.Millisecond)
+
+		_, err := testLoader.Load(ctx, 1)
+		assertions.Nil(err)
+		value, err := testLoader.Load(ctx, 1)
+		assertions.Nil(err)
+		assertions.Equal(int64(1), value)
+		assertions.Equal(1, calls)
+	})
+
+	t.Run("Unsuccessful Load Batch Error", func(t *testing.T) {
+		testLoader := NewLoader(func(ctx context.Context, keys []int64) (map[int64]int64, error) {
+			return nil, errors.New("Test")
+		}, time.Millisecond)
+
+		_, err := testLoader.Load(ctx, 1)
+		assertions.NotNil(err)
+	})
+}
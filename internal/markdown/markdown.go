@@ -0,0 +1,271 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// render converts the restricted Markdown subset to HTML. Every character of the source is either escaped
+// or replaced with one of the known tags, so the output never contains markup written by the user.
+//
+// Supported blocks: paragraphs (a single line break becomes <br>), fenced code blocks, "- " and "1. " lists
+// and "> " quotes. Supported inline elements: `code`, **strong**, *emphasis* (_emphasis_), [links](url)
+// and ![images](url). Links are only http, https and mailto; images are only https from allowed hosts,
+// other images are rendered as links.
+func render(text string, imageHosts []string) string {
+	renderer := &blockRenderer{imageHosts: imageHosts}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for idx := 0; idx < len(lines); idx++ {
+		line := lines[idx]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			renderer.closeBlock()
+			idx = renderer.writeCodeBlock(lines, idx+1)
+		case trimmed == "":
+			renderer.closeBlock()
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			renderer.writeListItem("ul", trimmed[2:])
+		case isOrderedItem(trimmed):
+			renderer.writeListItem("ol", trimmed[strings.Index(trimmed, ".")+2:])
+		case strings.HasPrefix(trimmed, ">"):
+			renderer.writeLine("blockquote", strings.TrimSpace(trimmed[1:]))
+		default:
+			renderer.writeLine("p", trimmed)
+		}
+	}
+
+	renderer.closeBlock()
+	return renderer.output.String()
+}
+
+type blockRenderer struct {
+	output     strings.Builder
+	openBlock  string
+	imageHosts []string
+}
+
+func (renderer *blockRenderer) closeBlock() {
+	switch renderer.openBlock {
+	case "":
+		return
+	case "blockquote":
+		renderer.output.WriteString("</p></blockquote>")
+	default:
+		renderer.output.WriteString("</" + renderer.openBlock + ">")
+	}
+
+	renderer.openBlock = ""
+}
+
+// writeLine appends the line to the open paragraph or quote, or opens a new one.
+func (renderer *blockRenderer) writeLine(block string, line string) {
+	if renderer.openBlock == block {
+		renderer.output.WriteString("<br>")
+	} else {
+		renderer.closeBlock()
+		renderer.openBlock = block
+		if block == "blockquote" {
+			renderer.output.WriteString("<blockquote><p>")
+		} else {
+			renderer.output.WriteString("<" + block + ">")
+		}
+	}
+
+	renderer.output.WriteString(renderer.renderInline(line, true))
+}
+
+func (renderer *blockRenderer) writeListItem(list string, item string) {
+	if renderer.openBlock != list {
+		renderer.closeBlock()
+		renderer.openBlock = list
+		renderer.output.WriteString("<" + list + ">")
+	}
+
+	renderer.output.WriteString("<li>" + renderer.renderInline(strings.TrimSpace(item), true) + "</li>")
+}
+
+// writeCodeBlock writes lines up to the closing fence and returns the index of the fence line.
+// An unclosed block lasts until the end of the text.
+func (renderer *blockRenderer) writeCodeBlock(lines []string, start int) int {
+	end := start
+	for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), "```") {
+		end++
+	}
+
+	renderer.output.WriteString("<pre><code>")
+	renderer.output.WriteString(html.EscapeString(strings.Join(lines[start:end], "\n")))
+	renderer.output.WriteString("</code></pre>")
+
+	return end
+}
+
+func (renderer *blockRenderer) renderInline(text string, allowLinks bool) string {
+	var output strings.Builder
+
+	for idx := 0; idx < len(text); {
+		char, size := utf8.DecodeRuneInString(text[idx:])
+		rest := text[idx:]
+
+		switch {
+		case char == '\\' && idx+1 < len(text) && isASCIIPunct(text[idx+1]):
+			output.WriteString(html.EscapeString(text[idx+1 : idx+2]))
+			idx += 2
+			continue
+
+		case char == '`':
+			if end := strings.Index(rest[1:], "`"); end >= 0 {
+				output.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				idx += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				output.WriteString("<strong>" + renderer.renderInline(rest[2:2+end], allowLinks) + "</strong>")
+				idx += end + 4
+				continue
+			}
+
+		case char == '*' || (char == '_' && isWordBoundary(text, idx-1)):
+			if end := findEmphasisEnd(rest, byte(char)); end > 0 {
+				output.WriteString("<em>" + renderer.renderInline(rest[1:end], allowLinks) + "</em>")
+				idx += end + 1
+				continue
+			}
+
+		case allowLinks && (char == '[' || strings.HasPrefix(rest, "![")):
+			if rendered, consumed, ok := renderer.renderLink(rest); ok {
+				output.WriteString(rendered)
+				idx += consumed
+				continue
+			}
+		}
+
+		output.WriteString(html.EscapeString(text[idx : idx+size]))
+		idx += size
+	}
+
+	return output.String()
+}
+
+// renderLink renders "[text](url)" or "![alt](url)" at the start of text and returns the number of consumed bytes.
+func (renderer *blockRenderer) renderLink(text string) (string, int, bool) {
+	isImage := strings.HasPrefix(text, "!")
+	start := 1
+	if isImage {
+		start = 2
+	}
+
+	labelEnd := strings.Index(text[start:], "](")
+	if labelEnd < 0 {
+		return "", 0, false
+	}
+	labelEnd += start
+
+	urlEnd := strings.Index(text[labelEnd+2:], ")")
+	if urlEnd < 0 {
+		return "", 0, false
+	}
+	urlEnd += labelEnd + 2
+
+	label := text[start:labelEnd]
+	target, ok := parseURL(text[labelEnd+2 : urlEnd])
+	if !ok {
+		return "", 0, false
+	}
+
+	href := html.EscapeString(target.String())
+	if isImage && target.Scheme == "https" && slices.Contains(renderer.imageHosts, strings.ToLower(target.Hostname())) {
+		return `<img src="` + href + `" alt="` + html.EscapeString(label) + `">`, urlEnd + 1, true
+	}
+
+	return `<a href="` + href + `" rel="nofollow">` + renderer.renderInline(label, false) + `</a>`, urlEnd + 1, true
+}
+
+func parseURL(rawURL string) (*url.URL, bool) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" || strings.ContainsAny(rawURL, " \t<>\"'") {
+		return nil, false
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, false
+	}
+
+	switch parsedURL.Scheme {
+	case "http", "https":
+		return parsedURL, parsedURL.Host != ""
+	case "mailto":
+		return parsedURL, parsedURL.Opaque != ""
+	default:
+		return nil, false
+	}
+}
+
+// findEmphasisEnd returns the index of the closing delimiter. The emphasized text must not start or end with a space,
+// and "_" must close at a word boundary, so snake_case words stay as they are.
+func findEmphasisEnd(text string, delimiter byte) int {
+	if len(text) < 3 || text[1] == ' ' {
+		return -1
+	}
+
+	for idx := 2; idx < len(text); idx++ {
+		if text[idx] != delimiter || text[idx-1] == ' ' {
+			continue
+		}
+
+		if delimiter == '_' && !isWordBoundary(text, idx+1) {
+			continue
+		}
+
+		if delimiter == '*' && idx+1 < len(text) && text[idx+1] == '*' {
+			idx++
+			continue
+		}
+
+		return idx
+	}
+
+	return -1
+}
+
+// isWordBoundary reports whether the character at idx is missing or is not a letter or a digit.
+func isWordBoundary(text string, idx int) bool {
+	if idx < 0 || idx >= len(text) {
+		return true
+	}
+
+	char, _ := utf8.DecodeRuneInString(text[idx:])
+	if char == utf8.RuneError && idx > 0 {
+		char, _ = utf8.DecodeLastRuneInString(text[:idx+1])
+	}
+
+	return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+}
+
+func isOrderedItem(line string) bool {
+	dot := strings.Index(line, ". ")
+	if dot <= 0 || dot > 9 {
+		return false
+	}
+
+	for _, char := range line[:dot] {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isASCIIPunct(char byte) bool {
+	return char < utf8.RuneSelf && unicode.IsPunct(rune(char)) || strings.IndexByte("`*_[]()!#>+-.\\", char) >= 0
+}
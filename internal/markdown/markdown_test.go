@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	assertions := assert.New(t)
+	imageHosts := []string{"images.example.com"}
+
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"Plain text", "Hello, world", "<p>Hello, world</p>"},
+		{"Paragraphs and line breaks", "first\nsecond\n\nthird", "<p>first<br>second</p><p>third</p>"},
+		{"Inline elements", "**bold** and *em* and _em_ and `a < b`", "<p><strong>bold</strong> and <em>em</em> and <em>em</em> and <code>a &lt; b</code></p>"},
+		{"Snake case is not emphasis", "snake_case_name", "<p>snake_case_name</p>"},
+		{"Escaped delimiter", `\*not em\*`, "<p>*not em*</p>"},
+		{"Unicode emphasis", "*привет* мир", "<p><em>привет</em> мир</p>"},
+		{"Link gets nofollow", "[site](https://example.com/a?b=1&c=2)", `<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow">site</a></p>`},
+		{"Mailto link", "[mail](mailto:user@example.com)", `<p><a href="mailto:user@example.com" rel="nofollow">mail</a></p>`},
+		{"Javascript link is text", "[x](javascript:alert(1))", "<p>[x](javascript:alert(1))</p>"},
+		{"Relative link is text", "[x](/admin)", "<p>[x](/admin)</p>"},
+		{"Allowed image", "![cat](https://images.example.com/cat.png)", `<p><img src="https://images.example.com/cat.png" alt="cat"></p>`},
+		{"Image from other host is a link", "![cat](https://evil.example.com/cat.png)", `<p><a href="https://evil.example.com/cat.png" rel="nofollow">cat</a></p>`},
+		{"Image over http is a link", "![cat](http://images.example.com/cat.png)", `<p><a href="http://images.example.com/cat.png" rel="nofollow">cat</a></p>`},
+		{"Script is escaped", "<script>alert(1)</script>", "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>"},
+		{"Attribute injection is escaped", `[x](https://example.com/"onmouseover="alert(1))`, `<p>[x](https://example.com/&#34;onmouseover=&#34;alert(1))</p>`},
+		{"Raw html in link text is escaped", "[<b>x</b>](https://example.com)", `<p><a href="https://example.com" rel="nofollow">&lt;b&gt;x&lt;/b&gt;</a></p>`},
+		{"Unordered list", "- one\n- **two**", "<ul><li>one</li><li><strong>two</strong></li></ul>"},
+		{"Ordered list", "1. one\n2. two", "<ol><li>one</li><li>two</li></ol>"},
+		{"Quote", "> quoted\n> text\n\nafter", "<blockquote><p>quoted<br>text</p></blockquote><p>after</p>"},
+		{"Code block", "```\n<b>*x*</b>\n```\ntext", "<pre><code>&lt;b&gt;*x*&lt;/b&gt;</code></pre><p>text</p>"},
+		{"Unclosed code block", "```\ncode", "<pre><code>code</code></pre>"},
+		{"Unclosed delimiters", "**bold and `code", "<p>**bold and `code</p>"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Successful Render "+testCase.name, func(t *testing.T) {
+			assertions.Equal(testCase.expected, render(testCase.text, imageHosts))
+		})
+	}
+}
+
+func TestRenderer(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Render HTML cached", func(t *testing.T) {
+		renderer, err := NewRenderer([]string{" Images.Example.com "}, 10)
+		assertions.Nil(err)
+
+		text := "![cat](https://images.example.com/cat.png)"
+		expected := `<p><img src="https://images.example.com/cat.png" alt="cat"></p>`
+		assertions.Equal(expected, renderer.RenderHTML(text))
+		assertions.Equal(1, renderer.cache.Len())
+
+		assertions.Equal(expected, renderer.RenderHTML(text))
+		assertions.Equal(1, renderer.cache.Len())
+
+		assertions.Equal("<p>edited</p>", renderer.RenderHTML("edited"))
+		assertions.Equal(2, renderer.cache.Len())
+	})
+
+	t.Run("Unsuccessful NewRenderer", func(t *testing.T) {
+		renderer, err := NewRenderer(nil, 0)
+		assertions.NotNil(err)
+		assertions.Nil(renderer)
+	})
+}
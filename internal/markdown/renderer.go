@@ -0,0 +1,46 @@
+package markdown
+
+import (
+	"crypto/sha256"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// Renderer renders text to sanitized HTML and caches the result by the hash of the text,
+// so every revision of a post or comment is rendered once while it stays in the cache.
+type Renderer struct {
+	imageHosts []string
+	cache      *lru.Cache[[sha256.Size]byte, string]
+}
+
+func NewRenderer(imageHosts []string, cacheSize int) (*Renderer, error) {
+	cache, err := lru.New[[sha256.Size]byte, string](cacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	normalizedHosts := make([]string, 0, len(imageHosts))
+	for _, host := range imageHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			normalizedHosts = append(normalizedHosts, host)
+		}
+	}
+
+	return &Renderer{
+		imageHosts: normalizedHosts,
+		cache:      cache,
+	}, nil
+}
+
+func (renderer *Renderer) RenderHTML(text string) string {
+	key := sha256.Sum256([]byte(text))
+	if rendered, ok := renderer.cache.Get(key); ok {
+		return rendered
+	}
+
+	rendered := render(text, renderer.imageHosts)
+	renderer.cache.Add(key, rendered)
+
+	return rendered
+}
@@ -0,0 +1,329 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/archive"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// batchSize is the number of posts copied, with all their comments, by one import into the target storage.
+const batchSize = 100
+
+// Progress counts copied records and records skipped because a previous run already copied them.
+type Progress struct {
+	Posts           int64
+	Comments        int64
+	SkippedPosts    int64
+	SkippedComments int64
+}
+
+// Summary describes the records of one storage. The checksum does not depend on the order of the records.
+type Summary struct {
+	Posts    int64
+	Comments int64
+	Checksum string
+}
+
+// Report compares the records of the source storage with their copies in the target storage.
+type Report struct {
+	Source Summary
+	Target Summary
+}
+
+func (report *Report) Matches() bool {
+	return report.Source == report.Target
+}
+
+// Migrator copies posts and comments between storages. Copies are remembered in the target under the source name,
+// so a failed migration can be run again and continues with the records that were not copied.
+type Migrator struct {
+	from       storage.Accessor
+	to         storage.Accessor
+	source     string
+	onProgress func(Progress)
+}
+
+func NewMigrator(from storage.Accessor, to storage.Accessor, source string, onProgress func(Progress)) *Migrator {
+	return &Migrator{
+		from:       from,
+		to:         to,
+		source:     source,
+		onProgress: onProgress,
+	}
+}
+
+// CheckSource fails when the source keeps records other than posts and comments, which the migration does not copy,
+// and lists them in the error.
+func (migrator *Migrator) CheckSource(ctx context.Context) error {
+	records, err := migrator.from.CountOtherRecords(ctx)
+	if err != nil {
+		return err
+	}
+
+	if records.IsEmpty() {
+		return nil
+	}
+
+	counts := []struct {
+		name  string
+		count int64
+	}{
+		{"users", records.Users},
+		{"edits", records.Revisions},
+		{"reactions", records.Reactions},
+		{"bans", records.Bans},
+		{"notifications", records.Notifications},
+		{"webhook subscriptions", records.WebhookSubscriptions},
+	}
+
+	described := make([]string, 0, len(counts))
+	for _, count := range counts {
+		if count.count > 0 {
+			described = append(described, strconv.FormatInt(count.count, 10)+" "+count.name)
+		}
+	}
+
+	return errors.New("Only posts and comments are migrated, the source also keeps " + strings.Join(described, ", ") + ".")
+}
+
+// Copy copies all posts and comments, including drafts and deleted records, reporting progress after every batch.
+func (migrator *Migrator) Copy(ctx context.Context) (Progress, error) {
+	var progress Progress
+	posts := make([]*storage.ExportPost, 0, batchSize)
+	comments := make([]*storage.TreeComment, 0)
+
+	flush := func() error {
+		if len(posts) == 0 {
+			return nil
+		}
+
+		copiedPosts, err := migrator.to.ImportPosts(ctx, migrator.source, posts)
+		if err != nil {
+			return err
+		}
+
+		copiedComments, err := migrator.to.ImportComments(ctx, migrator.source, comments)
+		if err != nil {
+			return err
+		}
+
+		progress.Posts += copiedPosts
+		progress.SkippedPosts += int64(len(posts)) - copiedPosts
+		progress.Comments += copiedComments
+		progress.SkippedComments += int64(len(comments)) - copiedComments
+		posts, comments = posts[:0], comments[:0]
+
+		if migrator.onProgress != nil {
+			migrator.onProgress(progress)
+		}
+		return nil
+	}
+
+	for post, err := range migrator.from.IteratePosts(ctx, nil) {
+		if err != nil {
+			return progress, err
+		}
+
+		posts = append(posts, post)
+		for comment, err := range migrator.from.IteratePostComments(ctx, post.Post.ID) {
+			if err != nil {
+				return progress, err
+			}
+
+			comments = append(comments, comment)
+		}
+
+		if len(posts) == batchSize {
+			if err = flush(); err != nil {
+				return progress, err
+			}
+		}
+	}
+
+	return progress, flush()
+}
+
+// Verify counts the records of the source storage and their copies in the target storage and computes checksums
+// of both. Records of the source are checksummed with the IDs of their copies, so matching checksums mean
+// that the copies have the same content and the same comment trees.
+func (migrator *Migrator) Verify(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	sourceChecksum := newChecksum()
+	copiedPostIDs := make(map[int64]struct{})
+
+	for post, err := range migrator.from.IteratePosts(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+
+		postIDs, err := migrator.to.GetImportedIDs(ctx, migrator.source, storage.ImportKindPost, []int64{post.Post.ID})
+		if err != nil {
+			return nil, err
+		}
+
+		comments := make([]*storage.TreeComment, 0)
+		for comment, err := range migrator.from.IteratePostComments(ctx, post.Post.ID) {
+			if err != nil {
+				return nil, err
+			}
+
+			comments = append(comments, comment)
+		}
+
+		commentIDs, err := migrator.to.GetImportedIDs(ctx, migrator.source, storage.ImportKindComment, getCommentIDs(comments))
+		if err != nil {
+			return nil, err
+		}
+
+		postID := mapID(postIDs, post.Post.ID)
+		copiedPostIDs[postID] = struct{}{}
+		if err = sourceChecksum.addPost(post, postID); err != nil {
+			return nil, err
+		}
+		report.Source.Posts++
+
+		for _, comment := range comments {
+			if err = sourceChecksum.addComment(comment, postID, commentIDs); err != nil {
+				return nil, err
+			}
+			report.Source.Comments++
+		}
+	}
+
+	targetChecksum := newChecksum()
+	for post, err := range migrator.to.IteratePosts(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+
+		// The target may keep posts that were not copied from the source.
+		if _, ok := copiedPostIDs[post.Post.ID]; !ok {
+			continue
+		}
+
+		if err = targetChecksum.addPost(post, post.Post.ID); err != nil {
+			return nil, err
+		}
+		report.Target.Posts++
+
+		for comment, err := range migrator.to.IteratePostComments(ctx, post.Post.ID) {
+			if err != nil {
+				return nil, err
+			}
+
+			if err = targetChecksum.addComment(comment, post.Post.ID, nil); err != nil {
+				return nil, err
+			}
+			report.Target.Comments++
+		}
+	}
+
+	report.Source.Checksum = sourceChecksum.String()
+	report.Target.Checksum = targetChecksum.String()
+	return report, nil
+}
+
+// checksum combines hashes of records with XOR, so it does not depend on the order of the records.
+type checksum [sha256.Size]byte
+
+func newChecksum() *checksum {
+	return &checksum{}
+}
+
+func (sum *checksum) add(record *archive.Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(data)
+	for i := range sum {
+		sum[i] ^= hash[i]
+	}
+	return nil
+}
+
+// addPost adds the post as if it had the ID.
+func (sum *checksum) addPost(post *storage.ExportPost, postID int64) error {
+	record := archive.NewPostRecord(post)
+	record.Post.ID = postID
+	record.Post.CreateDate = normalizeTime(record.Post.CreateDate)
+	record.Post.PublishAt = normalizeTimePointer(record.Post.PublishAt)
+	record.Post.DeletedAt = normalizeTimePointer(record.Post.DeletedAt)
+	return sum.add(record)
+}
+
+// addComment adds the comment of the post with the ID. IDs of the comment, of its parent and of the comments
+// in its path are replaced by the commentIDs when they are set.
+func (sum *checksum) addComment(comment *storage.TreeComment, postID int64, commentIDs map[int64]int64) error {
+	record := archive.NewCommentRecord(comment)
+	record.Comment.PostID = postID
+	record.Comment.CreateDate = normalizeTime(record.Comment.CreateDate)
+	record.Comment.DeletedAt = normalizeTimePointer(record.Comment.DeletedAt)
+	record.Comment.PinnedAt = normalizeTimePointer(record.Comment.PinnedAt)
+
+	if commentIDs != nil {
+		record.Comment.ID = mapID(commentIDs, record.Comment.ID)
+		if record.Comment.ParentID != nil {
+			parentID := mapID(commentIDs, *record.Comment.ParentID)
+			record.Comment.ParentID = &parentID
+		}
+
+		path := strings.Split(record.Comment.Path, ".")
+		path[0] = strconv.FormatInt(postID, 10)
+		for i := 1; i < len(path); i++ {
+			ancestorID, err := strconv.ParseInt(path[i], 10, 64)
+			if err != nil {
+				return err
+			}
+			path[i] = strconv.FormatInt(mapID(commentIDs, ancestorID), 10)
+		}
+		record.Comment.Path = strings.Join(path, ".")
+	}
+
+	return sum.add(record)
+}
+
+func (sum *checksum) String() string {
+	return hex.EncodeToString(sum[:])
+}
+
+// mapID returns the ID of the copy, or -1 when the record was not copied.
+func mapID(ids map[int64]int64, id int64) int64 {
+	if mappedID, ok := ids[id]; ok {
+		return mappedID
+	}
+
+	return -1
+}
+
+// normalizeTime drops the precision Postgres does not keep and the time zone.
+func normalizeTime(value time.Time) time.Time {
+	return value.UTC().Truncate(time.Microsecond)
+}
+
+func normalizeTimePointer(value *time.Time) *time.Time {
+	if value == nil {
+		return nil
+	}
+
+	normalized := normalizeTime(*value)
+	return &normalized
+}
+
+func getCommentIDs(comments []*storage.TreeComment) []int64 {
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.Comment.ID)
+	}
+
+	return commentIDs
+}
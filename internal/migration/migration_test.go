@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrator(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+
+	from := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	for range batchSize + 1 {
+		_, err := from.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+		assertions.NoError(err)
+	}
+	root, err := from.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: batchSize, Text: "Root"})
+	assertions.NoError(err)
+	_, err = from.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: batchSize, ParentID: &root.ID, Text: "Reply"})
+	assertions.NoError(err)
+
+	to := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	_, err = to.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Existing", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+
+	t.Run("Successful Resumed Copy", func(t *testing.T) {
+		firstPost, err := from.GetPost(ctx, 0, authorID)
+		assertions.NoError(err)
+		_, err = to.ImportPosts(ctx, "test", []*storage.ExportPost{{Post: firstPost}})
+		assertions.NoError(err)
+
+		reports := make([]Progress, 0)
+		migrator := NewMigrator(from, to, "test", func(progress Progress) {
+			reports = append(reports, progress)
+		})
+
+		progress, err := migrator.Copy(ctx)
+		assertions.NoError(err)
+		assertions.Equal(Progress{Posts: batchSize, Comments: 2, SkippedPosts: 1}, progress)
+		assertions.Len(reports, 2)
+	})
+
+	t.Run("Successful Verify", func(t *testing.T) {
+		report, err := NewMigrator(from, to, "test", nil).Verify(ctx)
+		assertions.NoError(err)
+		assertions.True(report.Matches())
+		assertions.Equal(int64(batchSize+1), report.Target.Posts)
+		assertions.Equal(int64(2), report.Target.Comments)
+	})
+
+	t.Run("Unsuccessful Verify Changed Copy", func(t *testing.T) {
+		_, err := to.UpdateCommentsEnabled(ctx, 1, authorID, false, false)
+		assertions.NoError(err)
+
+		report, err := NewMigrator(from, to, "test", nil).Verify(ctx)
+		assertions.NoError(err)
+		assertions.False(report.Matches())
+		assertions.Equal(report.Source.Posts, report.Target.Posts)
+	})
+	t.Run("Successful Check Source", func(t *testing.T) {
+		assertions.NoError(NewMigrator(from, to, "test", nil).CheckSource(ctx))
+	})
+
+	t.Run("Unsuccessful Check Source with other records", func(t *testing.T) {
+		text := "Edited"
+		_, _, err := to.EditPost(ctx, 0, authorID, &model.PostEditInput{Text: &text})
+		assertions.NoError(err)
+		_, err = to.AddUser(ctx, &model.UserInput{ID: &authorID, DisplayName: "Author"})
+		assertions.NoError(err)
+
+		err = NewMigrator(to, from, "test", nil).CheckSource(ctx)
+		assertions.EqualError(err, "Only posts and comments are migrated, the source also keeps 1 users, 1 edits.")
+	})
+}
@@ -0,0 +1,65 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+const subscriptionBuffer = 16
+
+// Broker delivers new notifications to subscribers of their recipients within one process.
+type Broker struct {
+	mutex       *sync.RWMutex
+	subscribers map[uuid.UUID]map[chan *model.Notification]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{
+		mutex:       &sync.RWMutex{},
+		subscribers: make(map[uuid.UUID]map[chan *model.Notification]struct{}),
+	}
+}
+
+// Subscribe returns a channel of notifications for the user. The channel is closed when ctx is done.
+func (broker *Broker) Subscribe(ctx context.Context, userID uuid.UUID) <-chan *model.Notification {
+	notifications := make(chan *model.Notification, subscriptionBuffer)
+
+	broker.mutex.Lock()
+	if _, ok := broker.subscribers[userID]; !ok {
+		broker.subscribers[userID] = make(map[chan *model.Notification]struct{})
+	}
+	broker.subscribers[userID][notifications] = struct{}{}
+	broker.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		broker.mutex.Lock()
+		delete(broker.subscribers[userID], notifications)
+		if len(broker.subscribers[userID]) == 0 {
+			delete(broker.subscribers, userID)
+		}
+		close(notifications)
+		broker.mutex.Unlock()
+	}()
+
+	return notifications
+}
+
+// Publish never blocks: a subscriber that does not keep up loses the notification, which stays in storage.
+func (broker *Broker) Publish(notification *model.Notification) {
+	defer broker.mutex.RUnlock()
+	broker.mutex.RLock()
+
+	for subscriber := range broker.subscribers[notification.UserID] {
+		select {
+		case subscriber <- notification:
+		default:
+			log.Printf("Notification %d was dropped for a slow subscriber of user %s", notification.ID, notification.UserID)
+		}
+	}
+}
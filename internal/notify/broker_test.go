@@ -0,0 +1,55 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+
+	t.Run("Successful Publish To Subscriber", func(t *testing.T) {
+		broker := NewBroker()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		notifications := broker.Subscribe(ctx, userID)
+		broker.Publish(&model.Notification{ID: 1, UserID: userID})
+		broker.Publish(&model.Notification{ID: 2, UserID: uuid.New()})
+
+		notification := <-notifications
+		assertions.Equal(int64(1), notification.ID)
+		assertions.Empty(notifications)
+	})
+
+	t.Run("Successful Close On Cancel", func(t *testing.T) {
+		broker := NewBroker()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		notifications := broker.Subscribe(ctx, userID)
+		cancel()
+
+		_, ok := <-notifications
+		assertions.False(ok)
+
+		broker.Publish(&model.Notification{ID: 1, UserID: userID})
+	})
+
+	t.Run("Successful Publish Without Blocking", func(t *testing.T) {
+		broker := NewBroker()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		notifications := broker.Subscribe(ctx, userID)
+		for idx := range subscriptionBuffer + 1 {
+			broker.Publish(&model.Notification{ID: int64(idx), UserID: userID})
+		}
+
+		assertions.Len(notifications, subscriptionBuffer)
+	})
+}
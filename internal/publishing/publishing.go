@@ -0,0 +1,65 @@
+package publishing
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Publisher publishes drafts whose publication date has come.
+type Publisher interface {
+	PublishDuePosts(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Scheduler periodically publishes scheduled posts. The schedule is kept in the storage, so posts that became due
+// while the service was stopped are published on the first run after the restart.
+type Scheduler struct {
+	publisher    Publisher
+	pollInterval time.Duration
+}
+
+func NewScheduler(publisher Publisher, pollInterval time.Duration) *Scheduler {
+	return &Scheduler{
+		publisher:    publisher,
+		pollInterval: pollInterval,
+	}
+}
+
+// Run publishes scheduled posts until the context is cancelled.
+func (scheduler *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(scheduler.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		published, err := scheduler.Publish(ctx, time.Now())
+		if err != nil {
+			log.Printf("Error while publishing scheduled posts: %s", err)
+		} else if published > 0 {
+			log.Printf("%d scheduled posts were published.", published)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish publishes all posts scheduled no later than now batch by batch and returns their number.
+func (scheduler *Scheduler) Publish(ctx context.Context, now time.Time) (int64, error) {
+	var total int64
+	for {
+		published, err := scheduler.publisher.PublishDuePosts(ctx, now)
+		if err != nil {
+			return total, err
+		}
+
+		if published == 0 {
+			return total, nil
+		}
+
+		total += published
+	}
+}
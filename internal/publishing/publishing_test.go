@@ -0,0 +1,51 @@
+package publishing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPublisher struct {
+	batches []int64
+	err     error
+	now     time.Time
+}
+
+func (publisher *testPublisher) PublishDuePosts(ctx context.Context, now time.Time) (int64, error) {
+	publisher.now = now
+	if len(publisher.batches) == 0 {
+		return 0, publisher.err
+	}
+
+	published := publisher.batches[0]
+	publisher.batches = publisher.batches[1:]
+	return published, nil
+}
+
+func TestScheduler(t *testing.T) {
+	assertions := assert.New(t)
+	now := time.Date(2026, 4, 14, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Successful Publish", func(t *testing.T) {
+		publisher := &testPublisher{batches: []int64{100, 100, 3}}
+		scheduler := NewScheduler(publisher, time.Minute)
+
+		published, err := scheduler.Publish(context.Background(), now)
+		assertions.NoError(err)
+		assertions.Equal(int64(203), published)
+		assertions.Equal(now, publisher.now)
+	})
+
+	t.Run("Unsuccessful Publish", func(t *testing.T) {
+		publisher := &testPublisher{batches: []int64{100}, err: errors.New("Test")}
+		scheduler := NewScheduler(publisher, time.Minute)
+
+		published, err := scheduler.Publish(context.Background(), now)
+		assertions.Error(err)
+		assertions.Equal(int64(100), published)
+	})
+}
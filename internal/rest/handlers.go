@@ -0,0 +1,413 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/google/uuid"
+)
+
+var (
+	postIDParameter = parameter{name: "id", in: "path", description: "ID of the post", kind: reflect.TypeFor[int64]()}
+	limitParameter  = parameter{name: "limit", in: "query", description: "Maximum number of items, " + strconv.Itoa(defaultPageSize) +
+		" by default and at most " + strconv.Itoa(maxPageSize), kind: reflect.TypeFor[int32]()}
+	cursorParameter = parameter{name: "cursor", in: "query", description: "nextCursor of the previous page", kind: reflect.TypeFor[string]()}
+)
+
+func (api *API) getRoutes() []*route {
+	return []*route{
+		{
+			method:      http.MethodGet,
+			path:        "/posts",
+			operationID: "listPosts",
+			summary:     "Lists posts from the newest to the oldest",
+			parameters: []parameter{
+				cursorParameter,
+				limitParameter,
+				{name: "authorID", in: "query", description: "Only posts of the author", kind: reflect.TypeFor[uuid.UUID]()},
+				{name: "tag", in: "query", description: "Only posts with the tag", kind: reflect.TypeFor[string]()},
+			},
+			response: reflect.TypeFor[PostsPage](),
+			status:   http.StatusOK,
+			handle:   api.listPosts,
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/posts",
+			operationID: "createPost",
+			summary:     "Creates a post",
+			request:     reflect.TypeFor[model.PostInput](),
+			response:    reflect.TypeFor[Post](),
+			status:      http.StatusCreated,
+			handle:      api.createPost,
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/posts/{id}",
+			operationID: "getPost",
+			summary:     "Returns the post",
+			parameters:  []parameter{postIDParameter},
+			response:    reflect.TypeFor[Post](),
+			status:      http.StatusOK,
+			handle:      api.getPost,
+		},
+		{
+			method:      http.MethodGet,
+			path:        "/posts/{id}/comments",
+			operationID: "listComments",
+			summary:     "Lists comments of the post, pinned comments first, or replies to the comment with parentID",
+			parameters: []parameter{
+				postIDParameter,
+				cursorParameter,
+				limitParameter,
+				{name: "order", in: "query", description: "Order of the comments, " + string(model.CommentsOrderOldest) + " by default",
+					kind: reflect.TypeFor[model.CommentsOrder]()},
+				{name: "parentID", in: "query", description: "ID of the comment to list replies to", kind: reflect.TypeFor[int64]()},
+			},
+			response: reflect.TypeFor[CommentsPage](),
+			status:   http.StatusOK,
+			handle:   api.listComments,
+		},
+		{
+			method:      http.MethodPost,
+			path:        "/posts/{id}/comments",
+			operationID: "createComment",
+			summary:     "Adds a comment to the post",
+			parameters:  []parameter{postIDParameter},
+			request:     reflect.TypeFor[NewComment](),
+			response:    reflect.TypeFor[Comment](),
+			status:      http.StatusCreated,
+			handle:      api.createComment,
+		},
+		{
+			method:      http.MethodPatch,
+			path:        "/posts/{id}/comments-enabled",
+			operationID: "updateCommentsEnabled",
+			summary:     "Enables or disables comments on the post",
+			parameters:  []parameter{postIDParameter},
+			request:     reflect.TypeFor[CommentsEnabledChange](),
+			response:    reflect.TypeFor[Post](),
+			status:      http.StatusOK,
+			handle:      api.updateCommentsEnabled,
+		},
+	}
+}
+
+func (api *API) listPosts(request *http.Request) (any, error) {
+	query := request.URL.Query()
+	limit, err := getLimit(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var after *cursor.PostCursor
+	if value := query.Get("cursor"); value != "" {
+		after, err = cursor.ParsePost(value)
+		if err != nil {
+			return nil, badRequest(err.Error())
+		}
+	}
+
+	filter := &model.PostsFilter{}
+	if value := query.Get("authorID"); value != "" {
+		authorID, err := uuid.Parse(value)
+		if err != nil {
+			return nil, badRequest("Author ID " + value + " is invalid.")
+		}
+		filter.AuthorID = &authorID
+	}
+
+	if value := query.Get("tag"); value != "" {
+		filter.Tag = &value
+	}
+
+	fetchLimit := limit + 1
+	posts, err := api.accessor.GetPosts(request.Context(), filter, authorization.PrincipalFromContext(request.Context()).UserID, after, &fetchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &PostsPage{}
+	if len(posts) > int(limit) {
+		posts = posts[:limit]
+		last := posts[len(posts)-1]
+		nextCursor := cursor.CreatePost(last.CreateDate.UnixNano(), last.ID)
+		page.NextCursor = &nextCursor
+	}
+
+	page.Items, err = api.newPosts(request.Context(), posts)
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (api *API) getPost(request *http.Request) (any, error) {
+	post, err := api.findPost(request)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := api.newPosts(request.Context(), []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return posts[0], nil
+}
+
+func (api *API) createPost(request *http.Request) (any, error) {
+	var newPost model.PostInput
+	if err := readJSON(request, &newPost); err != nil {
+		return nil, err
+	}
+
+	post, err := api.service.AddPost(request.Context(), &newPost)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := api.newPosts(request.Context(), []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return posts[0], nil
+}
+
+func (api *API) listComments(request *http.Request) (any, error) {
+	query := request.URL.Query()
+	limit, err := getLimit(request)
+	if err != nil {
+		return nil, err
+	}
+
+	order := model.CommentsOrderOldest
+	if value := query.Get("order"); value != "" {
+		order = model.CommentsOrder(value)
+		if !order.IsValid() {
+			return nil, badRequest("Order " + value + " is invalid.")
+		}
+	}
+
+	post, err := api.findPost(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// Comments of a post with disabled comments are hidden, as in the GraphQL API.
+	if !post.CommentsEnabled {
+		return &CommentsPage{Items: []*Comment{}}, nil
+	}
+
+	if value := query.Get("parentID"); value != "" {
+		parentID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, badRequest("Parent comment ID " + value + " is invalid.")
+		}
+
+		path, err := api.accessor.GetCommentPath(request.Context(), post.ID, &parentID)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := api.service.GetCommentsLevel(request.Context(), post.ID, path, order, getCursor(request), &limit)
+		if err != nil {
+			return nil, err
+		}
+
+		return api.newCommentsPage(page), nil
+	}
+
+	page, err := api.service.GetPostComments(request.Context(), post.ID, order, getCursor(request), &limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.newCommentsPage(page), nil
+}
+
+func (api *API) createComment(request *http.Request) (any, error) {
+	postID, err := getPostID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var newComment NewComment
+	if err = readJSON(request, &newComment); err != nil {
+		return nil, err
+	}
+
+	comment, err := api.service.AddComment(request.Context(), &model.CommentInput{
+		AuthorID: newComment.AuthorID,
+		PostID:   postID,
+		ParentID: newComment.ParentID,
+		Text:     newComment.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return api.newComment(comment), nil
+}
+
+func (api *API) updateCommentsEnabled(request *http.Request) (any, error) {
+	postID, err := getPostID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var change CommentsEnabledChange
+	if err = readJSON(request, &change); err != nil {
+		return nil, err
+	}
+
+	if change.CommentsEnabled == nil {
+		return nil, badRequest("commentsEnabled is required.")
+	}
+
+	post, err := api.service.UpdateCommentsEnabled(request.Context(), postID, change.AuthorID, *change.CommentsEnabled)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := api.newPosts(request.Context(), []*model.Post{post})
+	if err != nil {
+		return nil, err
+	}
+
+	return posts[0], nil
+}
+
+// findPost returns the post from the path if the principal may see it.
+func (api *API) findPost(request *http.Request) (*model.Post, error) {
+	postID, err := getPostID(request)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := api.accessor.GetPost(request.Context(), postID, authorization.PrincipalFromContext(request.Context()).UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (api *API) newPosts(ctx context.Context, posts []*model.Post) ([]*Post, error) {
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+
+	tags, err := api.accessor.GetPostsTags(ctx, postIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Post, 0, len(posts))
+	for _, post := range posts {
+		postTags := tags[post.ID]
+		if postTags == nil {
+			postTags = []string{}
+		}
+
+		items = append(items, &Post{
+			ID:              post.ID,
+			AuthorID:        post.AuthorID,
+			Title:           post.Title,
+			Text:            post.Text,
+			TextHTML:        api.renderer.RenderHTML(post.Text),
+			CreateDate:      post.CreateDate,
+			CommentsEnabled: post.CommentsEnabled,
+			ModerationMode:  post.ModerationMode,
+			Status:          post.Status,
+			PublishAt:       post.PublishAt,
+			CommentCount:    post.CommentCount,
+			Tags:            postTags,
+		})
+	}
+
+	return items, nil
+}
+
+func (api *API) newComment(comment *model.Comment) *Comment {
+	return &Comment{
+		ID:         comment.ID,
+		PostID:     comment.PostID,
+		ParentID:   comment.ParentID,
+		AuthorID:   comment.AuthorID,
+		Text:       comment.Text,
+		TextHTML:   api.renderer.RenderHTML(comment.Text),
+		CreateDate: comment.CreateDate,
+		ReplyCount: comment.ReplyCount,
+	}
+}
+
+// newCommentsPage turns the page of the service into a response, which has only the cursor of the next page.
+func (api *API) newCommentsPage(page *service.CommentsPage) *CommentsPage {
+	response := &CommentsPage{Items: make([]*Comment, 0, len(page.Edges))}
+	for _, edge := range page.Edges {
+		item := api.newComment(edge.Comment)
+		item.IsPinned = edge.IsPinned
+		response.Items = append(response.Items, item)
+	}
+
+	if page.HasNextPage && len(page.Edges) > 0 {
+		nextCursor := page.Edges[len(page.Edges)-1].Cursor
+		response.NextCursor = &nextCursor
+	}
+
+	return response
+}
+
+func getPostID(request *http.Request) (int64, error) {
+	value := request.PathValue("id")
+	postID, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, notFound("Post with ID " + value + " was not found")
+	}
+
+	return postID, nil
+}
+
+// getLimit reads the page size from the limit parameter.
+func getLimit(request *http.Request) (int32, error) {
+	value := request.URL.Query().Get("limit")
+	if value == "" {
+		return defaultPageSize, nil
+	}
+
+	limit, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || limit <= 0 || limit > maxPageSize {
+		return 0, badRequest("Limit must be a number from 1 to " + strconv.Itoa(maxPageSize) + ".")
+	}
+
+	return int32(limit), nil
+}
+
+// getCursor returns the cursor of the page from the query, or nil for the first page.
+func getCursor(request *http.Request) *string {
+	value := request.URL.Query().Get("cursor")
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func getEnumValues[enumType ~string](values []enumType) []string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, string(value))
+	}
+
+	return names
+}
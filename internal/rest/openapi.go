@@ -0,0 +1,208 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+const openAPIVersion = "3.0.3"
+
+// Schema is a subset of the OpenAPI schema object sufficient for the types of the API.
+type Schema struct {
+	Ref        string             `json:"$ref,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Format     string             `json:"format,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Document is the OpenAPI document of the API.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// enums lists values of string types generated from the GraphQL schema.
+var enums = map[reflect.Type][]string{
+	reflect.TypeFor[model.ModerationMode](): getEnumValues(model.AllModerationMode),
+	reflect.TypeFor[model.PostStatus]():     getEnumValues(model.AllPostStatus),
+	reflect.TypeFor[model.CommentsOrder]():  getEnumValues(model.AllCommentsOrder),
+}
+
+// newDocument generates the OpenAPI document from the routes and the types of their bodies.
+func newDocument(routes []*route) *Document {
+	document := &Document{
+		OpenAPI:    openAPIVersion,
+		Info:       Info{Title: "Simple posts service", Version: "v1"},
+		Servers:    []Server{{URL: BasePath}},
+		Paths:      map[string]map[string]*Operation{},
+		Components: Components{Schemas: map[string]*Schema{}},
+	}
+
+	errorSchema := document.getSchema(reflect.TypeFor[Error]())
+	for _, route := range routes {
+		operation := &Operation{
+			OperationID: route.operationID,
+			Summary:     route.summary,
+			Responses: map[string]*Response{
+				strconv.Itoa(route.status): {
+					Description: http.StatusText(route.status),
+					Content:     map[string]*MediaType{"application/json": {Schema: document.getSchema(route.response)}},
+				},
+				"default": {
+					Description: "Error",
+					Content:     map[string]*MediaType{"application/json": {Schema: errorSchema}},
+				},
+			},
+		}
+
+		for _, parameter := range route.parameters {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:        parameter.name,
+				In:          parameter.in,
+				Description: parameter.description,
+				Required:    parameter.in == "path",
+				Schema:      document.getSchema(parameter.kind),
+			})
+		}
+
+		if route.request != nil {
+			operation.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: document.getSchema(route.request)}},
+			}
+		}
+
+		if document.Paths[route.path] == nil {
+			document.Paths[route.path] = map[string]*Operation{}
+		}
+		document.Paths[route.path][strings.ToLower(route.method)] = operation
+	}
+
+	return document
+}
+
+// getSchema returns the schema of the type. Structs are added to the components and referenced by their names.
+func (document *Document) getSchema(kind reflect.Type) *Schema {
+	switch kind {
+	case reflect.TypeFor[time.Time]():
+		return &Schema{Type: "string", Format: "date-time"}
+
+	case reflect.TypeFor[uuid.UUID]():
+		return &Schema{Type: "string", Format: "uuid"}
+	}
+
+	if values, ok := enums[kind]; ok {
+		return &Schema{Type: "string", Enum: values}
+	}
+
+	switch kind.Kind() {
+	case reflect.Pointer:
+		return document.getSchema(kind.Elem())
+
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: document.getSchema(kind.Elem())}
+
+	case reflect.Struct:
+		if _, ok := document.Components.Schemas[kind.Name()]; !ok {
+			// The placeholder stops the recursion on types referencing themselves.
+			document.Components.Schemas[kind.Name()] = &Schema{}
+			document.Components.Schemas[kind.Name()] = document.getStructSchema(kind)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + kind.Name()}
+
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// getStructSchema describes the JSON fields of the struct. Fields without omitempty are required.
+func (document *Document) getStructSchema(kind reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for index := range kind.NumField() {
+		field := kind.Field(index)
+		tag := field.Tag.Get("json")
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = document.getSchema(field.Type)
+		if options != "omitempty" {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
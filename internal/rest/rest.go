@@ -0,0 +1,160 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"reflect"
+
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// BasePath is the prefix of all paths of the API.
+const BasePath = "/api/v1"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Renderer turns Markdown texts of posts and comments into HTML.
+type Renderer interface {
+	RenderHTML(text string) string
+}
+
+// parameter is a path or query parameter of a route.
+type parameter struct {
+	name        string
+	in          string
+	description string
+	kind        reflect.Type
+}
+
+// route describes an operation of the API. Routes are used both to serve requests and to generate the OpenAPI document,
+// so the document always matches the handlers.
+type route struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	parameters  []parameter
+	request     reflect.Type
+	response    reflect.Type
+	status      int
+	handle      func(request *http.Request) (any, error)
+}
+
+// API serves the REST API on the same service and storage as the GraphQL API.
+type API struct {
+	accessor storage.Accessor
+	service  *service.Service
+	renderer Renderer
+	routes   []*route
+	mux      *http.ServeMux
+	document []byte
+}
+
+func NewAPI(accessor storage.Accessor, postsService *service.Service, renderer Renderer) *API {
+	api := &API{
+		accessor: accessor,
+		service:  postsService,
+		renderer: renderer,
+		mux:      http.NewServeMux(),
+	}
+
+	api.routes = api.getRoutes()
+	for _, route := range api.routes {
+		api.mux.HandleFunc(route.method+" "+BasePath+route.path, api.serve(route))
+	}
+
+	document, err := json.Marshal(newDocument(api.routes))
+	if err != nil {
+		log.Fatalf("Error while generating OpenAPI document: %s", err)
+	}
+	api.document = document
+
+	api.mux.HandleFunc("GET "+BasePath+"/openapi.json", func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.Write(api.document)
+	})
+
+	return api
+}
+
+func (api *API) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	api.mux.ServeHTTP(writer, request)
+}
+
+func (api *API) serve(route *route) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		response, err := route.handle(request)
+		if err != nil {
+			writeError(writer, err)
+			return
+		}
+
+		writeJSON(writer, route.status, response)
+	}
+}
+
+// requestError is an error with the HTTP status of the response.
+type requestError struct {
+	status  int
+	message string
+}
+
+func (err *requestError) Error() string {
+	return err.message
+}
+
+func badRequest(message string) error {
+	return &requestError{status: http.StatusBadRequest, message: message}
+}
+
+func notFound(message string) error {
+	return &requestError{status: http.StatusNotFound, message: message}
+}
+
+// writeError responds with the error. Errors of access checks get the same code as in the GraphQL API.
+// Failures of the storage are logged and hidden behind a generic message, other errors of the service are errors of the request.
+func writeError(writer http.ResponseWriter, err error) {
+	var requestErr *requestError
+	switch {
+	case errors.As(err, &requestErr):
+		writeJSON(writer, requestErr.status, &Error{Message: requestErr.message})
+
+	case errors.Is(err, authorization.ErrForbidden):
+		writeJSON(writer, http.StatusForbidden, &Error{Message: err.Error(), Code: authorization.ErrorCodeForbidden})
+
+	case errors.Is(err, storage.ErrNotFound):
+		writeJSON(writer, http.StatusNotFound, &Error{Message: err.Error()})
+
+	case storage.IsInternal(err):
+		log.Printf("Error while handling request: %s", err)
+		writeJSON(writer, http.StatusInternalServerError, &Error{Message: http.StatusText(http.StatusInternalServerError)})
+
+	default:
+		writeJSON(writer, http.StatusBadRequest, &Error{Message: err.Error()})
+	}
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body any) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	if err := json.NewEncoder(writer).Encode(body); err != nil {
+		log.Printf("Error while writing response: %s", err)
+	}
+}
+
+// readJSON decodes the request body into the value.
+func readJSON(request *http.Request, value any) error {
+	if err := json.NewDecoder(request.Body).Decode(value); err != nil {
+		return badRequest("Request body is invalid: " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,314 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/service"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/storage/database"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type escapingRenderer struct{}
+
+func (escapingRenderer) RenderHTML(text string) string {
+	return "<p>" + html.EscapeString(text) + "</p>"
+}
+
+// failingAccessor fails to read posts, like a storage which lost its connection.
+type failingAccessor struct {
+	storage.Accessor
+}
+
+func (accessor *failingAccessor) GetPost(ctx context.Context, postID int64, viewerID uuid.UUID) (*model.Post, error) {
+	return nil, sql.ErrConnDone
+}
+
+func call(handler http.Handler, method string, target string, userID uuid.UUID, body any, response any) *httptest.ResponseRecorder {
+	var reader *bytes.Reader
+	if body != nil {
+		encoded, _ := json.Marshal(body)
+		reader = bytes.NewReader(encoded)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	request := httptest.NewRequest(method, target, reader)
+	if userID != uuid.Nil {
+		request.Header.Set(authorization.UserHeader, userID.String())
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if response != nil {
+		json.Unmarshal(recorder.Body.Bytes(), response)
+	}
+
+	return recorder
+}
+
+func TestAPI(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+	secondAuthorID := uuid.New()
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	postsService := service.NewService(accessor, service.DeletionPolicy{RestoreWindow: time.Hour},
+		service.ReplyDepthPolicy{MaxDepth: service.UnlimitedReplyDepth}, service.PinPolicy{MaxPinned: 3})
+	api := NewAPI(accessor, postsService, escapingRenderer{})
+	// Requests of httptest come from 192.0.2.1.
+	handler := authorization.Middleware(accessor, nil, []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")}, api)
+
+	var first, second Post
+	t.Run("Successful Create Post", func(t *testing.T) {
+		recorder := call(handler, http.MethodPost, BasePath+"/posts", authorID,
+			&model.PostInput{AuthorID: authorID, Title: "First", Text: "<b>Text</b>", CommentsEnabled: true}, &first)
+		assertions.Equal(http.StatusCreated, recorder.Code)
+		assertions.Equal("First", first.Title)
+		assertions.Equal("<p>&lt;b&gt;Text&lt;/b&gt;</p>", first.TextHTML)
+		assertions.Equal(model.PostStatusPublished, first.Status)
+		assertions.Equal([]string{}, first.Tags)
+
+		call(handler, http.MethodPost, BasePath+"/posts", secondAuthorID, &model.PostInput{AuthorID: secondAuthorID, Title: "Second", Text: "Text"}, &second)
+		assertions.Equal("Second", second.Title)
+	})
+
+	t.Run("Successful Get Post", func(t *testing.T) {
+		var post Post
+		recorder := call(handler, http.MethodGet, BasePath+"/posts/"+strconv.FormatInt(first.ID, 10), uuid.Nil, nil, &post)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Equal(first, post)
+	})
+
+	t.Run("Successful List Posts", func(t *testing.T) {
+		var page PostsPage
+		recorder := call(handler, http.MethodGet, BasePath+"/posts?limit=1", uuid.Nil, nil, &page)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Len(page.Items, 1)
+		assertions.Equal("Second", page.Items[0].Title)
+		assertions.NotNil(page.NextCursor)
+
+		var nextPage PostsPage
+		call(handler, http.MethodGet, BasePath+"/posts?limit=1&cursor="+url.QueryEscape(*page.NextCursor), uuid.Nil, nil, &nextPage)
+		assertions.Len(nextPage.Items, 1)
+		assertions.Equal("First", nextPage.Items[0].Title)
+		assertions.Nil(nextPage.NextCursor)
+
+		var authorPage PostsPage
+		call(handler, http.MethodGet, BasePath+"/posts?authorID="+authorID.String(), uuid.Nil, nil, &authorPage)
+		assertions.Len(authorPage.Items, 1)
+		assertions.Equal(first.ID, authorPage.Items[0].ID)
+	})
+
+	t.Run("Successful Comments", func(t *testing.T) {
+		target := BasePath + "/posts/" + strconv.FormatInt(first.ID, 10) + "/comments"
+
+		var comments []Comment
+		for _, text := range []string{"One", "Two", "Three"} {
+			var comment Comment
+			recorder := call(handler, http.MethodPost, target, authorID, &NewComment{AuthorID: authorID, Text: text}, &comment)
+			assertions.Equal(http.StatusCreated, recorder.Code)
+			assertions.Equal(first.ID, comment.PostID)
+			comments = append(comments, comment)
+		}
+
+		var reply Comment
+		call(handler, http.MethodPost, target, authorID, &NewComment{AuthorID: authorID, ParentID: &comments[0].ID, Text: "Reply"}, &reply)
+
+		_, err := postsService.PinComment(authorization.WithPrincipal(ctx, authorization.Principal{UserID: authorID, Role: model.RoleUser}), comments[2].ID, authorID)
+		assertions.NoError(err)
+
+		var page CommentsPage
+		recorder := call(handler, http.MethodGet, target+"?limit=2", uuid.Nil, nil, &page)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Len(page.Items, 2)
+		assertions.Equal("Three", page.Items[0].Text)
+		assertions.True(page.Items[0].IsPinned)
+		assertions.Equal("One", page.Items[1].Text)
+		assertions.Equal(int32(1), page.Items[1].ReplyCount)
+		assertions.NotNil(page.NextCursor)
+
+		var nextPage CommentsPage
+		call(handler, http.MethodGet, target+"?limit=2&cursor="+url.QueryEscape(*page.NextCursor), uuid.Nil, nil, &nextPage)
+		assertions.Len(nextPage.Items, 1)
+		assertions.Equal("Two", nextPage.Items[0].Text)
+		assertions.Nil(nextPage.NextCursor)
+
+		var replies CommentsPage
+		call(handler, http.MethodGet, target+"?parentID="+strconv.FormatInt(comments[0].ID, 10), uuid.Nil, nil, &replies)
+		assertions.Len(replies.Items, 1)
+		assertions.Equal(reply.ID, replies.Items[0].ID)
+
+		recorder = call(handler, http.MethodGet, target+"?parentID=100", uuid.Nil, nil, nil)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("Successful Update Comments Enabled", func(t *testing.T) {
+		target := BasePath + "/posts/" + strconv.FormatInt(first.ID, 10)
+		commentsEnabled := false
+
+		var post Post
+		recorder := call(handler, http.MethodPatch, target+"/comments-enabled", authorID,
+			&CommentsEnabledChange{AuthorID: authorID, CommentsEnabled: &commentsEnabled}, &post)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.False(post.CommentsEnabled)
+
+		var page CommentsPage
+		call(handler, http.MethodGet, target+"/comments", uuid.Nil, nil, &page)
+		assertions.Empty(page.Items)
+	})
+
+	t.Run("Successful OpenAPI Document", func(t *testing.T) {
+		var document Document
+		recorder := call(handler, http.MethodGet, BasePath+"/openapi.json", uuid.Nil, nil, &document)
+		assertions.Equal(http.StatusOK, recorder.Code)
+		assertions.Equal(openAPIVersion, document.OpenAPI)
+
+		for _, route := range api.routes {
+			assertions.Contains(document.Paths[route.path], strings.ToLower(route.method))
+		}
+
+		assertions.Equal("#/components/schemas/Post", document.Paths["/posts/{id}"]["get"].Responses["200"].Content["application/json"].Schema.Ref)
+		assertions.Equal(getEnumValues(model.AllPostStatus), document.Components.Schemas["Post"].Properties["status"].Enum)
+		assertions.NotContains(document.Components.Schemas["Post"].Required, "publishAt")
+	})
+
+	t.Run("Unsuccessful Not Found", func(t *testing.T) {
+		var response Error
+		recorder := call(handler, http.MethodGet, BasePath+"/posts/100", uuid.Nil, nil, &response)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+		assertions.NotEmpty(response.Message)
+
+		recorder = call(handler, http.MethodGet, BasePath+"/posts/post/comments", uuid.Nil, nil, nil)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+	})
+
+	t.Run("Unsuccessful Storage Error", func(t *testing.T) {
+		failingAPI := NewAPI(&failingAccessor{Accessor: accessor}, postsService, escapingRenderer{})
+
+		var response Error
+		recorder := call(failingAPI, http.MethodGet, BasePath+"/posts/"+strconv.FormatInt(first.ID, 10), uuid.Nil, nil, &response)
+		assertions.Equal(http.StatusInternalServerError, recorder.Code)
+		assertions.Equal(http.StatusText(http.StatusInternalServerError), response.Message)
+	})
+
+	t.Run("Unsuccessful Bad Request", func(t *testing.T) {
+		recorder := call(handler, http.MethodGet, BasePath+"/posts?limit=101", uuid.Nil, nil, nil)
+		assertions.Equal(http.StatusBadRequest, recorder.Code)
+
+		recorder = call(handler, http.MethodGet, BasePath+"/posts/"+strconv.FormatInt(second.ID, 10)+"/comments?order=RANDOM", uuid.Nil, nil, nil)
+		assertions.Equal(http.StatusBadRequest, recorder.Code)
+
+		recorder = call(handler, http.MethodPatch, BasePath+"/posts/"+strconv.FormatInt(second.ID, 10)+"/comments-enabled", authorID,
+			&CommentsEnabledChange{AuthorID: authorID}, nil)
+		assertions.Equal(http.StatusBadRequest, recorder.Code)
+
+		commentsEnabled := true
+		recorder = call(handler, http.MethodPatch, BasePath+"/posts/"+strconv.FormatInt(second.ID, 10)+"/comments-enabled", authorID,
+			&CommentsEnabledChange{AuthorID: authorID, CommentsEnabled: &commentsEnabled}, nil)
+		assertions.Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("Unsuccessful Forbidden", func(t *testing.T) {
+		bannedID := uuid.New()
+		_, err := accessor.AddBan(ctx, &model.BanInput{UserID: bannedID}, authorID)
+		assertions.NoError(err)
+
+		var response Error
+		recorder := call(handler, http.MethodPost, BasePath+"/posts", bannedID, &model.PostInput{AuthorID: bannedID, Title: "Banned", Text: "Text"}, &response)
+		assertions.Equal(http.StatusForbidden, recorder.Code)
+		assertions.Equal(authorization.ErrorCodeForbidden, response.Code)
+
+		recorder = call(handler, http.MethodPost, BasePath+"/posts", secondAuthorID, &model.PostInput{AuthorID: authorID, Title: "Other", Text: "Text"}, nil)
+		assertions.Equal(http.StatusForbidden, recorder.Code)
+
+		recorder = call(handler, http.MethodPost, BasePath+"/posts", uuid.Nil, &model.PostInput{AuthorID: authorID, Title: "Anonymous", Text: "Text"}, nil)
+		assertions.Equal(http.StatusForbidden, recorder.Code)
+	})
+}
+
+func TestAPIDatabase(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+
+	mockStorage, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mockStorage.Close()
+
+	accessor := database.NewDatabaseAccessor(mockStorage, "simple")
+	postsService := service.NewService(accessor, service.DeletionPolicy{RestoreWindow: time.Hour},
+		service.ReplyDepthPolicy{MaxDepth: service.UnlimitedReplyDepth}, service.PinPolicy{MaxPinned: 3})
+	handler := authorization.Middleware(accessor, nil, []netip.Prefix{netip.MustParsePrefix("192.0.2.0/24")}, NewAPI(accessor, postsService, escapingRenderer{}))
+
+	t.Run("Unsuccessful Not Found", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text`).
+			WithArgs(int64(100), model.PostStatusPublished, uuid.Nil).
+			WillReturnError(sql.ErrNoRows)
+
+		var response Error
+		recorder := call(handler, http.MethodGet, BasePath+"/posts/100", uuid.Nil, nil, &response)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+		assertions.NotEmpty(response.Message)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Update Comments Enabled Post Not Found", func(t *testing.T) {
+		mock.ExpectQuery(`FROM users`).
+			WithArgs(authorID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT post_id, author_id, moderation_mode`).
+			WithArgs(int64(100), authorID, false).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		commentsEnabled := false
+		var response Error
+		recorder := call(handler, http.MethodPatch, BasePath+"/posts/100/comments-enabled", authorID,
+			&CommentsEnabledChange{AuthorID: authorID, CommentsEnabled: &commentsEnabled}, &response)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+		assertions.NotEmpty(response.Message)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Create Comment Post Not Found", func(t *testing.T) {
+		mock.ExpectQuery(`FROM users`).
+			WithArgs(authorID).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FROM bans`).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery(`SELECT moderation_mode, status`).
+			WithArgs(int64(100)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		var response Error
+		recorder := call(handler, http.MethodPost, BasePath+"/posts/100/comments", authorID,
+			&NewComment{AuthorID: authorID, Text: "Text"}, &response)
+		assertions.Equal(http.StatusNotFound, recorder.Code)
+		assertions.NotEmpty(response.Message)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
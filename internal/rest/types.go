@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+)
+
+type Post struct {
+	ID              int64                `json:"id"`
+	AuthorID        uuid.UUID            `json:"authorID"`
+	Title           string               `json:"title"`
+	Text            string               `json:"text"`
+	TextHTML        string               `json:"textHtml"`
+	CreateDate      time.Time            `json:"createDate"`
+	CommentsEnabled bool                 `json:"commentsEnabled"`
+	ModerationMode  model.ModerationMode `json:"moderationMode"`
+	Status          model.PostStatus     `json:"status"`
+	PublishAt       *time.Time           `json:"publishAt,omitempty"`
+	CommentCount    int32                `json:"commentCount"`
+	Tags            []string             `json:"tags"`
+}
+
+type Comment struct {
+	ID         int64     `json:"id"`
+	PostID     int64     `json:"postID"`
+	ParentID   *int64    `json:"parentID,omitempty"`
+	AuthorID   uuid.UUID `json:"authorID"`
+	Text       string    `json:"text"`
+	TextHTML   string    `json:"textHtml"`
+	CreateDate time.Time `json:"createDate"`
+	ReplyCount int32     `json:"replyCount"`
+	IsPinned   bool      `json:"isPinned"`
+}
+
+// PostsPage is a page of posts. NextCursor is set when there are more posts.
+type PostsPage struct {
+	Items      []*Post `json:"items"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+}
+
+// CommentsPage is a page of comments. NextCursor is set when there are more comments.
+type CommentsPage struct {
+	Items      []*Comment `json:"items"`
+	NextCursor *string    `json:"nextCursor,omitempty"`
+}
+
+type NewComment struct {
+	AuthorID uuid.UUID `json:"authorID"`
+	ParentID *int64    `json:"parentID,omitempty"`
+	Text     string    `json:"text"`
+}
+
+type CommentsEnabledChange struct {
+	AuthorID        uuid.UUID `json:"authorID"`
+	CommentsEnabled *bool     `json:"commentsEnabled"`
+}
+
+// Error is the body of unsuccessful responses. Code is set for the same errors as in the GraphQL API.
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
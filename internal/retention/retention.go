@@ -0,0 +1,57 @@
+package retention
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Store permanently removes deleted posts and comments.
+type Store interface {
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// Purger periodically removes posts and comments that were deleted longer than maxAge ago.
+type Purger struct {
+	store        Store
+	maxAge       time.Duration
+	pollInterval time.Duration
+}
+
+func NewPurger(store Store, maxAge time.Duration, pollInterval time.Duration) *Purger {
+	return &Purger{
+		store:        store,
+		maxAge:       maxAge,
+		pollInterval: pollInterval,
+	}
+}
+
+// Run purges deleted content until the context is cancelled.
+func (purger *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(purger.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		purged, err := purger.Purge(ctx, time.Now())
+		if err != nil {
+			log.Printf("Error while purging deleted posts and comments: %s", err)
+			continue
+		}
+
+		if purged > 0 {
+			log.Printf("%d deleted posts and comments were purged.", purged)
+		}
+	}
+}
+
+// Purge removes posts and comments deleted longer than maxAge before now and returns their number.
+func (purger *Purger) Purge(ctx context.Context, now time.Time) (int64, error) {
+	return purger.store.PurgeDeleted(ctx, now.Add(-purger.maxAge))
+}
@@ -0,0 +1,33 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStore struct {
+	deletedBefore time.Time
+}
+
+func (store *testStore) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	store.deletedBefore = deletedBefore
+	return 2, nil
+}
+
+func TestPurger(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Purge", func(t *testing.T) {
+		store := &testStore{}
+		purger := NewPurger(store, 24*time.Hour, time.Hour)
+		now := time.Date(2026, 3, 27, 12, 0, 0, 0, time.UTC)
+
+		purged, err := purger.Purge(context.Background(), now)
+		assertions.NoError(err)
+		assertions.Equal(int64(2), purged)
+		assertions.Equal(time.Date(2026, 3, 26, 12, 0, 0, 0, time.UTC), store.deletedBefore)
+	})
+}
@@ -0,0 +1,151 @@
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const (
+	snippetRadius = 8
+	snippetStart  = "<b>"
+	snippetStop   = "</b>"
+)
+
+// Match is a document found by the index together with its relevance.
+type Match struct {
+	ID   int64
+	Rank float64
+}
+
+// Index is a simple in-memory inverted index: every term points to the documents containing it
+// and to the number of its occurrences there.
+type Index struct {
+	postings map[string]map[int64]int
+	lengths  map[int64]int
+	mutex    *sync.RWMutex
+}
+
+func NewIndex() *Index {
+	return &Index{
+		postings: make(map[string]map[int64]int),
+		lengths:  make(map[int64]int),
+		mutex:    &sync.RWMutex{},
+	}
+}
+
+func (index *Index) Add(id int64, text string) {
+	defer index.mutex.Unlock()
+	index.mutex.Lock()
+
+	index.remove(id)
+
+	terms := Tokenize(text)
+	for _, term := range terms {
+		if _, ok := index.postings[term]; !ok {
+			index.postings[term] = make(map[int64]int)
+		}
+		index.postings[term][id]++
+	}
+
+	index.lengths[id] = len(terms)
+}
+
+func (index *Index) Remove(id int64) {
+	defer index.mutex.Unlock()
+	index.mutex.Lock()
+
+	index.remove(id)
+}
+
+// Search returns documents containing every term of the query, most relevant first.
+func (index *Index) Search(query string) []Match {
+	defer index.mutex.RUnlock()
+	index.mutex.RLock()
+
+	terms := slices.Compact(slices.Sorted(slices.Values(Tokenize(query))))
+	if len(terms) == 0 {
+		return []Match{}
+	}
+
+	ranks := make(map[int64]float64)
+	for idx, term := range terms {
+		documents := index.postings[term]
+		inverseFrequency := math.Log(1 + float64(len(index.lengths))/float64(len(documents)+1))
+
+		nextRanks := make(map[int64]float64)
+		for id, frequency := range documents {
+			rank, ok := ranks[id]
+			if idx > 0 && !ok {
+				continue
+			}
+
+			nextRanks[id] = rank + float64(frequency)/float64(index.lengths[id])*inverseFrequency
+		}
+		ranks = nextRanks
+	}
+
+	matches := make([]Match, 0, len(ranks))
+	for id, rank := range ranks {
+		matches = append(matches, Match{ID: id, Rank: rank})
+	}
+
+	slices.SortFunc(matches, func(a, b Match) int {
+		return cmp.Or(cmp.Compare(b.Rank, a.Rank), cmp.Compare(a.ID, b.ID))
+	})
+
+	return matches
+}
+
+func (index *Index) remove(id int64) {
+	if _, ok := index.lengths[id]; !ok {
+		return
+	}
+
+	for term, documents := range index.postings {
+		delete(documents, id)
+		if len(documents) == 0 {
+			delete(index.postings, term)
+		}
+	}
+
+	delete(index.lengths, id)
+}
+
+// Tokenize splits text into lower-cased words of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+// Snippet returns a fragment of the text around the first query term with all query terms highlighted.
+func Snippet(text string, query string) string {
+	terms := Tokenize(query)
+	words := strings.Fields(text)
+
+	first := -1
+	highlighted := make([]string, len(words))
+	for idx, word := range words {
+		highlighted[idx] = word
+		for _, wordTerm := range Tokenize(word) {
+			if slices.Contains(terms, wordTerm) {
+				highlighted[idx] = snippetStart + word + snippetStop
+				if first == -1 {
+					first = idx
+				}
+				break
+			}
+		}
+	}
+
+	start := max(0, first-snippetRadius)
+	end := min(len(words), max(first, 0)+snippetRadius+1)
+
+	return strings.Join(highlighted[start:end], " ")
+}
+
+func isSeparator(char rune) bool {
+	return !unicode.IsLetter(char) && !unicode.IsDigit(char)
+}
@@ -0,0 +1,50 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex(t *testing.T) {
+	assertions := assert.New(t)
+
+	index := NewIndex()
+	index.Add(0, "Golang generics: a short introduction")
+	index.Add(1, "Cooking pasta. Golang is not involved, but generics of pasta are!")
+	index.Add(2, "Привет, мир! Пост про Golang")
+
+	t.Run("Successful Tokenize", func(t *testing.T) {
+		assertions.Equal([]string{"привет", "мир", "пост", "про", "golang"}, Tokenize("Привет, мир! Пост про Golang"))
+	})
+
+	t.Run("Successful Search All Terms", func(t *testing.T) {
+		matches := index.Search("golang GENERICS")
+		assertions.Len(matches, 2)
+		assertions.Equal(int64(0), matches[0].ID)
+		assertions.Equal(int64(1), matches[1].ID)
+		assertions.Greater(matches[0].Rank, matches[1].Rank)
+	})
+
+	t.Run("Successful Search Unicode", func(t *testing.T) {
+		matches := index.Search("мир")
+		assertions.Len(matches, 1)
+		assertions.Equal(int64(2), matches[0].ID)
+	})
+
+	t.Run("Successful Search Nothing Found", func(t *testing.T) {
+		assertions.Empty(index.Search("rust"))
+		assertions.Empty(index.Search("   "))
+	})
+
+	t.Run("Successful Remove", func(t *testing.T) {
+		index.Remove(0)
+		matches := index.Search("introduction")
+		assertions.Empty(matches)
+	})
+
+	t.Run("Successful Snippet", func(t *testing.T) {
+		assertions.Equal("Cooking pasta. <b>Golang</b> is not involved, but generics of pasta are!",
+			Snippet("Cooking pasta. Golang is not involved, but generics of pasta are!", "golang"))
+	})
+}
@@ -0,0 +1,915 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// Service performs state changes of posts, comments and reactions. Every change is stored together with its domain event,
+// so the event is published if and only if the change is committed. Changes of users, roles, bans and webhook
+// subscriptions go through the service too, so every mutation has the same checks, but they are settings of accounts
+// and integrations, not content, and have no events.
+type Service struct {
+	storageAccessor  storage.Accessor
+	deletionPolicy   DeletionPolicy
+	replyDepthPolicy ReplyDepthPolicy
+	pinPolicy        PinPolicy
+}
+
+// DeletionPolicy sets how long deleted posts and comments can be restored.
+type DeletionPolicy struct {
+	RestoreWindow time.Duration
+}
+
+// UnlimitedReplyDepth allows replies of any depth.
+const UnlimitedReplyDepth = -1
+
+// DepthOverflowMode sets what happens to a reply deeper than the maximum reply depth.
+type DepthOverflowMode string
+
+const (
+	// DepthOverflowReject rejects the reply.
+	DepthOverflowReject DepthOverflowMode = "REJECT"
+	// DepthOverflowFlatten adds the reply to the deepest ancestor of its parent that may have replies.
+	DepthOverflowFlatten DepthOverflowMode = "FLATTEN"
+)
+
+// ReplyDepthPolicy limits the nesting of comments. A post may override MaxDepth with its own maximum.
+type ReplyDepthPolicy struct {
+	MaxDepth int32
+	Mode     DepthOverflowMode
+}
+
+// publishBatchSize is the maximum number of scheduled posts published in one transaction.
+const publishBatchSize = 100
+
+// PinPolicy limits the number of comments the author of a post may pin.
+type PinPolicy struct {
+	MaxPinned int32
+}
+
+func NewService(accessor storage.Accessor, deletionPolicy DeletionPolicy, replyDepthPolicy ReplyDepthPolicy, pinPolicy PinPolicy) *Service {
+	return &Service{
+		storageAccessor:  accessor,
+		deletionPolicy:   deletionPolicy,
+		replyDepthPolicy: replyDepthPolicy,
+		pinPolicy:        pinPolicy,
+	}
+}
+
+func (service *Service) AddPost(ctx context.Context, newPost *model.PostInput) (*model.Post, error) {
+	if err := checkActingUser(ctx, newPost.AuthorID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		if err := checkNotBanned(ctx, accessor, newPost.AuthorID, nil); err != nil {
+			return err
+		}
+
+		var err error
+		post, err = accessor.AddPost(ctx, newPost)
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewPostCreated(post))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) AddComment(ctx context.Context, newComment *model.CommentInput) (*model.Comment, error) {
+	if err := checkActingUser(ctx, newComment.AuthorID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		if err := checkNotBanned(ctx, accessor, newComment.AuthorID, &newComment.PostID); err != nil {
+			return err
+		}
+
+		parentID, err := service.getReplyParentID(ctx, accessor, newComment)
+		if err != nil {
+			return err
+		}
+
+		limitedComment := *newComment
+		limitedComment.ParentID = parentID
+
+		comment, err = accessor.AddComment(ctx, &limitedComment)
+		if err != nil {
+			return err
+		}
+
+		return addCommentEvent(ctx, accessor, comment, events.NewCommentAdded(comment))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) EditPost(ctx context.Context, postID int64, editorID uuid.UUID, changes *model.PostEditInput) (*model.Post, error) {
+	if err := checkActingUser(ctx, editorID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		if err := checkNotBanned(ctx, accessor, editorID, &postID); err != nil {
+			return err
+		}
+
+		var err error
+		var revision *model.Revision
+		post, revision, err = accessor.EditPost(ctx, postID, editorID, changes)
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewPostEdited(post, revision))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) EditComment(ctx context.Context, commentID int64, editorID uuid.UUID, text string) (*model.Comment, error) {
+	if err := checkActingUser(ctx, editorID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		oldComment, err := accessor.GetComment(ctx, commentID)
+		if err != nil {
+			return err
+		}
+
+		if err = checkNotBanned(ctx, accessor, editorID, &oldComment.PostID); err != nil {
+			return err
+		}
+
+		var revision *model.Revision
+		comment, revision, err = accessor.EditComment(ctx, commentID, editorID, text)
+		if err != nil {
+			return err
+		}
+
+		return addCommentEvent(ctx, accessor, comment, events.NewCommentEdited(comment, revision))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, newCommentsEnabled bool) (*model.Post, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		post, err = accessor.UpdateCommentsEnabled(ctx, postID, authorID, service.isPrivileged(ctx, authorization.ActionLockComments), newCommentsEnabled)
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewCommentsToggled(post))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) UpdateModerationMode(ctx context.Context, postID int64, authorID uuid.UUID, newModerationMode model.ModerationMode) (*model.Post, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		post, err = accessor.UpdateModerationMode(ctx, postID, authorID, newModerationMode)
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewCommentsToggled(post))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) UpdateCommentStatus(ctx context.Context, commentID int64, authorID uuid.UUID, newStatus model.CommentStatus) (*model.Comment, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		comment, err = accessor.UpdateCommentStatus(ctx, commentID, authorID, newStatus)
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, accessor, events.NewCommentStatusChanged(comment))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) DeletePost(ctx context.Context, postID int64, userID uuid.UUID) (*model.Post, error) {
+	if err := checkActingUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		post, err = accessor.DeletePost(ctx, postID, userID, service.isPrivileged(ctx, authorization.ActionRemovePost))
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewPostDeleted(post, userID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) RestorePost(ctx context.Context, postID int64, userID uuid.UUID) (*model.Post, error) {
+	if err := checkActingUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		post, err = accessor.RestorePost(ctx, postID, userID, service.isPrivileged(ctx, authorization.ActionRestorePost), service.getRestoreDeadline())
+		if err != nil {
+			return err
+		}
+
+		return addPostEvent(ctx, accessor, post, events.NewPostRestored(post, userID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (service *Service) DeleteComment(ctx context.Context, commentID int64, userID uuid.UUID) (*model.Comment, error) {
+	if err := checkActingUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		comment, err = accessor.DeleteComment(ctx, commentID, userID, service.isPrivileged(ctx, authorization.ActionRemoveComment))
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, accessor, events.NewCommentDeleted(comment, userID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) RestoreComment(ctx context.Context, commentID int64, userID uuid.UUID) (*model.Comment, error) {
+	if err := checkActingUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		comment, err = accessor.RestoreComment(ctx, commentID, userID, service.isPrivileged(ctx, authorization.ActionRestoreComment), service.getRestoreDeadline())
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, accessor, events.NewCommentRestored(comment, userID))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) PinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		comment, err = accessor.PinComment(ctx, commentID, authorID, service.pinPolicy.MaxPinned)
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, accessor, events.NewCommentPinToggled(comment, true))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (service *Service) UnpinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var comment *model.Comment
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		comment, err = accessor.UnpinComment(ctx, commentID, authorID)
+		if err != nil {
+			return err
+		}
+
+		return addEvent(ctx, accessor, events.NewCommentPinToggled(comment, false))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// PublishPost publishes the draft right away or schedules its publication if publishAt is in the future.
+func (service *Service) PublishPost(ctx context.Context, postID int64, authorID uuid.UUID, publishAt *time.Time) (*model.Post, error) {
+	if err := checkActingUser(ctx, authorID); err != nil {
+		return nil, err
+	}
+
+	var post *model.Post
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		post, err = accessor.PublishPost(ctx, postID, authorID, publishAt)
+		if err != nil {
+			return err
+		}
+
+		return addPublicationEvents(ctx, accessor, post)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// PublishDuePosts publishes a batch of drafts scheduled no later than now and returns the number of published posts.
+func (service *Service) PublishDuePosts(ctx context.Context, now time.Time) (int64, error) {
+	var published int64
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		posts, err := accessor.PublishDuePosts(ctx, now, publishBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, post := range posts {
+			if err = addPublicationEvents(ctx, accessor, post); err != nil {
+				return err
+			}
+		}
+
+		published = int64(len(posts))
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
+
+// BanUser bans the user globally or on the post. Moderators may ban only users with a lower role than their own,
+// so they cannot silence themselves, each other or administrators. Users who were never stored have the USER role.
+func (service *Service) BanUser(ctx context.Context, newBan *model.BanInput) (*model.Ban, error) {
+	principal := authorization.PrincipalFromContext(ctx)
+
+	var ban *model.Ban
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		role := model.RoleUser
+		user, err := accessor.GetUser(ctx, newBan.UserID)
+		if err == nil {
+			role = user.Role
+		} else if !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+
+		if newBan.UserID == principal.UserID || authorization.HasRole(role, principal.Role) {
+			return authorization.Forbidden("User with ID " + newBan.UserID.String() + " has a role not lower than the role of the moderator.")
+		}
+
+		ban, err = accessor.AddBan(ctx, newBan, principal.UserID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+// UnbanUser lifts the ban of the user globally or on the post.
+func (service *Service) UnbanUser(ctx context.Context, userID uuid.UUID, postID *int64) error {
+	return service.storageAccessor.DeleteBan(ctx, userID, postID)
+}
+
+// CreateUser stores the user. A user with the ID set may be created only by itself.
+func (service *Service) CreateUser(ctx context.Context, newUser *model.UserInput) (*model.User, error) {
+	if newUser.ID != nil {
+		if err := checkActingUser(ctx, *newUser.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return service.storageAccessor.AddUser(ctx, newUser)
+}
+
+func (service *Service) UpdateUser(ctx context.Context, userID uuid.UUID, changes *model.UserUpdateInput) (*model.User, error) {
+	if err := checkActingUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return service.storageAccessor.UpdateUser(ctx, userID, changes)
+}
+
+func (service *Service) SetUserRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error) {
+	return service.storageAccessor.UpdateUserRole(ctx, userID, role)
+}
+
+func (service *Service) CreateWebhookSubscription(ctx context.Context, newSubscription *model.WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	if err := checkActingUser(ctx, newSubscription.OwnerID); err != nil {
+		return nil, err
+	}
+
+	return service.storageAccessor.AddWebhookSubscription(ctx, newSubscription)
+}
+
+func (service *Service) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) error {
+	if err := checkActingUser(ctx, ownerID); err != nil {
+		return err
+	}
+
+	return service.storageAccessor.DeleteWebhookSubscription(ctx, subscriptionID, ownerID)
+}
+
+// React adds the reaction of the user and returns the new counts of reactions of the target.
+func (service *Service) React(ctx context.Context, reaction *model.ReactionInput) ([]*model.ReactionCount, error) {
+	return service.toggleReaction(ctx, reaction, true)
+}
+
+// Unreact removes the reaction of the user and returns the new counts of reactions of the target.
+func (service *Service) Unreact(ctx context.Context, reaction *model.ReactionInput) ([]*model.ReactionCount, error) {
+	return service.toggleReaction(ctx, reaction, false)
+}
+
+// GetCommentSortKeys returns the keys comments of a level are sorted by in the order: the create time,
+// or the number of reactions for the TOP order.
+func (service *Service) GetCommentSortKeys(ctx context.Context, comments []*model.Comment, order model.CommentsOrder) (map[int64]int64, error) {
+	sortKeys := make(map[int64]int64, len(comments))
+
+	if order != model.CommentsOrderTop {
+		for _, comment := range comments {
+			sortKeys[comment.ID] = comment.CreateDate.UnixNano()
+		}
+
+		return sortKeys, nil
+	}
+
+	commentIDs := make([]int64, len(comments))
+	for idx, comment := range comments {
+		commentIDs[idx] = comment.ID
+	}
+
+	reactionCounts, err := service.storageAccessor.GetReactionCounts(ctx, model.ReactionTargetComment, commentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, commentID := range commentIDs {
+		for _, reactionCount := range reactionCounts[commentID] {
+			sortKeys[commentID] += int64(reactionCount.Count)
+		}
+	}
+
+	return sortKeys, nil
+}
+
+// CommentEdge is a comment of a page with the cursor of the page following it.
+type CommentEdge struct {
+	Comment  *model.Comment
+	Cursor   string
+	IsPinned bool
+}
+
+// CommentsPage is a page of comments of one level.
+type CommentsPage struct {
+	Edges       []*CommentEdge
+	HasNextPage bool
+}
+
+// GetCommentsLevel returns up to limit ordered comments of the level with the path after the cursor,
+// or all of them if limit is nil.
+func (service *Service) GetCommentsLevel(ctx context.Context, postID int64, path string, order model.CommentsOrder, after *string, limit *int32) (*CommentsPage, error) {
+	var afterCursor *cursor.Cursor
+	if after != nil {
+		var err error
+		afterCursor, err = cursor.Parse(*after)
+		if err != nil {
+			return nil, err
+		}
+
+		if afterCursor.Path != path {
+			return nil, errors.New("Comment with cursor " + *after + " is not on the level " + path + ".")
+		}
+
+		if afterCursor.Order != order.String() {
+			return nil, errors.New("Cursor " + *after + " was created for the order " + afterCursor.Order + ", not " + order.String() + ".")
+		}
+	}
+
+	fetchLimit, err := getFetchLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := service.storageAccessor.GetCommentsLevel(ctx, postID, path, order, afterCursor, fetchLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CommentsPage{}
+	if limit != nil && len(comments) > int(*limit) {
+		comments = comments[:*limit]
+		page.HasNextPage = true
+	}
+
+	sortKeys, err := service.GetCommentSortKeys(ctx, comments, order)
+	if err != nil {
+		return nil, err
+	}
+
+	page.Edges = make([]*CommentEdge, 0, len(comments))
+	for _, comment := range comments {
+		page.Edges = append(page.Edges, &CommentEdge{
+			Comment: comment,
+			Cursor:  cursor.Create(order.String(), sortKeys[comment.ID], comment.ID, path),
+		})
+	}
+
+	return page, nil
+}
+
+// GetPostComments returns the first level of comments of the post. Pinned comments open the first page
+// and are excluded from the ordered level, so cursors of ordered comments stay valid when comments are pinned or unpinned.
+func (service *Service) GetPostComments(ctx context.Context, postID int64, order model.CommentsOrder, after *string, limit *int32) (*CommentsPage, error) {
+	path := strconv.FormatInt(postID, 10)
+
+	var afterPinned *cursor.PinnedCursor
+	if after != nil {
+		var err error
+		afterPinned, err = cursor.ParsePinned(*after)
+		if err != nil {
+			return service.GetCommentsLevel(ctx, postID, path, order, after, limit)
+		}
+	}
+
+	if _, err := getFetchLimit(limit); err != nil {
+		return nil, err
+	}
+
+	pinned, err := service.storageAccessor.GetPinnedComments(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if afterPinned != nil {
+		// A comment unpinned since the previous page is on the ordered level now, so the page starts there.
+		position := slices.IndexFunc(pinned, func(comment *model.Comment) bool {
+			return comment.ID == afterPinned.CommentID
+		})
+		if position == -1 {
+			position = len(pinned) - 1
+		}
+
+		pinned = pinned[position+1:]
+	}
+
+	hasMorePinned := false
+	var orderedLimit *int32
+	if limit != nil {
+		if len(pinned) > int(*limit) {
+			pinned = pinned[:*limit]
+			hasMorePinned = true
+		}
+
+		left := *limit - int32(len(pinned))
+		orderedLimit = &left
+	}
+
+	page, err := service.GetCommentsLevel(ctx, postID, path, order, nil, orderedLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*CommentEdge, 0, len(pinned)+len(page.Edges))
+	for _, comment := range pinned {
+		edges = append(edges, &CommentEdge{
+			Comment:  comment,
+			Cursor:   cursor.CreatePinned(comment.ID),
+			IsPinned: true,
+		})
+	}
+	page.Edges = append(edges, page.Edges...)
+	page.HasNextPage = page.HasNextPage || hasMorePinned
+
+	return page, nil
+}
+
+// GetPostRevisions returns revisions of the post. Revisions of a deleted post are hidden like its text.
+func (service *Service) GetPostRevisions(ctx context.Context, post *model.Post, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	if post.DeletedAt != nil {
+		return []*model.Revision{}, nil
+	}
+
+	return service.storageAccessor.GetPostRevisions(ctx, post.ID, after, limit)
+}
+
+// GetPostRevisionDiff compares two revisions of the post. It returns nil for a deleted post.
+func (service *Service) GetPostRevisionDiff(ctx context.Context, post *model.Post, from int32, to int32) (*model.RevisionDiff, error) {
+	if post.DeletedAt != nil {
+		return nil, nil
+	}
+
+	fromRevision, err := service.storageAccessor.GetPostRevision(ctx, post.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := service.storageAccessor.GetPostRevision(ctx, post.ID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.NewRevisionDiff(fromRevision, toRevision), nil
+}
+
+// GetCommentRevisions returns revisions of the comment. Revisions of a deleted comment are hidden
+// like its text and author, which a placeholder does not show.
+func (service *Service) GetCommentRevisions(ctx context.Context, comment *model.Comment, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	if comment.DeletedAt != nil {
+		return []*model.Revision{}, nil
+	}
+
+	return service.storageAccessor.GetCommentRevisions(ctx, comment.ID, after, limit)
+}
+
+// GetCommentRevisionDiff compares two revisions of the comment. It returns nil for a deleted comment.
+func (service *Service) GetCommentRevisionDiff(ctx context.Context, comment *model.Comment, from int32, to int32) (*model.RevisionDiff, error) {
+	if comment.DeletedAt != nil {
+		return nil, nil
+	}
+
+	fromRevision, err := service.storageAccessor.GetCommentRevision(ctx, comment.ID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRevision, err := service.storageAccessor.GetCommentRevision(ctx, comment.ID, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.NewRevisionDiff(fromRevision, toRevision), nil
+}
+
+// isPrivileged reports whether the principal of the request may perform the action on content of other users.
+func (service *Service) isPrivileged(ctx context.Context, action authorization.Action) bool {
+	return authorization.Overrides(authorization.PrincipalFromContext(ctx), action)
+}
+
+// getRestoreDeadline returns the earliest deletion date of posts and comments that can still be restored.
+func (service *Service) getRestoreDeadline() time.Time {
+	return time.Now().Add(-service.deletionPolicy.RestoreWindow)
+}
+
+func (service *Service) toggleReaction(ctx context.Context, reaction *model.ReactionInput, added bool) ([]*model.ReactionCount, error) {
+	if err := checkActingUser(ctx, reaction.UserID); err != nil {
+		return nil, err
+	}
+
+	var reactionCounts map[int64][]*model.ReactionCount
+	err := service.storageAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+		var err error
+		if added {
+			err = accessor.AddReaction(ctx, reaction)
+		} else {
+			err = accessor.DeleteReaction(ctx, reaction)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err = addEvent(ctx, accessor, events.NewReactionToggled(reaction, added)); err != nil {
+			return err
+		}
+
+		reactionCounts, err = accessor.GetReactionCounts(ctx, reaction.TargetType, []int64{reaction.TargetID})
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reactionCounts[reaction.TargetID], nil
+}
+
+// getReplyParentID returns the parent of the new comment allowed by the reply depth policy: the requested one
+// if the reply is not too deep, otherwise its deepest ancestor that may have replies in the FLATTEN mode.
+func (service *Service) getReplyParentID(ctx context.Context, accessor storage.Accessor, newComment *model.CommentInput) (*int64, error) {
+	if newComment.ParentID == nil {
+		return nil, nil
+	}
+
+	maxDepth, err := accessor.GetPostMaxReplyDepth(ctx, newComment.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxDepth == nil {
+		if service.replyDepthPolicy.MaxDepth == UnlimitedReplyDepth {
+			return newComment.ParentID, nil
+		}
+
+		maxDepth = &service.replyDepthPolicy.MaxDepth
+	}
+
+	path, err := accessor.GetCommentPath(ctx, newComment.PostID, newComment.ParentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestorIDs, err := helpers.GetPathCommentIDs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ancestorIDs) <= int(*maxDepth) {
+		return newComment.ParentID, nil
+	}
+
+	if service.replyDepthPolicy.Mode != DepthOverflowFlatten {
+		return nil, errors.New("Replies on post " + strconv.FormatInt(newComment.PostID, 10) + " can be nested no deeper than " + strconv.Itoa(int(*maxDepth)) + " levels.")
+	}
+
+	if *maxDepth == 0 {
+		return nil, nil
+	}
+
+	return &ancestorIDs[*maxDepth-1], nil
+}
+
+// getFetchLimit returns the number of items to read for a page of limit items: one more,
+// which tells whether there is a next page.
+func getFetchLimit(limit *int32) (*int32, error) {
+	if limit == nil {
+		return nil, nil
+	}
+
+	if *limit < 0 {
+		return nil, errors.New("Amount of items must not be negative, got " + strconv.FormatInt(int64(*limit), 10) + ".")
+	}
+
+	fetchLimit := *limit + 1
+	return &fetchLimit, nil
+}
+
+// checkActingUser returns a forbidden error unless the user passed by the client is the principal of the request.
+func checkActingUser(ctx context.Context, userID uuid.UUID) error {
+	return authorization.CheckActingUser(authorization.PrincipalFromContext(ctx), userID)
+}
+
+// checkNotBanned returns a forbidden error if the author is banned globally or, if postID is set, on the post.
+func checkNotBanned(ctx context.Context, accessor storage.Accessor, authorID uuid.UUID, postID *int64) error {
+	banned, err := accessor.IsBanned(ctx, authorID, postID)
+	if err != nil {
+		return err
+	}
+
+	if banned {
+		return authorization.Forbidden("User with ID " + authorID.String() + " is banned.")
+	}
+
+	return nil
+}
+
+// addPostEvent adds the event of a change of the post. Drafts stay private, so changes of unpublished posts are not announced.
+func addPostEvent(ctx context.Context, accessor storage.Accessor, post *model.Post, event events.Event) error {
+	if post.Status != model.PostStatusPublished {
+		return nil
+	}
+
+	return addEvent(ctx, accessor, event)
+}
+
+// addCommentEvent adds the event of a change of the comment. The text of comments awaiting premoderation must not
+// reach subscribers, so changes of comments which are not approved are not announced. Approval announces the text.
+func addCommentEvent(ctx context.Context, accessor storage.Accessor, comment *model.Comment, event events.Event) error {
+	if comment.Status != model.CommentStatusApproved {
+		return nil
+	}
+
+	return addEvent(ctx, accessor, event)
+}
+
+// addPublicationEvents announces the published post. Creation of a draft is not announced, so subscribers
+// to POST_CREATED learn about the post only now, before POST_PUBLISHED.
+func addPublicationEvents(ctx context.Context, accessor storage.Accessor, post *model.Post) error {
+	if post.Status != model.PostStatusPublished {
+		return nil
+	}
+
+	if err := addEvent(ctx, accessor, events.NewPostCreated(post)); err != nil {
+		return err
+	}
+
+	return addEvent(ctx, accessor, events.NewPostPublished(post))
+}
+
+func addEvent(ctx context.Context, accessor storage.Accessor, event events.Event) error {
+	envelope, err := events.NewEnvelope(event)
+	if err != nil {
+		return err
+	}
+
+	return accessor.AddEvent(ctx, envelope)
+}
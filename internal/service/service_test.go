@@ -0,0 +1,694 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/authorization"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/storage/inmemory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func asUser(ctx context.Context, userID uuid.UUID) context.Context {
+	return authorization.WithPrincipal(ctx, authorization.Principal{UserID: userID, Role: model.RoleUser})
+}
+
+func TestServiceEvents(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	authorID := uuid.New()
+	authorCtx := asUser(ctx, authorID)
+	commenterID := uuid.New()
+	commenterCtx := asUser(ctx, commenterID)
+	otherID := uuid.New()
+	otherCtx := asUser(ctx, otherID)
+	adminID := uuid.New()
+	adminCtx := authorization.WithPrincipal(ctx, authorization.Principal{UserID: adminID, Role: model.RoleAdmin})
+	moderatorID := uuid.New()
+	moderatorCtx := authorization.WithPrincipal(ctx, authorization.Principal{UserID: moderatorID, Role: model.RoleModerator})
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	getPendingKinds := func() []events.Kind {
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		kinds := make([]events.Kind, 0, len(envelopes))
+		for _, envelope := range envelopes {
+			kinds = append(kinds, envelope.Kind)
+		}
+		return kinds
+	}
+
+	t.Run("Successful AddPost", func(t *testing.T) {
+		post, err := service.AddPost(authorCtx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Title",
+			Text:            "Text",
+			CommentsEnabled: true,
+		})
+		assertions.NoError(err)
+
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+		assertions.Len(envelopes, 1)
+
+		event, err := envelopes[0].Decode()
+		assertions.NoError(err)
+
+		postCreated := event.(*events.PostCreated)
+		assertions.Equal(post.ID, postCreated.PostID)
+		assertions.Equal(post.Title, postCreated.Title)
+		assertions.True(post.CreateDate.Equal(postCreated.CreateDate))
+	})
+
+	t.Run("Successful AddComment", func(t *testing.T) {
+		_, err := service.AddComment(commenterCtx, &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Comment",
+		})
+		assertions.NoError(err)
+		assertions.Equal([]events.Kind{events.KindPostCreated, events.KindCommentAdded}, getPendingKinds())
+	})
+
+	t.Run("Successful UpdateCommentsEnabled", func(t *testing.T) {
+		_, err := service.UpdateCommentsEnabled(authorCtx, 0, authorID, false)
+		assertions.NoError(err)
+		assertions.Equal([]events.Kind{events.KindPostCreated, events.KindCommentAdded, events.KindCommentsToggled}, getPendingKinds())
+	})
+
+	t.Run("Unsuccessful AddComment to closed post", func(t *testing.T) {
+		_, err := service.AddComment(commenterCtx, &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Comment",
+		})
+		assertions.Error(err)
+		assertions.Equal([]events.Kind{events.KindPostCreated, events.KindCommentAdded, events.KindCommentsToggled}, getPendingKinds())
+	})
+
+	t.Run("Unsuccessful UpdateModerationMode not author", func(t *testing.T) {
+		_, err := service.UpdateModerationMode(otherCtx, 0, otherID, model.ModerationModePremoderated)
+		assertions.Error(err)
+		assertions.Len(getPendingKinds(), 3)
+	})
+
+	t.Run("Unsuccessful acting as another user", func(t *testing.T) {
+		_, err := service.UpdateModerationMode(otherCtx, 0, authorID, model.ModerationModePremoderated)
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+
+		_, err = service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text"})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+		assertions.Len(getPendingKinds(), 3)
+	})
+
+	t.Run("Successful EditPost", func(t *testing.T) {
+		title := "New title"
+		_, err := service.EditPost(authorCtx, 0, authorID, &model.PostEditInput{Title: &title})
+		assertions.NoError(err)
+
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+		assertions.Len(envelopes, 4)
+
+		event, err := envelopes[3].Decode()
+		assertions.NoError(err)
+
+		postEdited := event.(*events.PostEdited)
+		assertions.Equal(int64(0), postEdited.PostID)
+		assertions.Equal(title, postEdited.Title)
+	})
+
+	t.Run("Unsuccessful EditComment not author", func(t *testing.T) {
+		_, err := service.EditComment(authorCtx, 0, authorID, "Edited")
+		assertions.Error(err)
+		assertions.Len(getPendingKinds(), 4)
+	})
+
+	t.Run("Unsuccessful DeletePost not author", func(t *testing.T) {
+		_, err := service.DeletePost(otherCtx, 0, otherID)
+		assertions.Error(err)
+		assertions.Len(getPendingKinds(), 4)
+	})
+
+	t.Run("Unsuccessful DeletePost by moderator", func(t *testing.T) {
+		_, err := service.DeletePost(moderatorCtx, 0, moderatorID)
+		assertions.Error(err)
+		assertions.Len(getPendingKinds(), 4)
+	})
+
+	t.Run("Successful DeletePost by admin", func(t *testing.T) {
+		post, err := service.DeletePost(adminCtx, 0, adminID)
+		assertions.NoError(err)
+
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+		assertions.Len(envelopes, 5)
+
+		event, err := envelopes[4].Decode()
+		assertions.NoError(err)
+
+		postDeleted := event.(*events.PostDeleted)
+		assertions.Equal(int64(0), postDeleted.PostID)
+		assertions.Equal(adminID, postDeleted.ActorID)
+		assertions.True(post.DeletedAt.Equal(postDeleted.DeleteDate))
+	})
+
+	t.Run("Successful RestorePost", func(t *testing.T) {
+		_, err := service.RestorePost(authorCtx, 0, authorID)
+		assertions.NoError(err)
+		assertions.Equal(events.KindPostRestored, getPendingKinds()[5])
+	})
+
+	t.Run("Unsuccessful UpdateCommentsEnabled not author", func(t *testing.T) {
+		_, err := service.UpdateCommentsEnabled(otherCtx, 0, otherID, true)
+		assertions.Error(err)
+		assertions.Len(getPendingKinds(), 6)
+	})
+
+	t.Run("Successful UpdateCommentsEnabled by moderator", func(t *testing.T) {
+		post, err := service.UpdateCommentsEnabled(moderatorCtx, 0, moderatorID, true)
+		assertions.NoError(err)
+		assertions.True(post.CommentsEnabled)
+		assertions.Equal(events.KindCommentsToggled, getPendingKinds()[6])
+	})
+
+	t.Run("Unsuccessful AddComment banned on post", func(t *testing.T) {
+		bannedID := uuid.New()
+		postID := int64(0)
+		_, err := accessor.AddBan(ctx, &model.BanInput{UserID: bannedID, PostID: &postID}, adminID)
+		assertions.NoError(err)
+
+		_, err = service.AddComment(asUser(ctx, bannedID), &model.CommentInput{
+			AuthorID: bannedID,
+			PostID:   postID,
+			Text:     "Comment",
+		})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+
+		_, err = service.AddPost(asUser(ctx, bannedID), &model.PostInput{AuthorID: bannedID, Title: "Title", Text: "Text"})
+		assertions.NoError(err)
+		assertions.Len(getPendingKinds(), 8)
+	})
+
+	t.Run("Unsuccessful AddPost banned globally", func(t *testing.T) {
+		bannedID := uuid.New()
+		_, err := accessor.AddBan(ctx, &model.BanInput{UserID: bannedID}, adminID)
+		assertions.NoError(err)
+
+		_, err = service.AddPost(asUser(ctx, bannedID), &model.PostInput{AuthorID: bannedID, Title: "Title", Text: "Text"})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+		assertions.Len(getPendingKinds(), 8)
+	})
+
+	t.Run("Unsuccessful EditPost and EditComment banned", func(t *testing.T) {
+		bannedID := uuid.New()
+		bannedCtx := asUser(ctx, bannedID)
+		post, err := service.AddPost(bannedCtx, &model.PostInput{AuthorID: bannedID, Title: "Title", Text: "Text", CommentsEnabled: true})
+		assertions.NoError(err)
+		comment, err := service.AddComment(bannedCtx, &model.CommentInput{AuthorID: bannedID, PostID: 0, Text: "Comment"})
+		assertions.NoError(err)
+
+		postID := int64(0)
+		_, err = service.BanUser(moderatorCtx, &model.BanInput{UserID: bannedID, PostID: &postID})
+		assertions.NoError(err)
+
+		_, err = service.EditComment(bannedCtx, comment.ID, bannedID, "Edited")
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+
+		text := "Edited"
+		_, err = service.EditPost(bannedCtx, post.ID, bannedID, &model.PostEditInput{Text: &text})
+		assertions.NoError(err)
+
+		_, err = service.BanUser(moderatorCtx, &model.BanInput{UserID: bannedID})
+		assertions.NoError(err)
+
+		_, err = service.EditPost(bannedCtx, post.ID, bannedID, &model.PostEditInput{Text: &text})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+	})
+
+	t.Run("Unsuccessful BanUser of equal or higher role", func(t *testing.T) {
+		otherModeratorID := uuid.New()
+		_, err := accessor.AddUser(ctx, &model.UserInput{ID: &otherModeratorID, DisplayName: "Moderator"})
+		assertions.NoError(err)
+		_, err = accessor.UpdateUserRole(ctx, otherModeratorID, model.RoleModerator)
+		assertions.NoError(err)
+
+		_, err = service.BanUser(moderatorCtx, &model.BanInput{UserID: otherModeratorID})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+
+		_, err = service.BanUser(moderatorCtx, &model.BanInput{UserID: moderatorID})
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+
+		ban, err := service.BanUser(adminCtx, &model.BanInput{UserID: otherModeratorID})
+		assertions.NoError(err)
+		assertions.Equal(adminID, ban.ModeratorID)
+	})
+}
+
+func TestReplyDepthPolicy(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+
+	// addThread creates a post with a root comment and a reply to it and returns their IDs.
+	addThread := func(service *Service, maxReplyDepth *int32) (int64, int64, int64) {
+		post, err := service.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Title",
+			Text:            "Text",
+			CommentsEnabled: true,
+			MaxReplyDepth:   maxReplyDepth,
+		})
+		assertions.NoError(err)
+
+		root, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Root"})
+		assertions.NoError(err)
+
+		reply, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+		assertions.NoError(err)
+
+		return post.ID, root.ID, reply.ID
+	}
+
+	t.Run("Unsuccessful AddComment too deep in REJECT mode", func(t *testing.T) {
+		accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: 1, Mode: DepthOverflowReject}, PinPolicy{MaxPinned: 3})
+
+		postID, _, replyID := addThread(service, nil)
+
+		comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: postID, ParentID: &replyID, Text: "Too deep"})
+		assertions.Error(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Successful AddComment too deep in FLATTEN mode", func(t *testing.T) {
+		accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: 1, Mode: DepthOverflowFlatten}, PinPolicy{MaxPinned: 3})
+
+		postID, rootID, replyID := addThread(service, nil)
+
+		comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: postID, ParentID: &replyID, Text: "Flattened"})
+		assertions.NoError(err)
+		assertions.Equal(rootID, *comment.ParentID)
+	})
+
+	t.Run("Successful AddComment post override flattens to root", func(t *testing.T) {
+		accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth, Mode: DepthOverflowFlatten}, PinPolicy{MaxPinned: 3})
+
+		maxReplyDepth := int32(0)
+		postID, rootID, _ := addThread(service, &maxReplyDepth)
+
+		comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: postID, ParentID: &rootID, Text: "Flattened"})
+		assertions.NoError(err)
+		assertions.Nil(comment.ParentID)
+	})
+
+	t.Run("Successful AddComment post override allows deeper replies", func(t *testing.T) {
+		accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+		service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: 1, Mode: DepthOverflowReject}, PinPolicy{MaxPinned: 3})
+
+		maxReplyDepth := int32(5)
+		postID, _, replyID := addThread(service, &maxReplyDepth)
+
+		comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: postID, ParentID: &replyID, Text: "Deep"})
+		assertions.NoError(err)
+		assertions.Equal(replyID, *comment.ParentID)
+	})
+}
+
+func TestPinComments(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 1})
+
+	post, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+
+	commenterID := uuid.New()
+	first, err := service.AddComment(asUser(ctx, commenterID), &model.CommentInput{AuthorID: commenterID, PostID: post.ID, Text: "First"})
+	assertions.NoError(err)
+
+	second, err := service.AddComment(asUser(ctx, commenterID), &model.CommentInput{AuthorID: commenterID, PostID: post.ID, Text: "Second"})
+	assertions.NoError(err)
+
+	getLastEvent := func() events.Event {
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		event, err := envelopes[len(envelopes)-1].Decode()
+		assertions.NoError(err)
+		return event
+	}
+
+	t.Run("Successful PinComment", func(t *testing.T) {
+		comment, err := service.PinComment(ctx, first.ID, authorID)
+		assertions.NoError(err)
+		assertions.Equal(first.ID, comment.ID)
+		assertions.Equal(&events.CommentPinToggled{CommentID: first.ID, PostID: post.ID, Pinned: true}, getLastEvent())
+	})
+
+	t.Run("Unsuccessful PinComment over the limit", func(t *testing.T) {
+		_, err := service.PinComment(ctx, second.ID, authorID)
+		assertions.Error(err)
+		assertions.Equal(events.KindCommentPinToggled, getLastEvent().Kind())
+	})
+
+	t.Run("Successful UnpinComment", func(t *testing.T) {
+		_, err := service.UnpinComment(ctx, first.ID, authorID)
+		assertions.NoError(err)
+		assertions.Equal(&events.CommentPinToggled{CommentID: first.ID, PostID: post.ID, Pinned: false}, getLastEvent())
+
+		_, err = service.PinComment(ctx, second.ID, authorID)
+		assertions.NoError(err)
+	})
+}
+
+func TestPremoderatedCommentEvents(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+	commenterID := uuid.New()
+	commenterCtx := asUser(ctx, commenterID)
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	premoderated := model.ModerationModePremoderated
+	post, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true, ModerationMode: &premoderated})
+	assertions.NoError(err)
+
+	getEvents := func() []events.Event {
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		decoded := make([]events.Event, 0, len(envelopes))
+		for _, envelope := range envelopes {
+			event, err := envelope.Decode()
+			assertions.NoError(err)
+			decoded = append(decoded, event)
+		}
+		return decoded
+	}
+
+	var commentID int64
+
+	t.Run("Successful AddComment and EditComment without events while pending", func(t *testing.T) {
+		comment, err := service.AddComment(commenterCtx, &model.CommentInput{AuthorID: commenterID, PostID: post.ID, Text: "Secret"})
+		assertions.NoError(err)
+		assertions.Equal(model.CommentStatusPending, comment.Status)
+		commentID = comment.ID
+
+		_, err = service.EditComment(commenterCtx, comment.ID, commenterID, "Edited secret")
+		assertions.NoError(err)
+
+		assertions.Len(getEvents(), 1)
+	})
+
+	t.Run("Successful UpdateCommentStatus announces the text", func(t *testing.T) {
+		_, err := service.UpdateCommentStatus(ctx, commentID, authorID, model.CommentStatusApproved)
+		assertions.NoError(err)
+
+		text := "Edited secret"
+		recorded := getEvents()
+		assertions.Len(recorded, 2)
+		assertions.Equal(&events.CommentStatusChanged{
+			CommentID: commentID,
+			PostID:    post.ID,
+			AuthorID:  &commenterID,
+			Text:      &text,
+			Status:    model.CommentStatusApproved,
+		}, recorded[1])
+	})
+}
+
+func TestReactions(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	post, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+
+	reaction := &model.ReactionInput{TargetType: model.ReactionTargetPost, TargetID: post.ID, UserID: authorID, Kind: model.ReactionKindHeart}
+
+	getLastEvent := func() events.Event {
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		event, err := envelopes[len(envelopes)-1].Decode()
+		assertions.NoError(err)
+		return event
+	}
+
+	t.Run("Successful React", func(t *testing.T) {
+		counts, err := service.React(ctx, reaction)
+		assertions.NoError(err)
+		assertions.Equal([]*model.ReactionCount{{Kind: model.ReactionKindHeart, Count: 1}}, counts)
+		assertions.Equal(&events.ReactionToggled{
+			TargetType:   model.ReactionTargetPost,
+			TargetID:     post.ID,
+			UserID:       authorID,
+			ReactionKind: model.ReactionKindHeart,
+			Added:        true,
+		}, getLastEvent())
+	})
+
+	t.Run("Successful Unreact", func(t *testing.T) {
+		counts, err := service.Unreact(ctx, reaction)
+		assertions.NoError(err)
+		assertions.Empty(counts)
+		assertions.False(getLastEvent().(*events.ReactionToggled).Added)
+	})
+
+	t.Run("Unsuccessful React as another user", func(t *testing.T) {
+		_, err := service.React(asUser(ctx, uuid.New()), reaction)
+		assertions.ErrorIs(err, authorization.ErrForbidden)
+		assertions.False(getLastEvent().(*events.ReactionToggled).Added)
+	})
+}
+
+func TestGetPostComments(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	post, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+
+	commentIDs := make([]int64, 0, 4)
+	for _, text := range []string{"First", "Second", "Third", "Fourth"} {
+		comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: text})
+		assertions.NoError(err)
+		commentIDs = append(commentIDs, comment.ID)
+	}
+
+	_, err = service.PinComment(ctx, commentIDs[3], authorID)
+	assertions.NoError(err)
+	_, err = service.PinComment(ctx, commentIDs[1], authorID)
+	assertions.NoError(err)
+
+	getIDs := func(page *CommentsPage) []int64 {
+		ids := make([]int64, 0, len(page.Edges))
+		for _, edge := range page.Edges {
+			ids = append(ids, edge.Comment.ID)
+		}
+		return ids
+	}
+
+	t.Run("Successful GetPostComments pinned first", func(t *testing.T) {
+		page, err := service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, nil, nil)
+		assertions.NoError(err)
+		assertions.Equal([]int64{commentIDs[3], commentIDs[1], commentIDs[0], commentIDs[2]}, getIDs(page))
+		assertions.True(page.Edges[0].IsPinned)
+		assertions.False(page.Edges[2].IsPinned)
+		assertions.False(page.HasNextPage)
+	})
+
+	t.Run("Successful GetPostComments pages", func(t *testing.T) {
+		limit := int32(1)
+		page, err := service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, nil, &limit)
+		assertions.NoError(err)
+		assertions.Equal([]int64{commentIDs[3]}, getIDs(page))
+		assertions.True(page.HasNextPage)
+
+		limit = 2
+		page, err = service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, &page.Edges[0].Cursor, &limit)
+		assertions.NoError(err)
+		assertions.Equal([]int64{commentIDs[1], commentIDs[0]}, getIDs(page))
+		assertions.True(page.HasNextPage)
+
+		page, err = service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, &page.Edges[1].Cursor, &limit)
+		assertions.NoError(err)
+		assertions.Equal([]int64{commentIDs[2]}, getIDs(page))
+		assertions.False(page.HasNextPage)
+	})
+
+	t.Run("Unsuccessful GetPostComments negative limit", func(t *testing.T) {
+		limit := int32(-1)
+		_, err := service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, nil, &limit)
+		assertions.Error(err)
+	})
+
+	t.Run("Unsuccessful GetPostComments cursor of another order", func(t *testing.T) {
+		page, err := service.GetPostComments(ctx, post.ID, model.CommentsOrderOldest, nil, nil)
+		assertions.NoError(err)
+
+		_, err = service.GetPostComments(ctx, post.ID, model.CommentsOrderNewest, &page.Edges[2].Cursor, nil)
+		assertions.Error(err)
+	})
+}
+
+func TestPublishPosts(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+	draftStatus := model.PostStatusDraft
+	publishAt := time.Now().Add(time.Hour)
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	getPendingKinds := func() []events.Kind {
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		kinds := make([]events.Kind, 0, len(envelopes))
+		for _, envelope := range envelopes {
+			kinds = append(kinds, envelope.Kind)
+		}
+		return kinds
+	}
+
+	draft, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Draft", Text: "Text", CommentsEnabled: true, Status: &draftStatus})
+	assertions.NoError(err)
+
+	scheduled, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Scheduled", Text: "Text", CommentsEnabled: true, PublishAt: &publishAt})
+	assertions.NoError(err)
+
+	t.Run("Successful AddPost draft without events", func(t *testing.T) {
+		assertions.Empty(getPendingKinds())
+	})
+
+	t.Run("Successful changes of draft without events", func(t *testing.T) {
+		editedText := "Edited"
+		_, err := service.EditPost(ctx, draft.ID, authorID, &model.PostEditInput{Text: &editedText})
+		assertions.NoError(err)
+
+		_, err = service.UpdateCommentsEnabled(ctx, draft.ID, authorID, false)
+		assertions.NoError(err)
+
+		_, err = service.UpdateModerationMode(ctx, draft.ID, authorID, model.ModerationModePremoderated)
+		assertions.NoError(err)
+
+		_, err = service.DeletePost(ctx, draft.ID, authorID)
+		assertions.NoError(err)
+
+		_, err = service.RestorePost(ctx, draft.ID, authorID)
+		assertions.NoError(err)
+
+		assertions.Empty(getPendingKinds())
+	})
+
+	t.Run("Successful PublishPost", func(t *testing.T) {
+		post, err := service.PublishPost(ctx, draft.ID, authorID, nil)
+		assertions.NoError(err)
+		assertions.Equal(model.PostStatusPublished, post.Status)
+		assertions.Equal([]events.Kind{events.KindPostCreated, events.KindPostPublished}, getPendingKinds())
+	})
+
+	t.Run("Successful PublishDuePosts", func(t *testing.T) {
+		published, err := service.PublishDuePosts(ctx, time.Now())
+		assertions.NoError(err)
+		assertions.Equal(int64(0), published)
+
+		published, err = service.PublishDuePosts(ctx, publishAt)
+		assertions.NoError(err)
+		assertions.Equal(int64(1), published)
+		assertions.Equal([]events.Kind{events.KindPostCreated, events.KindPostPublished, events.KindPostCreated, events.KindPostPublished}, getPendingKinds())
+
+		envelopes, err := accessor.GetPendingEvents(ctx, 100)
+		assertions.NoError(err)
+
+		event, err := envelopes[3].Decode()
+		assertions.NoError(err)
+		assertions.Equal(scheduled.ID, event.(*events.PostPublished).PostID)
+		assertions.True(publishAt.Equal(event.(*events.PostPublished).PublishDate))
+	})
+}
+
+func TestRevisions(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := asUser(context.Background(), authorID)
+	editedText := "Edited"
+
+	accessor := inmemory.NewInMemoryAccessor(inmemory.NewInMemoryStorage())
+	service := NewService(accessor, DeletionPolicy{RestoreWindow: time.Hour}, ReplyDepthPolicy{MaxDepth: UnlimitedReplyDepth}, PinPolicy{MaxPinned: 3})
+
+	post, err := service.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.NoError(err)
+	post, err = service.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Text: &editedText})
+	assertions.NoError(err)
+
+	comment, err := service.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Text"})
+	assertions.NoError(err)
+	comment, err = service.EditComment(ctx, comment.ID, authorID, editedText)
+	assertions.NoError(err)
+
+	t.Run("Successful GetCommentRevisions", func(t *testing.T) {
+		revisions, err := service.GetCommentRevisions(ctx, comment, nil, nil)
+		assertions.NoError(err)
+		assertions.Len(revisions, 2)
+
+		revisionDiff, err := service.GetCommentRevisionDiff(ctx, comment, 1, 2)
+		assertions.NoError(err)
+		assertions.NotNil(revisionDiff)
+	})
+
+	t.Run("Successful GetCommentRevisions of deleted comment", func(t *testing.T) {
+		deleted, err := service.DeleteComment(ctx, comment.ID, authorID)
+		assertions.NoError(err)
+
+		revisions, err := service.GetCommentRevisions(ctx, deleted, nil, nil)
+		assertions.NoError(err)
+		assertions.Empty(revisions)
+
+		revisionDiff, err := service.GetCommentRevisionDiff(ctx, deleted, 1, 2)
+		assertions.NoError(err)
+		assertions.Nil(revisionDiff)
+	})
+
+	t.Run("Successful GetPostRevisions of deleted post", func(t *testing.T) {
+		revisions, err := service.GetPostRevisions(ctx, post, nil, nil)
+		assertions.NoError(err)
+		assertions.Len(revisions, 2)
+
+		deleted, err := service.DeletePost(ctx, post.ID, authorID)
+		assertions.NoError(err)
+
+		revisions, err = service.GetPostRevisions(ctx, deleted, nil, nil)
+		assertions.NoError(err)
+		assertions.Empty(revisions)
+
+		revisionDiff, err := service.GetPostRevisionDiff(ctx, deleted, 1, 2)
+		assertions.NoError(err)
+		assertions.Nil(revisionDiff)
+	})
+}
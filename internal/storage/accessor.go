@@ -2,20 +2,101 @@ package storage
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
 	"github.com/google/uuid"
 )
 
 type Accessor interface {
 	AddPost(ctx context.Context, newPost *model.PostInput) (*model.Post, error)
-	GetPost(ctx context.Context, postID int64) (*model.Post, error)
-	GetAllPosts(ctx context.Context) ([]*model.Post, error)
-	UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, newCommentsEnabled bool) (*model.Post, error)
+	GetPost(ctx context.Context, postID int64, viewerID uuid.UUID) (*model.Post, error)
+	GetAllPosts(ctx context.Context, viewerID uuid.UUID) ([]*model.Post, error)
+	GetPostMaxReplyDepth(ctx context.Context, postID int64) (*int32, error)
+	GetPosts(ctx context.Context, filter *model.PostsFilter, viewerID uuid.UUID, after *cursor.PostCursor, limit *int32) ([]*model.Post, error)
+	UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, privileged bool, newCommentsEnabled bool) (*model.Post, error)
+	UpdateModerationMode(ctx context.Context, postID int64, authorID uuid.UUID, newModerationMode model.ModerationMode) (*model.Post, error)
+	GetPostsTags(ctx context.Context, postIDs []int64) (map[int64][]string, error)
+	EditPost(ctx context.Context, postID int64, editorID uuid.UUID, changes *model.PostEditInput) (*model.Post, *model.Revision, error)
+	GetPostRevisions(ctx context.Context, postID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error)
+	GetPostRevision(ctx context.Context, postID int64, number int32) (*model.Revision, error)
+	GetPostsEditDates(ctx context.Context, postIDs []int64) (map[int64]time.Time, error)
+	DeletePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool) (*model.Post, error)
+	RestorePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Post, error)
+	PublishPost(ctx context.Context, postID int64, authorID uuid.UUID, publishAt *time.Time) (*model.Post, error)
+	PublishDuePosts(ctx context.Context, now time.Time, limit int32) ([]*model.Post, error)
+	IteratePosts(ctx context.Context, filter *ExportFilter) iter.Seq2[*ExportPost, error]
+	IteratePostComments(ctx context.Context, postID int64) iter.Seq2[*TreeComment, error]
+	GetImportedIDs(ctx context.Context, source string, kind ImportKind, sourceIDs []int64) (map[int64]int64, error)
+	ImportPosts(ctx context.Context, source string, posts []*ExportPost) (int64, error)
+	ImportComments(ctx context.Context, source string, comments []*TreeComment) (int64, error)
+	CountOtherRecords(ctx context.Context) (*OtherRecords, error)
 
 	AddComment(ctx context.Context, newComment *model.CommentInput) (*model.Comment, error)
+	GetComment(ctx context.Context, commentID int64) (*model.Comment, error)
 	GetCommentPath(ctx context.Context, postID int64, parentID *int64) (string, error)
-	GetCommentsLevel(ctx context.Context, postID int64, path string) ([]*model.Comment, error)
+	GetCommentsLevel(ctx context.Context, postID int64, path string, order model.CommentsOrder, after *cursor.Cursor, limit *int32) ([]*model.Comment, error)
+	GetCommentsCount(ctx context.Context, postID int64, path string) (int32, error)
+	GetCommentsByAuthor(ctx context.Context, authorID uuid.UUID, after *cursor.AuthorCursor, limit *int32) ([]*model.Comment, error)
+	GetCommentsByAuthorCount(ctx context.Context, authorID uuid.UUID) (int32, error)
+	GetRecentComments(ctx context.Context, postID int64, limit int32) ([]*model.Comment, error)
+	GetPendingComments(ctx context.Context, postID int64, viewerID uuid.UUID, privileged bool) ([]*model.Comment, error)
+	UpdateCommentStatus(ctx context.Context, commentID int64, authorID uuid.UUID, newStatus model.CommentStatus) (*model.Comment, error)
+	EditComment(ctx context.Context, commentID int64, editorID uuid.UUID, text string) (*model.Comment, *model.Revision, error)
+	GetCommentRevisions(ctx context.Context, commentID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error)
+	GetCommentRevision(ctx context.Context, commentID int64, number int32) (*model.Revision, error)
+	GetCommentsEditDates(ctx context.Context, commentIDs []int64) (map[int64]time.Time, error)
+	DeleteComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool) (*model.Comment, error)
+	RestoreComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Comment, error)
+	PinComment(ctx context.Context, commentID int64, authorID uuid.UUID, maxPinned int32) (*model.Comment, error)
+	UnpinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error)
+	GetPinnedComments(ctx context.Context, postID int64) ([]*model.Comment, error)
+	GetCommentsPinned(ctx context.Context, commentIDs []int64) (map[int64]bool, error)
+
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	SearchPosts(ctx context.Context, query string, after *cursor.SearchCursor, limit *int32) ([]*model.PostSearchEdge, error)
+	SearchComments(ctx context.Context, postID int64, query string, after *cursor.SearchCursor, limit *int32) ([]*model.CommentSearchEdge, error)
+
+	AddUser(ctx context.Context, newUser *model.UserInput) (*model.User, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error)
+	GetUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*model.User, error)
+	UpdateUser(ctx context.Context, userID uuid.UUID, changes *model.UserUpdateInput) (*model.User, error)
+	UpdateUserRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error)
+
+	GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, after *cursor.NotificationCursor, limit *int32) ([]*model.Notification, error)
+	GetCommentNotifications(ctx context.Context, commentID int64) ([]*model.Notification, error)
+	GetPostNotifications(ctx context.Context, postID int64) ([]*model.Notification, error)
+	GetRevisionNotifications(ctx context.Context, postID int64, commentID *int64, revisionNumber int32) ([]*model.Notification, error)
+	MarkNotificationsRead(ctx context.Context, userID uuid.UUID, notificationIDs []int64) (int32, error)
+
+	AddWebhookSubscription(ctx context.Context, newSubscription *model.WebhookSubscriptionInput) (*model.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) error
+	GetWebhookSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]*model.WebhookSubscription, error)
+	GetWebhookDeliveries(ctx context.Context, subscriptionID int64, ownerID uuid.UUID, status *model.WebhookDeliveryStatus, after *cursor.DeliveryCursor, limit *int32) ([]*model.WebhookDelivery, error)
+	AddWebhookDeliveries(ctx context.Context, envelope *events.Envelope) (int32, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int32) ([]*webhooks.PendingDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+
+	AddBan(ctx context.Context, newBan *model.BanInput, moderatorID uuid.UUID) (*model.Ban, error)
+	DeleteBan(ctx context.Context, userID uuid.UUID, postID *int64) error
+	GetBans(ctx context.Context, userID *uuid.UUID, postID *int64, activeOnly bool) ([]*model.Ban, error)
+	IsBanned(ctx context.Context, userID uuid.UUID, postID *int64) (bool, error)
+
+	AddReaction(ctx context.Context, reaction *model.ReactionInput) error
+	DeleteReaction(ctx context.Context, reaction *model.ReactionInput) error
+	GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64) (map[int64][]*model.ReactionCount, error)
+	GetViewerReactions(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64, viewerID uuid.UUID) (map[int64][]model.ReactionKind, error)
+
+	WithTransaction(ctx context.Context, fn func(accessor Accessor) error) error
+	AddEvent(ctx context.Context, envelope *events.Envelope) error
+	GetPendingEvents(ctx context.Context, limit int32) ([]*events.Envelope, error)
+	ClaimPendingEvents(ctx context.Context, ownerID uuid.UUID, now time.Time, leaseUntil time.Time, limit int32) ([]*events.Envelope, error)
+	MarkEventsDispatched(ctx context.Context, eventIDs []int64) error
 
 	CloseStorage()
 }
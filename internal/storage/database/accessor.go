@@ -9,27 +9,87 @@ import (
 	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
 	"github.com/google/uuid"
 )
 
 type DatabaseAccessor struct {
-	storage *sql.DB
+	database       *sql.DB
+	storage        queryExecutor
+	tx             *sql.Tx
+	searchLanguage string
 }
 
-func NewDatabaseAccessor(database *sql.DB) *DatabaseAccessor {
+func NewDatabaseAccessor(database *sql.DB, searchLanguage string) *DatabaseAccessor {
 	return &DatabaseAccessor{
-		storage: database,
+		database:       database,
+		storage:        database,
+		searchLanguage: searchLanguage,
 	}
 }
 
+// WithTransaction runs fn with an accessor bound to a single transaction, so all changes made through it
+// are committed together or not at all. Transactions opened by the accessor methods join this one.
+func (databaseAccessor *DatabaseAccessor) WithTransaction(ctx context.Context, fn func(accessor storage.Accessor) error) error {
+	if databaseAccessor.tx != nil {
+		return fn(databaseAccessor)
+	}
+
+	tx, err := databaseAccessor.database.BeginTx(ctx, nil)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	err = fn(&DatabaseAccessor{
+		database:       databaseAccessor.database,
+		storage:        tx,
+		tx:             tx,
+		searchLanguage: databaseAccessor.searchLanguage,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// beginTx opens a transaction or joins the one the accessor is bound to.
+func (databaseAccessor *DatabaseAccessor) beginTx(ctx context.Context) (transaction, error) {
+	if databaseAccessor.tx != nil {
+		return nestedTransaction{databaseAccessor.tx}, nil
+	}
+
+	tx, err := databaseAccessor.database.BeginTx(ctx, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
 func (databaseAccessor *DatabaseAccessor) AddPost(ctx context.Context, newPost *model.PostInput) (*model.Post, error) {
+	if err := helpers.CheckMaxReplyDepth(newPost.MaxReplyDepth); err != nil {
+		return nil, err
+	}
+
+	moderationMode := helpers.GetModerationMode(newPost.CommentsEnabled, newPost.ModerationMode)
 	post := &model.Post{
 		AuthorID:        newPost.AuthorID,
 		Title:           newPost.Title,
 		Text:            newPost.Text,
-		CommentsEnabled: newPost.CommentsEnabled,
+		CommentsEnabled: moderationMode != model.ModerationModeClosed,
+		ModerationMode:  moderationMode,
 		CreateDate:      time.Now(),
 	}
+	post.Status, post.PublishAt = helpers.GetPostPublication(newPost.Status, newPost.PublishAt, post.CreateDate)
 
 	select {
 	case <-ctx.Done():
@@ -38,27 +98,85 @@ func (databaseAccessor *DatabaseAccessor) AddPost(ctx context.Context, newPost *
 	default:
 	}
 
-	queryInsertPost := `INSERT INTO posts (author_id, title, text, create_date, comments_enabled)
-						VALUES ($1, $2, $3, $4, $5)
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	queryInsertPost := `INSERT INTO posts (author_id, title, text, create_date, comments_enabled, moderation_mode, max_reply_depth, status, publish_at, search_language)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 						RETURNING post_id`
 
-	err := databaseAccessor.storage.QueryRowContext(ctx, queryInsertPost,
+	err = tx.QueryRowContext(ctx, queryInsertPost,
 		post.AuthorID,
 		post.Title,
 		post.Text,
 		post.CreateDate,
-		post.CommentsEnabled).Scan(&post.ID)
+		post.CommentsEnabled,
+		post.ModerationMode,
+		newPost.MaxReplyDepth,
+		post.Status,
+		post.PublishAt,
+		databaseAccessor.searchLanguage).Scan(&post.ID)
 
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err = addPostRevision(ctx, tx, post, post.AuthorID, post.CreateDate); err != nil {
+		return nil, err
+	}
+
+	refs := textrefs.Extract(post.Title, post.Text)
+	if err = addPostTags(ctx, tx, post.ID, refs.Tags); err != nil {
+		return nil, err
+	}
+
+	if len(refs.Mentions) > 0 {
+		if err = addMentions(ctx, tx, post.ID, nil, refs.Mentions); err != nil {
+			return nil, err
+		}
+
+		if post.Status == model.PostStatusPublished {
+			if err = addMentionNotifications(ctx, tx, post.ID, nil, post.AuthorID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return post, nil
 }
 
-func (databaseAccessor *DatabaseAccessor) GetPost(ctx context.Context, postID int64) (*model.Post, error) {
-	var post model.Post
+// GetPost returns the post if the viewer may see it: drafts are visible only to their authors.
+func (databaseAccessor *DatabaseAccessor) GetPost(ctx context.Context, postID int64, viewerID uuid.UUID) (*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPost := `SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL AND (status = $2 OR author_id = $3)`
 
+	post, err := scanPost(databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID, model.PostStatusPublished, viewerID))
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	return post, err
+}
+
+// GetPostMaxReplyDepth returns the maximum reply depth set for the post or nil if the post uses the global one.
+func (databaseAccessor *DatabaseAccessor) GetPostMaxReplyDepth(ctx context.Context, postID int64) (*int32, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -66,25 +184,23 @@ func (databaseAccessor *DatabaseAccessor) GetPost(ctx context.Context, postID in
 	default:
 	}
 
-	querySelectPost := `SELECT post_id, author_id, title, text, create_date, comments_enabled
+	querySelectPost := `SELECT max_reply_depth
 						FROM posts
-						WHERE post_id = $1`
+						WHERE post_id = $1 AND deleted_at IS NULL`
 
-	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&post.ID,
-		&post.AuthorID,
-		&post.Title,
-		&post.Text,
-		&post.CreateDate,
-		&post.CommentsEnabled)
+	var maxReplyDepth *int32
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&maxReplyDepth)
 
-	if err != nil {
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
 		return nil, err
 	}
 
-	return &post, nil
+	return maxReplyDepth, nil
 }
 
-func (databaseAccessor *DatabaseAccessor) GetAllPosts(ctx context.Context) ([]*model.Post, error) {
+func (databaseAccessor *DatabaseAccessor) GetAllPosts(ctx context.Context, viewerID uuid.UUID) ([]*model.Post, error) {
 	posts := make([]*model.Post, 0)
 
 	select {
@@ -94,10 +210,11 @@ func (databaseAccessor *DatabaseAccessor) GetAllPosts(ctx context.Context) ([]*m
 	default:
 	}
 
-	querySelectPosts := `SELECT post_id, author_id, title, text, create_date, comments_enabled
-						FROM posts`
+	querySelectPosts := `SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE deleted_at IS NULL AND (status = $1 OR author_id = $2)`
 
-	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPosts)
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPosts, model.PostStatusPublished, viewerID)
 
 	if err != nil {
 		return nil, err
@@ -105,25 +222,98 @@ func (databaseAccessor *DatabaseAccessor) GetAllPosts(ctx context.Context) ([]*m
 
 	defer rows.Close()
 	for rows.Next() {
-		var post model.Post
-		if err = rows.Scan(&post.ID,
-			&post.AuthorID,
-			&post.Title,
-			&post.Text,
-			&post.CreateDate,
-			&post.CommentsEnabled); err != nil {
+		post, err := scanPost(rows)
+		if err != nil {
 			return nil, err
 		}
 
-		posts = append(posts, &post)
+		posts = append(posts, post)
 	}
 
 	return posts, nil
 }
 
-func (databaseAccessor *DatabaseAccessor) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, newCommentsEnabled bool) (*model.Post, error) {
+func (databaseAccessor *DatabaseAccessor) GetPosts(ctx context.Context, filter *model.PostsFilter, viewerID uuid.UUID, after *cursor.PostCursor, limit *int32) ([]*model.Post, error) {
+	posts := make([]*model.Post, 0)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPosts, args := getPostsQuery(filter, viewerID, after, limit)
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPosts, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts, nil
+}
+
+// getPostsQuery builds a query for the filtered list of posts ordered from the newest to the oldest.
+func getPostsQuery(filter *model.PostsFilter, viewerID uuid.UUID, after *cursor.PostCursor, limit *int32) (string, queryArgs) {
+	args := make(queryArgs, 0)
+	conditions := []string{`deleted_at IS NULL`,
+		`(status = ` + args.add(model.PostStatusPublished) + ` OR author_id = ` + args.add(viewerID) + `)`}
+
+	if filter != nil {
+		if filter.AuthorID != nil {
+			conditions = append(conditions, `author_id = `+args.add(*filter.AuthorID))
+		}
+
+		if filter.CreatedAfter != nil {
+			conditions = append(conditions, `create_date > `+args.add(*filter.CreatedAfter))
+		}
+
+		if filter.CreatedBefore != nil {
+			conditions = append(conditions, `create_date < `+args.add(*filter.CreatedBefore))
+		}
+
+		if filter.CommentsEnabled != nil {
+			conditions = append(conditions, `comments_enabled = `+args.add(*filter.CommentsEnabled))
+		}
+
+		if filter.Tag != nil {
+			conditions = append(conditions, `post_id IN (SELECT post_id FROM post_tags WHERE tag = `+args.add(textrefs.NormalizeTag(*filter.Tag))+`)`)
+		}
+	}
+
+	if after != nil {
+		conditions = append(conditions, `(create_date, post_id) < (`+args.add(time.Unix(0, after.SortKey))+`, `+args.add(after.PostID)+`)`)
+	}
+
+	querySelectPosts := `SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE ` + strings.Join(conditions, " AND ")
+
+	querySelectPosts += `
+						ORDER BY create_date DESC, post_id DESC`
+
+	if limit != nil {
+		querySelectPosts += `
+						LIMIT ` + args.add(*limit)
+	}
+
+	return querySelectPosts, args
+}
+
+func (databaseAccessor *DatabaseAccessor) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, privileged bool, newCommentsEnabled bool) (*model.Post, error) {
 	var dbPostId int64
 	var dbAuthorID uuid.UUID
+	var moderationMode model.ModerationMode
 
 	select {
 	case <-ctx.Done():
@@ -132,55 +322,97 @@ func (databaseAccessor *DatabaseAccessor) UpdateCommentsEnabled(ctx context.Cont
 	default:
 	}
 
-	querySelectPost := `SELECT post_id, author_id
+	querySelectPost := `SELECT post_id, author_id, moderation_mode
 						FROM posts
-						WHERE post_id = $1 AND author_id = $2`
+						WHERE post_id = $1 AND (author_id = $2 OR $3) AND deleted_at IS NULL`
 
-	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID, authorID).Scan(&dbPostId, &dbAuthorID)
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID, authorID, privileged).Scan(&dbPostId, &dbAuthorID, &moderationMode)
 
-	if err != nil {
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
 		return nil, err
 	}
 
 	if dbPostId != postID {
-		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
 	}
 
-	if dbAuthorID != authorID {
+	if dbAuthorID != authorID && !privileged {
 		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
 	}
 
-	var post model.Post
-	queryUpdatePost := `UPDATE posts SET comments_enabled = $1
-						WHERE post_id = $2
-						RETURNING post_id, author_id, title, text, create_date, comments_enabled`
-	err = databaseAccessor.storage.QueryRowContext(ctx, queryUpdatePost, postID, newCommentsEnabled).Scan(&post.ID,
-		&post.AuthorID,
-		&post.Title,
-		&post.Text,
-		&post.CreateDate,
-		&post.CommentsEnabled)
+	return databaseAccessor.setModerationMode(ctx, postID, helpers.SwitchCommentsEnabled(moderationMode, newCommentsEnabled))
+}
 
-	if err != nil {
+func (databaseAccessor *DatabaseAccessor) UpdateModerationMode(ctx context.Context, postID int64, authorID uuid.UUID, newModerationMode model.ModerationMode) (*model.Post, error) {
+	var dbPostId int64
+	var dbAuthorID uuid.UUID
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPost := `SELECT post_id, author_id
+						FROM posts
+						WHERE post_id = $1 AND author_id = $2 AND deleted_at IS NULL`
+
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID, authorID).Scan(&dbPostId, &dbAuthorID)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
 		return nil, err
 	}
 
-	return &post, nil
+	if dbPostId != postID {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if dbAuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	return databaseAccessor.setModerationMode(ctx, postID, newModerationMode)
+}
+
+func (databaseAccessor *DatabaseAccessor) setModerationMode(ctx context.Context, postID int64, newModerationMode model.ModerationMode) (*model.Post, error) {
+	queryUpdatePost := `UPDATE posts SET comments_enabled = $1, moderation_mode = $2
+						WHERE post_id = $3
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+	return scanPost(databaseAccessor.storage.QueryRowContext(ctx, queryUpdatePost,
+		newModerationMode != model.ModerationModeClosed,
+		newModerationMode,
+		postID))
 }
 
 func (databaseAccessor *DatabaseAccessor) AddComment(ctx context.Context, newComment *model.CommentInput) (*model.Comment, error) {
-	var commentsEnabled bool
+	var moderationMode model.ModerationMode
+	var status model.PostStatus
+
+	if err := helpers.CheckCommentText(newComment.Text); err != nil {
+		return nil, err
+	}
 
-	querySelectPost := `SELECT comments_enabled
+	querySelectPost := `SELECT moderation_mode, status
 						FROM posts
-						WHERE post_id = $1`
-	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, newComment.PostID).Scan(&commentsEnabled)
+						WHERE post_id = $1 AND deleted_at IS NULL`
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, newComment.PostID).Scan(&moderationMode, &status)
 
-	if err != nil {
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(newComment.PostID, 10) + " was not found")
+	} else if err != nil {
 		return nil, err
 	}
 
-	if !commentsEnabled {
+	if status != model.PostStatusPublished {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(newComment.PostID, 10) + " is not published.")
+	}
+
+	if moderationMode == model.ModerationModeClosed {
 		return nil, errors.New("Comments on post " + strconv.FormatInt(newComment.PostID, 10) + " are disabled.")
 	}
 
@@ -190,6 +422,7 @@ func (databaseAccessor *DatabaseAccessor) AddComment(ctx context.Context, newCom
 		ParentID:   newComment.ParentID,
 		Text:       newComment.Text,
 		CreateDate: time.Now(),
+		Status:     helpers.GetNewCommentStatus(moderationMode),
 	}
 
 	select {
@@ -199,55 +432,144 @@ func (databaseAccessor *DatabaseAccessor) AddComment(ctx context.Context, newCom
 	default:
 	}
 
+	// Pending and rejected comments are hidden from other users, so only approved comments of the post accept replies.
 	querySelectComment := `SELECT path, replies_level
 							FROM comments
-							WHERE comment_id = $1`
+							WHERE comment_id = $1 AND post_id = $2 AND status = $3 AND deleted_at IS NULL`
 
 	var parentPath string
 	var parentRepliesLevel int
-	err = databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, newComment.ParentID).Scan(&parentPath, &parentRepliesLevel)
+	err = databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, newComment.ParentID, newComment.PostID, model.CommentStatusApproved).
+		Scan(&parentPath, &parentRepliesLevel)
 
-	queryInsertComment := `INSERT INTO comments (author_id, post_id, parent_id, text, create_date, path, replies_level)
-							VALUES ($1, $2, $3, $4, $5, $6, $7)
-							RETURNING comment_id`
+	var path string
+	var repliesLevel int
+	switch err {
+	case sql.ErrNoRows:
+		if comment.ParentID != nil {
+			return nil, storage.NotFound("Parent comment with ID " + strconv.FormatInt(*comment.ParentID, 10) + " was not found")
+		}
+		path = strconv.FormatInt(comment.PostID, 10)
+	case nil:
+		path = strings.Join([]string{parentPath, strconv.FormatInt(*comment.ParentID, 10)}, ".")
+		repliesLevel = parentRepliesLevel + 1
+	default:
+		return nil, err
+	}
 
-	if err == sql.ErrNoRows {
-		err = databaseAccessor.storage.QueryRowContext(ctx, queryInsertComment,
-			comment.AuthorID,
-			comment.PostID,
-			comment.ParentID,
-			comment.Text,
-			comment.CreateDate,
-			comment.PostID,
-			0).Scan(&comment.ID)
-	} else if err == nil {
-
-		err = databaseAccessor.storage.QueryRowContext(ctx, queryInsertComment,
-			comment.AuthorID,
-			comment.PostID,
-			comment.ParentID,
-			comment.Text,
-			comment.CreateDate,
-			strings.Join([]string{parentPath, strconv.FormatInt(*comment.ParentID, 10)}, "."),
-			parentRepliesLevel+1).Scan(&comment.ID)
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
 	}
 
+	defer tx.Rollback()
+
+	queryInsertComment := `INSERT INTO comments (author_id, post_id, parent_id, text, create_date, path, replies_level, status, search_language)
+							VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+							RETURNING comment_id`
+
+	err = tx.QueryRowContext(ctx, queryInsertComment,
+		comment.AuthorID,
+		comment.PostID,
+		comment.ParentID,
+		comment.Text,
+		comment.CreateDate,
+		path,
+		repliesLevel,
+		comment.Status,
+		databaseAccessor.searchLanguage).Scan(&comment.ID)
+
 	if err != nil {
 		return nil, err
 	}
 
+	if _, err = addCommentRevision(ctx, tx, comment, comment.AuthorID, comment.CreateDate); err != nil {
+		return nil, err
+	}
+
+	mentions := textrefs.Extract(comment.Text).Mentions
+	if err = addMentions(ctx, tx, comment.PostID, &comment.ID, mentions); err != nil {
+		return nil, err
+	}
+
+	if comment.Status == model.CommentStatusApproved {
+		if err = changeCommentCounters(ctx, tx, comment.PostID, comment.ParentID, 1); err != nil {
+			return nil, err
+		}
+
+		if err = addReplyNotification(ctx, tx, comment); err != nil {
+			return nil, err
+		}
+
+		if len(mentions) > 0 {
+			if err = addMentionNotifications(ctx, tx, comment.PostID, &comment.ID, comment.AuthorID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
 	return comment, nil
 }
 
+// changeCommentCounters shifts the denormalized counters of the post and of the parent comment by delta.
+// Counters include only approved comments, so they must be changed whenever a comment becomes visible or hidden.
+func changeCommentCounters(ctx context.Context, tx queryExecutor, postID int64, parentID *int64, delta int32) error {
+	if parentID == nil {
+		queryUpdatePost := `UPDATE posts SET comment_count = comment_count + $1, root_comment_count = root_comment_count + $1
+							WHERE post_id = $2`
+		_, err := tx.ExecContext(ctx, queryUpdatePost, delta, postID)
+		return err
+	}
+
+	queryUpdatePost := `UPDATE posts SET comment_count = comment_count + $1
+						WHERE post_id = $2`
+	if _, err := tx.ExecContext(ctx, queryUpdatePost, delta, postID); err != nil {
+		return err
+	}
+
+	queryUpdateComment := `UPDATE comments SET reply_count = reply_count + $1
+							WHERE comment_id = $2`
+	_, err := tx.ExecContext(ctx, queryUpdateComment, delta, *parentID)
+	return err
+}
+
+// GetComment returns the comment with any status unless it is deleted.
+func (databaseAccessor *DatabaseAccessor) GetComment(ctx context.Context, commentID int64) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectComment := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+							FROM comments
+							WHERE comment_id = $1 AND deleted_at IS NULL`
+
+	comment, err := scanComment(databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, commentID))
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	return comment, err
+}
+
 func (databaseAccessor *DatabaseAccessor) GetCommentPath(ctx context.Context, postID int64, parentID *int64) (string, error) {
 	var commentsEnabled bool
 
 	querySelectPost := `SELECT post_id
 						FROM posts
-						WHERE post_id = $1`
+						WHERE post_id = $1 AND deleted_at IS NULL`
 	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&commentsEnabled)
 
-	if err != nil {
+	if err == sql.ErrNoRows {
+		return "", storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
 		return "", err
 	}
 
@@ -260,7 +582,7 @@ func (databaseAccessor *DatabaseAccessor) GetCommentPath(ctx context.Context, po
 
 	querySelectComment := `SELECT path
 							FROM comments
-							WHERE comment_id = $1`
+							WHERE comment_id = $1 AND deleted_at IS NULL`
 
 	var parentPath string
 	err = databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, parentID).Scan(&parentPath)
@@ -269,7 +591,7 @@ func (databaseAccessor *DatabaseAccessor) GetCommentPath(ctx context.Context, po
 	switch err {
 	case sql.ErrNoRows:
 		if parentID != nil {
-			return "", errors.New("Parent comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
+			return "", storage.NotFound("Parent comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
 		}
 		path = strconv.FormatInt(postID, 10)
 	case nil:
@@ -281,12 +603,12 @@ func (databaseAccessor *DatabaseAccessor) GetCommentPath(ctx context.Context, po
 	return path, nil
 }
 
-func (databaseAccessor *DatabaseAccessor) GetCommentsLevel(ctx context.Context, postID int64, path string) ([]*model.Comment, error) {
+func (databaseAccessor *DatabaseAccessor) GetCommentsLevel(ctx context.Context, postID int64, path string, order model.CommentsOrder, after *cursor.Cursor, limit *int32) ([]*model.Comment, error) {
 	var commentsEnabled bool
 
 	querySelectPost := `SELECT post_id
 						FROM posts
-						WHERE post_id = $1`
+						WHERE post_id = $1 AND deleted_at IS NULL`
 	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&commentsEnabled)
 
 	if err != nil {
@@ -302,11 +624,197 @@ func (databaseAccessor *DatabaseAccessor) GetCommentsLevel(ctx context.Context,
 
 	comments := make([]*model.Comment, 0)
 
-	querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date
+	querySelectComments, args := getCommentsLevelQuery(path, order, after, limit)
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var deletedAt *time.Time
+		comment, err := scanComment(rows, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if deletedAt != nil {
+			comment.DeletedAt = deletedAt
+			comment = helpers.NewCommentPlaceholder(comment)
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// getCommentsLevelQuery builds a keyset pagination query: the cursor position is compared with
+// the same columns the level is ordered by, so pages stay stable while new comments are added.
+func getCommentsLevelQuery(path string, order model.CommentsOrder, after *cursor.Cursor, limit *int32) (string, queryArgs) {
+	args := make(queryArgs, 0)
+	addArg := args.add
+
+	var querySelectComments string
+	switch order {
+	case model.CommentsOrderTop:
+		querySelectComments = `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
+							FROM (
+								SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at,
+									(SELECT COUNT(*) FROM reactions WHERE target_type = ` + addArg(model.ReactionTargetComment) + ` AND target_id = comment_id) AS reactions_count
+								FROM comments
+								WHERE path = ` + addArg(path) + ` AND pinned_at IS NULL AND ` + getVisibleCommentCondition(addArg(model.CommentStatusApproved)) + `
+							) AS level`
+		if after != nil {
+			sortKey, commentID := addArg(after.SortKey), addArg(after.CommentID)
+			querySelectComments += `
+							WHERE reactions_count < ` + sortKey + ` OR (reactions_count = ` + sortKey + ` AND comment_id > ` + commentID + `)`
+		}
+		querySelectComments += `
+							ORDER BY reactions_count DESC, comment_id`
+
+	case model.CommentsOrderNewest:
+		querySelectComments = `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
+							FROM comments
+							WHERE path = ` + addArg(path) + ` AND pinned_at IS NULL AND ` + getVisibleCommentCondition(addArg(model.CommentStatusApproved))
+		if after != nil {
+			querySelectComments += ` AND (create_date, comment_id) < (` + addArg(time.Unix(0, after.SortKey)) + `, ` + addArg(after.CommentID) + `)`
+		}
+		querySelectComments += `
+							ORDER BY create_date DESC, comment_id DESC`
+
+	default:
+		querySelectComments = `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
+							FROM comments
+							WHERE path = ` + addArg(path) + ` AND pinned_at IS NULL AND ` + getVisibleCommentCondition(addArg(model.CommentStatusApproved))
+		if after != nil {
+			querySelectComments += ` AND (create_date, comment_id) > (` + addArg(time.Unix(0, after.SortKey)) + `, ` + addArg(after.CommentID) + `)`
+		}
+		querySelectComments += `
+							ORDER BY create_date, comment_id`
+	}
+
+	if limit != nil {
+		querySelectComments += `
+							LIMIT ` + addArg(*limit)
+	}
+
+	return querySelectComments, args
+}
+
+// getVisibleCommentCondition selects approved comments of a level. Deleted comments stay on the level
+// while any of their replies, direct or nested, is visible, so they can be shown as placeholders.
+func getVisibleCommentCondition(status string) string {
+	return `status = ` + status + ` AND (deleted_at IS NULL OR EXISTS (
+								SELECT 1
+								FROM comments AS replies
+								WHERE replies.path <@ (comments.path || comments.comment_id::text) AND replies.status = ` + status + ` AND replies.deleted_at IS NULL
+							))`
+}
+
+func (databaseAccessor *DatabaseAccessor) GetCommentsByAuthor(ctx context.Context, authorID uuid.UUID, after *cursor.AuthorCursor, limit *int32) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	// Deleting a post keeps its comments, so comments of deleted posts are excluded by the join.
+	args := queryArgs{authorID, model.CommentStatusApproved}
+	querySelectComments := `SELECT comments.comment_id, comments.author_id, comments.post_id, comments.parent_id, comments.text,
+								comments.create_date, comments.status, comments.reply_count
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.author_id = $1 AND comments.status = $2 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL`
+	if after != nil {
+		querySelectComments += ` AND (comments.create_date, comments.comment_id) < (` + args.add(time.Unix(0, after.SortKey)) + `, ` + args.add(after.CommentID) + `)`
+	}
+	querySelectComments += `
+							ORDER BY comments.create_date DESC, comments.comment_id DESC`
+	if limit != nil {
+		querySelectComments += `
+							LIMIT ` + args.add(*limit)
+	}
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*model.Comment, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetCommentsByAuthorCount(ctx context.Context, authorID uuid.UUID) (int32, error) {
+	var count int32
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	querySelectCount := `SELECT COUNT(*)
 							FROM comments
-							WHERE path = $1`
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.author_id = $1 AND comments.status = $2 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL`
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectCount, authorID, model.CommentStatusApproved).Scan(&count)
+
+	return count, err
+}
 
-	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, path)
+func (databaseAccessor *DatabaseAccessor) GetPendingComments(ctx context.Context, postID int64, viewerID uuid.UUID, privileged bool) ([]*model.Comment, error) {
+	var postAuthorID uuid.UUID
+
+	querySelectPost := `SELECT author_id
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL`
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&postAuthorID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comments := make([]*model.Comment, 0)
+
+	var rows *sql.Rows
+	if privileged || postAuthorID == viewerID {
+		querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+								FROM comments
+								WHERE post_id = $1 AND status = $2 AND deleted_at IS NULL
+								ORDER BY comment_id`
+
+		rows, err = databaseAccessor.storage.QueryContext(ctx, querySelectComments, postID, model.CommentStatusPending)
+	} else {
+		querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+								FROM comments
+								WHERE post_id = $1 AND status = $2 AND author_id = $3 AND deleted_at IS NULL
+								ORDER BY comment_id`
+
+		rows, err = databaseAccessor.storage.QueryContext(ctx, querySelectComments, postID, model.CommentStatusPending, viewerID)
+	}
 
 	if err != nil {
 		return nil, err
@@ -314,22 +822,91 @@ func (databaseAccessor *DatabaseAccessor) GetCommentsLevel(ctx context.Context,
 
 	defer rows.Close()
 	for rows.Next() {
-		var comment model.Comment
-		if err = rows.Scan(&comment.ID,
-			&comment.AuthorID,
-			&comment.PostID,
-			&comment.ParentID,
-			&comment.Text,
-			&comment.CreateDate); err != nil {
+		comment, err := scanComment(rows)
+		if err != nil {
 			return nil, err
 		}
 
-		comments = append(comments, &comment)
+		comments = append(comments, comment)
 	}
 
 	return comments, nil
 }
 
+func (databaseAccessor *DatabaseAccessor) UpdateCommentStatus(ctx context.Context, commentID int64, authorID uuid.UUID, newStatus model.CommentStatus) (*model.Comment, error) {
+	var postID int64
+	var parentID *int64
+	var postAuthorID uuid.UUID
+	var status model.CommentStatus
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectComment := `SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = $1 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL`
+
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, commentID).Scan(&postID, &parentID, &postAuthorID, &status)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if postAuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	if status != model.CommentStatusPending {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not pending moderation.")
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	queryUpdateComment := `UPDATE comments SET status = $1
+							WHERE comment_id = $2
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+	comment, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, newStatus, commentID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if newStatus == model.CommentStatusApproved {
+		if err = changeCommentCounters(ctx, tx, postID, parentID, 1); err != nil {
+			return nil, err
+		}
+
+		if err = addReplyNotification(ctx, tx, comment); err != nil {
+			return nil, err
+		}
+
+		if hasMentions(comment.Text) {
+			if err = addMentionNotifications(ctx, tx, comment.PostID, &comment.ID, comment.AuthorID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
 func (databaseAccessor *DatabaseAccessor) CloseStorage() {
-	databaseAccessor.storage.Close()
+	databaseAccessor.database.Close()
 }
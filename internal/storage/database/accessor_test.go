@@ -5,15 +5,20 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
+const testSearchLanguage = "simple"
+
 type AnyTime struct{}
 
 // Match satisfies sqlmock.Argument interface
@@ -27,7 +32,7 @@ func getMockAccessor(t *testing.T) (*DatabaseAccessor, sqlmock.Sqlmock) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	mockAccessor := NewDatabaseAccessor(mockStorage)
+	mockAccessor := NewDatabaseAccessor(mockStorage, testSearchLanguage)
 	return mockAccessor, mock
 }
 
@@ -47,13 +52,22 @@ func TestAddPost(t *testing.T) {
 			CommentsEnabled: true,
 		}
 
-		mock.ExpectQuery(`INSERT INTO posts \(author_id, title, text, create_date, comments_enabled\)
-						VALUES \(\$1, \$2, \$3, \$4, \$5\)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO posts \(author_id, title, text, create_date, comments_enabled, moderation_mode, max_reply_depth, status, publish_at, search_language\)
+						VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10\)
 						RETURNING post_id`).WithArgs(authorID,
 			newPost.Title,
 			newPost.Text,
 			AnyTime{},
-			newPost.CommentsEnabled).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+			newPost.CommentsEnabled,
+			model.ModerationModeOpen,
+			nil,
+			model.PostStatusPublished,
+			AnyTime{},
+			testSearchLanguage).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+		mock.ExpectQuery(`INSERT INTO post_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectCommit()
 
 		createdPost, err := mockAccessor.AddPost(ctx, newPost)
 		assertions.Nil(err)
@@ -65,6 +79,9 @@ func TestAddPost(t *testing.T) {
 			Text:            newPost.Text,
 			CommentsEnabled: newPost.CommentsEnabled,
 			CreateDate:      createdPost.CreateDate,
+			ModerationMode:  model.ModerationModeOpen,
+			Status:          model.PostStatusPublished,
+			PublishAt:       &createdPost.CreateDate,
 		}, createdPost)
 	})
 
@@ -72,39 +89,64 @@ func TestAddPost(t *testing.T) {
 		mockAccessor, mock := getMockAccessor(t)
 		defer mockAccessor.CloseStorage()
 
-		mock.ExpectQuery(`SELECT post_id, author_id
+		mock.ExpectQuery(`SELECT post_id, author_id, moderation_mode
 						FROM posts
-						WHERE post_id = \$1 AND author_id = \$2`).
-			WithArgs(int64(0), authorID).
-			WillReturnRows(sqlmock.NewRows([]string{"post_id", "author_id"}).AddRow(int64(0), authorID))
-
-		mock.ExpectQuery(`UPDATE posts SET comments_enabled = \$1
-						WHERE post_id = \$2
-						RETURNING post_id, author_id, title, text, create_date, comments_enabled`).
-			WithArgs(int64(0), false).
+						WHERE post_id = \$1 AND \(author_id = \$2 OR \$3\)`).
+			WithArgs(int64(0), authorID, false).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "author_id", "moderation_mode"}).AddRow(int64(0), authorID, "OPEN"))
+
+		mock.ExpectQuery(`UPDATE posts SET comments_enabled = \$1, moderation_mode = \$2
+						WHERE post_id = \$3
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`).
+			WithArgs(false, model.ModerationModeClosed, int64(0)).
 			WillReturnRows(sqlmock.
-				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled"}).
-				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false))
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false, "CLOSED", 0, "PUBLISHED", time.Now()))
 
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, authorID, false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, authorID, false, false)
 		assertions.Nil(err)
 		assertions.NotNil(updatedPost)
 		assertions.Equal(updatedPost.CommentsEnabled, false)
+		assertions.Equal(updatedPost.ModerationMode, model.ModerationModeClosed)
+	})
+
+	t.Run("Successful Update CommentsEnabled Privileged", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		moderatorID := uuid.New()
+		mock.ExpectQuery(`SELECT post_id, author_id, moderation_mode
+						FROM posts
+						WHERE post_id = \$1 AND \(author_id = \$2 OR \$3\)`).
+			WithArgs(int64(0), moderatorID, true).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "author_id", "moderation_mode"}).AddRow(int64(0), authorID, "OPEN"))
+
+		mock.ExpectQuery(`UPDATE posts SET comments_enabled = \$1, moderation_mode = \$2`).
+			WithArgs(false, model.ModerationModeClosed, int64(0)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false, "CLOSED", 0, "PUBLISHED", time.Now()))
+
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, moderatorID, true, false)
+		assertions.Nil(err)
+		assertions.Equal(authorID, updatedPost.AuthorID)
+		assertions.False(updatedPost.CommentsEnabled)
+		assertions.Nil(mock.ExpectationsWereMet())
 	})
 
 	t.Run("Unsuccessful Update CommentsEnabled Incorrect PostID", func(t *testing.T) {
 		mockAccessor, mock := getMockAccessor(t)
 		defer mockAccessor.CloseStorage()
 
-		mock.ExpectQuery(`SELECT post_id, author_id
+		mock.ExpectQuery(`SELECT post_id, author_id, moderation_mode
 						FROM posts
-						WHERE post_id = \$1 AND author_id = \$2`).
-			WithArgs(int64(0), authorID)
+						WHERE post_id = \$1 AND \(author_id = \$2 OR \$3\)`).
+			WithArgs(int64(0), authorID, false)
 
 		err := mock.ExpectationsWereMet()
 		assertions.NotNil(err)
 
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, -1, authorID, false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, -1, authorID, false, false)
 		assertions.NotNil(err)
 		assertions.Nil(updatedPost)
 	})
@@ -114,15 +156,15 @@ func TestAddPost(t *testing.T) {
 		defer mockAccessor.CloseStorage()
 
 		incorrectAuthorID := uuid.New()
-		mock.ExpectQuery(`SELECT post_id, author_id
+		mock.ExpectQuery(`SELECT post_id, author_id, moderation_mode
 						FROM posts
-						WHERE post_id = \$1 AND author_id = \$2`).
-			WithArgs(int64(0), authorID)
+						WHERE post_id = \$1 AND \(author_id = \$2 OR \$3\)`).
+			WithArgs(int64(0), authorID, false)
 
 		err := mock.ExpectationsWereMet()
 		assertions.NotNil(err)
 
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, incorrectAuthorID, false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, incorrectAuthorID, false, false)
 		assertions.NotNil(err)
 		assertions.Nil(updatedPost)
 	})
@@ -138,15 +180,15 @@ func TestAddPost(t *testing.T) {
 			CommentsEnabled: false,
 		}
 
-		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
 						FROM posts
-						WHERE post_id = \$1`).
-			WithArgs(int64(0)).
+						WHERE post_id = \$1 AND deleted_at IS NULL AND \(status = \$2 OR author_id = \$3\)`).
+			WithArgs(int64(0), model.PostStatusPublished, uuid.Nil).
 			WillReturnRows(sqlmock.
-				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled"}).
-				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false))
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false, "CLOSED", 0, "PUBLISHED", time.Now()))
 
-		post, err := mockAccessor.GetPost(ctx, 0)
+		post, err := mockAccessor.GetPost(ctx, 0, uuid.Nil)
 		assertions.Nil(err)
 		assertions.Equal(&model.Post{
 			ID:              0,
@@ -155,6 +197,9 @@ func TestAddPost(t *testing.T) {
 			Text:            existingPost.Text,
 			CommentsEnabled: existingPost.CommentsEnabled,
 			CreateDate:      post.CreateDate,
+			ModerationMode:  model.ModerationModeClosed,
+			Status:          model.PostStatusPublished,
+			PublishAt:       post.PublishAt,
 		}, post)
 	})
 
@@ -162,7 +207,7 @@ func TestAddPost(t *testing.T) {
 		mockAccessor, mock := getMockAccessor(t)
 		defer mockAccessor.CloseStorage()
 
-		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(-1))
@@ -170,7 +215,7 @@ func TestAddPost(t *testing.T) {
 		err := mock.ExpectationsWereMet()
 		assertions.NotNil(err)
 
-		post, err := mockAccessor.GetPost(ctx, -1)
+		post, err := mockAccessor.GetPost(ctx, -1, uuid.Nil)
 		assertions.NotNil(err)
 		assertions.Nil(post)
 	})
@@ -179,14 +224,14 @@ func TestAddPost(t *testing.T) {
 		mockAccessor, mock := getMockAccessor(t)
 		defer mockAccessor.CloseStorage()
 
-		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
 						FROM posts`).
 			WillReturnRows(sqlmock.
-				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled"}).
-				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false).
-				AddRow(int64(1), authorID, "Test Title", "Test Text", time.Now(), true))
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), false, "CLOSED", 0, "PUBLISHED", time.Now()).
+				AddRow(int64(1), authorID, "Test Title", "Test Text", time.Now(), true, "OPEN", 0, "PUBLISHED", time.Now()))
 
-		posts, err := mockAccessor.GetAllPosts(ctx)
+		posts, err := mockAccessor.GetAllPosts(ctx, uuid.Nil)
 		assertions.Nil(err)
 		assertions.Equal([]*model.Post{
 			{
@@ -196,6 +241,9 @@ func TestAddPost(t *testing.T) {
 				Text:            "Test Text",
 				CommentsEnabled: false,
 				CreateDate:      posts[0].CreateDate,
+				ModerationMode:  model.ModerationModeClosed,
+				Status:          model.PostStatusPublished,
+				PublishAt:       posts[0].PublishAt,
 			},
 			{
 				ID:              1,
@@ -204,6 +252,9 @@ func TestAddPost(t *testing.T) {
 				Text:            "Test Text",
 				CommentsEnabled: true,
 				CreateDate:      posts[1].CreateDate,
+				ModerationMode:  model.ModerationModeOpen,
+				Status:          model.PostStatusPublished,
+				PublishAt:       posts[1].PublishAt,
 			},
 		}, posts)
 	})
@@ -219,26 +270,42 @@ func TestAddPost(t *testing.T) {
 			ParentID: nil,
 		}
 
-		mock.ExpectQuery(`SELECT comments_enabled
+		mock.ExpectQuery(`SELECT moderation_mode, status
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(1)).
-			WillReturnRows(sqlmock.NewRows([]string{"comments_enabled"}).AddRow(true))
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("OPEN", "PUBLISHED"))
 
 		mock.ExpectQuery(`SELECT path, replies_level
 							FROM comments
-							WHERE comment_id = \$1`).
-			WithArgs(nil).WillReturnError(sql.ErrNoRows)
+							WHERE comment_id = \$1 AND post_id = \$2 AND status = \$3 AND deleted_at IS NULL`).
+			WithArgs(nil, int64(1), model.CommentStatusApproved).WillReturnError(sql.ErrNoRows)
 
-		mock.ExpectQuery(`INSERT INTO comments \(author_id, post_id, parent_id, text, create_date, path, replies_level\)
-							VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO comments \(author_id, post_id, parent_id, text, create_date, path, replies_level, status, search_language\)
+							VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\)
 							RETURNING comment_id`).WithArgs(authorID,
 			newComment.PostID,
 			newComment.ParentID,
 			newComment.Text,
 			AnyTime{},
-			newComment.PostID,
-			0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+			"1",
+			0,
+			model.CommentStatusApproved,
+			testSearchLanguage).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+		mock.ExpectQuery(`INSERT INTO comment_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+
+		mock.ExpectExec(`UPDATE posts SET comment_count = comment_count \+ \$1, root_comment_count = root_comment_count \+ \$1
+							WHERE post_id = \$2`).
+			WithArgs(int32(1), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindReply, sqlmock.AnyArg(), sqlmock.AnyArg(), AnyTime{}, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectCommit()
 
 		createdComment, err := mockAccessor.AddComment(ctx, newComment)
 		assertions.Nil(err)
@@ -250,6 +317,7 @@ func TestAddPost(t *testing.T) {
 			ParentID:   nil,
 			Text:       newComment.Text,
 			CreateDate: createdComment.CreateDate,
+			Status:     model.CommentStatusApproved,
 		}, createdComment)
 	})
 
@@ -264,11 +332,11 @@ func TestAddPost(t *testing.T) {
 			ParentID: nil,
 		}
 
-		mock.ExpectQuery(`SELECT comments_enabled
+		mock.ExpectQuery(`SELECT moderation_mode, status
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(0)).
-			WillReturnRows(sqlmock.NewRows([]string{"comments_enabled"}).AddRow(false))
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("CLOSED", "PUBLISHED"))
 
 		createdComment, err := mockAccessor.AddComment(ctx, newComment)
 		assertions.NotNil(err)
@@ -286,7 +354,7 @@ func TestAddPost(t *testing.T) {
 			ParentID: nil,
 		}
 
-		mock.ExpectQuery(`SELECT comments_enabled
+		mock.ExpectQuery(`SELECT moderation_mode, status
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(-1)).
@@ -297,6 +365,23 @@ func TestAddPost(t *testing.T) {
 		assertions.Nil(createdComment)
 	})
 
+	t.Run("Unsuccessful Add Comment Too Long", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		newComment := &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   1,
+			Text:     strings.Repeat("a", 2001),
+			ParentID: nil,
+		}
+
+		createdComment, err := mockAccessor.AddComment(ctx, newComment)
+		assertions.NotNil(err)
+		assertions.Nil(createdComment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
 	t.Run("Unsuccessful Add Comment Comments Parent Comment Does Not Exist", func(t *testing.T) {
 		mockAccessor, mock := getMockAccessor(t)
 		defer mockAccessor.CloseStorage()
@@ -309,20 +394,21 @@ func TestAddPost(t *testing.T) {
 			ParentID: &incorrectParentID,
 		}
 
-		mock.ExpectQuery(`SELECT comments_enabled
+		mock.ExpectQuery(`SELECT moderation_mode, status
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(1)).
-			WillReturnRows(sqlmock.NewRows([]string{"comments_enabled"}).AddRow(true))
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("OPEN", "PUBLISHED"))
 
 		mock.ExpectQuery(`SELECT path, replies_level
 							FROM comments
-							WHERE comment_id = \$1`).
-			WithArgs(nil).WillReturnError(errors.New("Test"))
+							WHERE comment_id = \$1 AND post_id = \$2 AND status = \$3 AND deleted_at IS NULL`).
+			WithArgs(&incorrectParentID, int64(1), model.CommentStatusApproved).WillReturnError(sql.ErrNoRows)
 
 		createdComment, err := mockAccessor.AddComment(ctx, newComment)
-		assertions.NotNil(err)
+		assertions.ErrorIs(err, storage.ErrNotFound)
 		assertions.Nil(createdComment)
+		assertions.Nil(mock.ExpectationsWereMet())
 	})
 
 	t.Run("Successful Add Child Comment", func(t *testing.T) {
@@ -337,27 +423,48 @@ func TestAddPost(t *testing.T) {
 			ParentID: &parentID,
 		}
 
-		mock.ExpectQuery(`SELECT comments_enabled
+		mock.ExpectQuery(`SELECT moderation_mode, status
 						FROM posts
 						WHERE post_id = \$1`).
 			WithArgs(int64(1)).
-			WillReturnRows(sqlmock.NewRows([]string{"comments_enabled"}).AddRow(true))
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("OPEN", "PUBLISHED"))
 
 		mock.ExpectQuery(`SELECT path, replies_level
 							FROM comments
-							WHERE comment_id = \$1`).
-			WithArgs(newComment.ParentID).
+							WHERE comment_id = \$1 AND post_id = \$2 AND status = \$3 AND deleted_at IS NULL`).
+			WithArgs(newComment.ParentID, int64(1), model.CommentStatusApproved).
 			WillReturnRows(sqlmock.NewRows([]string{"path", "replies_level"}).AddRow("1", 0))
 
-		mock.ExpectQuery(`INSERT INTO comments \(author_id, post_id, parent_id, text, create_date, path, replies_level\)
-							VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7\)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO comments \(author_id, post_id, parent_id, text, create_date, path, replies_level, status, search_language\)
+							VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\)
 							RETURNING comment_id`).WithArgs(authorID,
 			newComment.PostID,
 			newComment.ParentID,
 			newComment.Text,
 			AnyTime{},
 			"1.0",
-			1).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			1,
+			model.CommentStatusApproved,
+			testSearchLanguage).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+		mock.ExpectQuery(`INSERT INTO comment_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+
+		mock.ExpectExec(`UPDATE posts SET comment_count = comment_count \+ \$1
+						WHERE post_id = \$2`).
+			WithArgs(int32(1), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`UPDATE comments SET reply_count = reply_count \+ \$1
+							WHERE comment_id = \$2`).
+			WithArgs(int32(1), int64(0)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindReply, sqlmock.AnyArg(), sqlmock.AnyArg(), AnyTime{}, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectCommit()
 
 		createdComment, err := mockAccessor.AddComment(ctx, newComment)
 		assertions.Nil(err)
@@ -369,6 +476,7 @@ func TestAddPost(t *testing.T) {
 			ParentID:   &parentID,
 			Text:       newComment.Text,
 			CreateDate: createdComment.CreateDate,
+			Status:     model.CommentStatusApproved,
 		}, createdComment)
 	})
 
@@ -467,16 +575,21 @@ func TestAddPost(t *testing.T) {
 			WithArgs(int64(1)).
 			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
 
-		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
 							FROM comments
-							WHERE path = \$1`).
-			WithArgs("1").
+							WHERE path = \$1 AND pinned_at IS NULL AND status = \$2 AND \(deleted_at IS NULL OR EXISTS \(
+								SELECT 1
+								FROM comments AS replies
+								WHERE replies.path <@ \(comments.path \|\| comments.comment_id::text\) AND replies.status = \$2 AND replies.deleted_at IS NULL
+							\)\)
+							ORDER BY create_date, comment_id`).
+			WithArgs("1", model.CommentStatusApproved).
 			WillReturnRows(sqlmock.
-				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date"}).
-				AddRow(int64(0), authorID, int64(1), nil, "Test Text", time.Now()).
-				AddRow(int64(1), authorID, int64(1), nil, "Test Text", time.Now()))
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "deleted_at"}).
+				AddRow(int64(0), authorID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 0, nil).
+				AddRow(int64(1), authorID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 1, time.Now()))
 
-		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1")
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1", model.CommentsOrderOldest, nil, nil)
 
 		assertions.Nil(err)
 		assertions.Equal([]*model.Comment{
@@ -487,14 +600,18 @@ func TestAddPost(t *testing.T) {
 				ParentID:   nil,
 				Text:       "Test Text",
 				CreateDate: comments[0].CreateDate,
+				Status:     model.CommentStatusApproved,
 			},
 			{
 				ID:         1,
-				AuthorID:   authorID,
+				AuthorID:   uuid.Nil,
 				PostID:     1,
 				ParentID:   nil,
-				Text:       "Test Text",
+				Text:       "",
 				CreateDate: comments[1].CreateDate,
+				Status:     model.CommentStatusApproved,
+				ReplyCount: 1,
+				DeletedAt:  comments[1].DeletedAt,
 			},
 		}, comments)
 	})
@@ -511,15 +628,16 @@ func TestAddPost(t *testing.T) {
 			WithArgs(int64(1)).
 			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
 
-		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
 							FROM comments
-							WHERE path = \$1`).
-			WithArgs("1.0").
+							WHERE path = \$1 AND pinned_at IS NULL AND status = \$2 AND \(deleted_at IS NULL OR EXISTS \(.+\)\)
+							ORDER BY create_date, comment_id`).
+			WithArgs("1.0", model.CommentStatusApproved).
 			WillReturnRows(sqlmock.
-				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date"}).
-				AddRow(int64(2), authorID, int64(1), &parentID, "Test Text", time.Now()))
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "deleted_at"}).
+				AddRow(int64(2), authorID, int64(1), &parentID, "Test Text", time.Now(), "APPROVED", 0, nil))
 
-		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1.0")
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1.0", model.CommentsOrderOldest, nil, nil)
 
 		assertions.Nil(err)
 		assertions.Equal([]*model.Comment{
@@ -530,7 +648,515 @@ func TestAddPost(t *testing.T) {
 				ParentID:   &parentID,
 				Text:       "Test Text",
 				CreateDate: comments[0].CreateDate,
+				Status:     model.CommentStatusApproved,
 			},
 		}, comments)
 	})
 }
+
+func TestCommentModeration(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	commenterID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Pending Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		newComment := &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   1,
+			Text:     "Test Text",
+			ParentID: nil,
+		}
+
+		mock.ExpectQuery(`SELECT moderation_mode, status
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("PREMODERATED", "PUBLISHED"))
+
+		mock.ExpectQuery(`SELECT path, replies_level
+							FROM comments
+							WHERE comment_id = \$1 AND post_id = \$2 AND status = \$3 AND deleted_at IS NULL`).
+			WithArgs(nil, int64(1), model.CommentStatusApproved).WillReturnError(sql.ErrNoRows)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO comments \(author_id, post_id, parent_id, text, create_date, path, replies_level, status, search_language\)
+							VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9\)
+							RETURNING comment_id`).WithArgs(commenterID,
+			newComment.PostID,
+			newComment.ParentID,
+			newComment.Text,
+			AnyTime{},
+			"1",
+			0,
+			model.CommentStatusPending,
+			testSearchLanguage).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(0))
+		mock.ExpectQuery(`INSERT INTO comment_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+
+		mock.ExpectCommit()
+
+		createdComment, err := mockAccessor.AddComment(ctx, newComment)
+		assertions.Nil(err)
+		assertions.Equal(model.CommentStatusPending, createdComment.Status)
+	})
+
+	t.Run("Successful Get Pending Comments Post Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+								FROM comments
+								WHERE post_id = \$1 AND status = \$2 AND deleted_at IS NULL
+								ORDER BY comment_id`).
+			WithArgs(int64(1), model.CommentStatusPending).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(0), commenterID, int64(1), nil, "Test Text", time.Now(), "PENDING", 0))
+
+		comments, err := mockAccessor.GetPendingComments(ctx, 1, authorID, false)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+		assertions.Equal(model.CommentStatusPending, comments[0].Status)
+	})
+
+	t.Run("Successful Get Pending Comments Privileged", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+								FROM comments
+								WHERE post_id = \$1 AND status = \$2 AND deleted_at IS NULL
+								ORDER BY comment_id`).
+			WithArgs(int64(1), model.CommentStatusPending).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(0), commenterID, int64(1), nil, "Test Text", time.Now(), "PENDING", 0))
+
+		comments, err := mockAccessor.GetPendingComments(ctx, 1, uuid.New(), true)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Pending Comments Comment Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+								FROM comments
+								WHERE post_id = \$1 AND status = \$2 AND author_id = \$3 AND deleted_at IS NULL
+								ORDER BY comment_id`).
+			WithArgs(int64(1), model.CommentStatusPending, commenterID).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(0), commenterID, int64(1), nil, "Test Text", time.Now(), "PENDING", 0))
+
+		comments, err := mockAccessor.GetPendingComments(ctx, 1, commenterID, false)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+	})
+
+	t.Run("Successful Approve Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = \$1`).
+			WithArgs(int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "parent_id", "author_id", "status"}).AddRow(int64(1), nil, authorID, "PENDING"))
+
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(`UPDATE comments SET status = \$1
+							WHERE comment_id = \$2
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`).
+			WithArgs(model.CommentStatusApproved, int64(0)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(0), commenterID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 0))
+
+
+		mock.ExpectExec(`UPDATE posts SET comment_count = comment_count \+ \$1, root_comment_count = root_comment_count \+ \$1
+							WHERE post_id = \$2`).
+			WithArgs(int32(1), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindReply, sqlmock.AnyArg(), sqlmock.AnyArg(), AnyTime{}, sqlmock.AnyArg(), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, authorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+		assertions.Equal(model.CommentStatusApproved, comment.Status)
+	})
+
+	t.Run("Unsuccessful Approve Comment Incorrect AuthorID", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = \$1`).
+			WithArgs(int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "parent_id", "author_id", "status"}).AddRow(int64(1), nil, authorID, "PENDING"))
+
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, commenterID, model.CommentStatusApproved)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Unsuccessful Reject Comment Not Pending", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = \$1`).
+			WithArgs(int64(0)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "parent_id", "author_id", "status"}).AddRow(int64(1), nil, authorID, "APPROVED"))
+
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, authorID, model.CommentStatusRejected)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+}
+
+func TestCommentsOrder(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Get Comments Newest After Cursor", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		createDate := time.Now()
+		limit := int32(2)
+		after := &cursor.Cursor{
+			Order:     model.CommentsOrderNewest.String(),
+			SortKey:   createDate.UnixNano(),
+			Path:      "1",
+			CommentID: 5,
+		}
+
+		mock.ExpectQuery(`SELECT post_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
+							FROM comments
+							WHERE path = \$1 AND pinned_at IS NULL AND status = \$2 AND \(deleted_at IS NULL OR EXISTS \(.+\)\) AND \(create_date, comment_id\) < \(\$3, \$4\)
+							ORDER BY create_date DESC, comment_id DESC
+							LIMIT \$5`).
+			WithArgs("1", model.CommentStatusApproved, AnyTime{}, int64(5), limit).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "deleted_at"}).
+				AddRow(int64(4), authorID, int64(1), nil, "Test Text", createDate, "APPROVED", 0, nil))
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1", model.CommentsOrderNewest, after, &limit)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+		assertions.Equal(int64(4), comments[0].ID)
+	})
+
+	t.Run("Successful Get Comments Top", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at
+							FROM \(
+								SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at,
+									\(SELECT COUNT\(\*\) FROM reactions WHERE target_type = \$1 AND target_id = comment_id\) AS reactions_count
+								FROM comments
+								WHERE path = \$2 AND pinned_at IS NULL AND status = \$3 AND \(deleted_at IS NULL OR EXISTS \(.+\)\)
+							\) AS level
+							ORDER BY reactions_count DESC, comment_id`).
+			WithArgs(model.ReactionTargetComment, "1", model.CommentStatusApproved).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "deleted_at"}).
+				AddRow(int64(1), authorID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 0, nil).
+				AddRow(int64(0), authorID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 0, nil))
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1", model.CommentsOrderTop, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+	})
+}
+
+func TestGetPosts(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Get Posts Without Filter", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE deleted_at IS NULL AND \(status = \$1 OR author_id = \$2\)
+						ORDER BY create_date DESC, post_id DESC`).
+			WithArgs(model.PostStatusPublished, uuid.Nil).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(1), authorID, "Test Title", "Test Text", time.Now(), true, "OPEN", 0, "PUBLISHED", time.Now()).
+				AddRow(int64(0), authorID, "Test Title", "Test Text", time.Now(), true, "OPEN", 0, "PUBLISHED", time.Now()))
+
+		posts, err := mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(posts, 2)
+	})
+
+	t.Run("Successful Get Posts With Filter After Cursor", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		createdAfter := time.Now().Add(-time.Hour)
+		commentsEnabled := false
+		limit := int32(2)
+		filter := &model.PostsFilter{
+			AuthorID:        &authorID,
+			CreatedAfter:    &createdAfter,
+			CommentsEnabled: &commentsEnabled,
+		}
+		after := &cursor.PostCursor{
+			SortKey: time.Now().UnixNano(),
+			PostID:  5,
+		}
+
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE deleted_at IS NULL AND \(status = \$1 OR author_id = \$2\) AND author_id = \$3 AND create_date > \$4 AND comments_enabled = \$5 AND \(create_date, post_id\) < \(\$6, \$7\)
+						ORDER BY create_date DESC, post_id DESC
+						LIMIT \$8`).
+			WithArgs(model.PostStatusPublished, authorID, authorID, AnyTime{}, commentsEnabled, AnyTime{}, int64(5), limit).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(3), authorID, "Test Title", "Test Text", time.Now(), false, "CLOSED", 0, "PUBLISHED", time.Now()))
+
+		posts, err := mockAccessor.GetPosts(ctx, filter, authorID, after, &limit)
+		assertions.Nil(err)
+		assertions.Len(posts, 1)
+		assertions.Equal(int64(3), posts[0].ID)
+	})
+
+	t.Run("Unsuccessful Get Posts Query Error", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts`).
+			WillReturnError(errors.New("Test Error"))
+
+		posts, err := mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(posts)
+		assertions.NotNil(err)
+	})
+}
+
+func TestCommentsByAuthor(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Get Comments By Author After Cursor", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		limit := int32(2)
+		after := &cursor.AuthorCursor{
+			SortKey:   time.Now().UnixNano(),
+			CommentID: 7,
+		}
+
+		mock.ExpectQuery(`SELECT comments.comment_id, comments.author_id, comments.post_id, comments.parent_id, comments.text,
+								comments.create_date, comments.status, comments.reply_count
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.author_id = \$1 AND comments.status = \$2 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							AND \(comments.create_date, comments.comment_id\) < \(\$3, \$4\)
+							ORDER BY comments.create_date DESC, comments.comment_id DESC
+							LIMIT \$5`).
+			WithArgs(authorID, model.CommentStatusApproved, AnyTime{}, int64(7), limit).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(5), authorID, int64(1), nil, "Test Text", time.Now(), "APPROVED", 0).
+				AddRow(int64(2), authorID, int64(3), nil, "Test Text", time.Now(), "APPROVED", 1))
+
+		comments, err := mockAccessor.GetCommentsByAuthor(ctx, authorID, after, &limit)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(int64(5), comments[0].ID)
+	})
+
+	t.Run("Successful Get Comments By Author Count", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\)
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.author_id = \$1 AND comments.status = \$2 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL`).
+			WithArgs(authorID, model.CommentStatusApproved).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int32(4)))
+
+		count, err := mockAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int32(4), count)
+	})
+}
+
+func TestMaxReplyDepth(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Post With Max Reply Depth", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		maxReplyDepth := int32(2)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO posts`).
+			WithArgs(authorID, "Title", "Text", AnyTime{}, true, model.ModerationModeOpen, &maxReplyDepth, model.PostStatusPublished, AnyTime{}, testSearchLanguage).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+		mock.ExpectQuery(`INSERT INTO post_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectCommit()
+
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true, MaxReplyDepth: &maxReplyDepth})
+		assertions.Nil(err)
+		assertions.Equal(int64(1), post.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add Post Negative Max Reply Depth", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		maxReplyDepth := int32(-1)
+
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", MaxReplyDepth: &maxReplyDepth})
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Post Max Reply Depth", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT max_reply_depth
+						FROM posts
+						WHERE post_id = \$1 AND deleted_at IS NULL`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"max_reply_depth"}).AddRow(int32(2)))
+
+		maxReplyDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, 1)
+		assertions.Nil(err)
+		assertions.Equal(int32(2), *maxReplyDepth)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Post Max Reply Depth Not Set", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT max_reply_depth`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"max_reply_depth"}).AddRow(nil))
+
+		maxReplyDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, 1)
+		assertions.Nil(err)
+		assertions.Nil(maxReplyDepth)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Get Post Max Reply Depth Post Not Found", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT max_reply_depth`).
+			WithArgs(int64(1)).
+			WillReturnError(sql.ErrNoRows)
+
+		maxReplyDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, 1)
+		assertions.NotNil(err)
+		assertions.Nil(maxReplyDepth)
+	})
+}
+
+func TestGetComment(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Get Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+							FROM comments
+							WHERE comment_id = \$1 AND deleted_at IS NULL`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(5), authorID, int64(1), nil, "Text", time.Now(), "PENDING", int32(0)))
+
+		comment, err := mockAccessor.GetComment(ctx, 5)
+		assertions.Nil(err)
+		assertions.Equal(int64(1), comment.PostID)
+		assertions.Equal(model.CommentStatusPending, comment.Status)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Get Comment Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id`).
+			WithArgs(int64(5)).
+			WillReturnError(sql.ErrNoRows)
+
+		comment, err := mockAccessor.GetComment(ctx, 5)
+		assertions.ErrorIs(err, storage.ErrNotFound)
+		assertions.Nil(comment)
+	})
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// AddBan bans the user globally or on one post. A repeated ban of the same scope replaces the previous one.
+func (databaseAccessor *DatabaseAccessor) AddBan(ctx context.Context, newBan *model.BanInput, moderatorID uuid.UUID) (*model.Ban, error) {
+	if err := helpers.CheckBan(newBan); err != nil {
+		return nil, err
+	}
+
+	ban := &model.Ban{
+		UserID:      newBan.UserID,
+		PostID:      newBan.PostID,
+		ModeratorID: moderatorID,
+		Reason:      newBan.Reason,
+		CreateDate:  time.Now(),
+		ExpiresAt:   newBan.ExpiresAt,
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	conflictTarget := `(user_id) WHERE post_id IS NULL`
+	if ban.PostID != nil {
+		querySelectPost := `SELECT post_id
+							FROM posts
+							WHERE post_id = $1 AND deleted_at IS NULL`
+
+		err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, *ban.PostID).Scan(new(int64))
+
+		if err == sql.ErrNoRows {
+			return nil, storage.NotFound("Post with ID " + strconv.FormatInt(*ban.PostID, 10) + " was not found")
+		} else if err != nil {
+			return nil, err
+		}
+
+		conflictTarget = `(user_id, post_id) WHERE post_id IS NOT NULL`
+	}
+
+	queryInsertBan := `INSERT INTO bans (user_id, post_id, moderator_id, reason, create_date, expires_at)
+						VALUES ($1, $2, $3, $4, $5, $6)
+						ON CONFLICT ` + conflictTarget + `
+						DO UPDATE SET moderator_id = EXCLUDED.moderator_id, reason = EXCLUDED.reason, create_date = EXCLUDED.create_date, expires_at = EXCLUDED.expires_at
+						RETURNING ban_id`
+
+	err := databaseAccessor.storage.QueryRowContext(ctx, queryInsertBan,
+		ban.UserID,
+		ban.PostID,
+		ban.ModeratorID,
+		ban.Reason,
+		ban.CreateDate,
+		ban.ExpiresAt).Scan(&ban.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) DeleteBan(ctx context.Context, userID uuid.UUID, postID *int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	queryDeleteBan := `DELETE FROM bans
+						WHERE user_id = $1 AND post_id IS NOT DISTINCT FROM $2`
+
+	result, err := databaseAccessor.storage.ExecContext(ctx, queryDeleteBan, userID, postID)
+
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return storage.NotFound("Ban of the user with ID " + userID.String() + " was not found")
+	}
+
+	return nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetBans(ctx context.Context, userID *uuid.UUID, postID *int64, activeOnly bool) ([]*model.Ban, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := make(queryArgs, 0)
+	conditions := []string{`TRUE`}
+
+	if userID != nil {
+		conditions = append(conditions, `user_id = `+args.add(*userID))
+	}
+
+	if postID != nil {
+		conditions = append(conditions, `post_id = `+args.add(*postID))
+	}
+
+	if activeOnly {
+		conditions = append(conditions, `(expires_at IS NULL OR expires_at > `+args.add(time.Now())+`)`)
+	}
+
+	querySelectBans := `SELECT ban_id, user_id, post_id, moderator_id, reason, create_date, expires_at
+						FROM bans
+						WHERE ` + strings.Join(conditions, " AND ") + `
+						ORDER BY ban_id`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectBans, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bans := make([]*model.Ban, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var ban model.Ban
+		err := rows.Scan(&ban.ID, &ban.UserID, &ban.PostID, &ban.ModeratorID, &ban.Reason, &ban.CreateDate, &ban.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		bans = append(bans, &ban)
+	}
+
+	return bans, nil
+}
+
+// IsBanned reports whether the user has an active global ban or, if postID is set, an active ban on the post.
+// Both scopes are looked up by unique indexes, so the check is cheap enough for every insert.
+func (databaseAccessor *DatabaseAccessor) IsBanned(ctx context.Context, userID uuid.UUID, postID *int64) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+
+	default:
+	}
+
+	querySelectBan := `SELECT EXISTS (
+							SELECT 1
+							FROM bans
+							WHERE user_id = $1 AND (post_id IS NULL OR post_id = $2) AND (expires_at IS NULL OR expires_at > $3)
+						)`
+
+	var banned bool
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectBan, userID, postID, time.Now()).Scan(&banned)
+
+	return banned, err
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBans(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+	moderatorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Global Ban", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		expiresAt := time.Now().Add(time.Hour)
+
+		mock.ExpectQuery(`INSERT INTO bans \(user_id, post_id, moderator_id, reason, create_date, expires_at\)
+						VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+						ON CONFLICT \(user_id\) WHERE post_id IS NULL
+						DO UPDATE SET moderator_id = EXCLUDED.moderator_id`).
+			WithArgs(userID, nil, moderatorID, nil, AnyTime{}, &expiresAt).
+			WillReturnRows(sqlmock.NewRows([]string{"ban_id"}).AddRow(int64(3)))
+
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, ExpiresAt: &expiresAt}, moderatorID)
+		assertions.Nil(err)
+		assertions.Equal(int64(3), ban.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Add Post Ban", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		postID := int64(1)
+
+		mock.ExpectQuery(`SELECT post_id
+							FROM posts
+							WHERE post_id = \$1 AND deleted_at IS NULL`).
+			WithArgs(postID).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(postID))
+		mock.ExpectQuery(`INSERT INTO bans \(user_id, post_id, moderator_id, reason, create_date, expires_at\)
+						VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+						ON CONFLICT \(user_id, post_id\) WHERE post_id IS NOT NULL`).
+			WithArgs(userID, &postID, moderatorID, nil, AnyTime{}, nil).
+			WillReturnRows(sqlmock.NewRows([]string{"ban_id"}).AddRow(int64(4)))
+
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, PostID: &postID}, moderatorID)
+		assertions.Nil(err)
+		assertions.Equal(int64(4), ban.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add Post Ban Post Not Found", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		postID := int64(1)
+
+		mock.ExpectQuery(`SELECT post_id`).
+			WithArgs(postID).
+			WillReturnError(sql.ErrNoRows)
+
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, PostID: &postID}, moderatorID)
+		assertions.NotNil(err)
+		assertions.Nil(ban)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Delete Ban Not Found", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`DELETE FROM bans
+						WHERE user_id = \$1 AND post_id IS NOT DISTINCT FROM \$2`).
+			WithArgs(userID, nil).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := mockAccessor.DeleteBan(ctx, userID, nil)
+		assertions.NotNil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Bans", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT ban_id, user_id, post_id, moderator_id, reason, create_date, expires_at
+						FROM bans
+						WHERE TRUE AND user_id = \$1 AND \(expires_at IS NULL OR expires_at > \$2\)
+						ORDER BY ban_id`).
+			WithArgs(userID, AnyTime{}).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"ban_id", "user_id", "post_id", "moderator_id", "reason", "create_date", "expires_at"}).
+				AddRow(int64(3), userID, nil, moderatorID, "Spam", time.Now(), nil))
+
+		bans, err := mockAccessor.GetBans(ctx, &userID, nil, true)
+		assertions.Nil(err)
+		assertions.Len(bans, 1)
+		assertions.Nil(bans[0].PostID)
+		assertions.Equal("Spam", *bans[0].Reason)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Is Banned", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		postID := int64(1)
+
+		mock.ExpectQuery(`SELECT EXISTS \(
+							SELECT 1
+							FROM bans
+							WHERE user_id = \$1 AND \(post_id IS NULL OR post_id = \$2\) AND \(expires_at IS NULL OR expires_at > \$3\)
+						\)`).
+			WithArgs(userID, &postID, AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+		banned, err := mockAccessor.IsBanned(ctx, userID, &postID)
+		assertions.Nil(err)
+		assertions.True(banned)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
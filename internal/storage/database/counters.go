@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+)
+
+func (databaseAccessor *DatabaseAccessor) GetCommentsCount(ctx context.Context, postID int64, path string) (int32, error) {
+	var count int32
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	separatorIdx := strings.LastIndex(path, ".")
+	if separatorIdx == -1 {
+		querySelectPost := `SELECT root_comment_count
+							FROM posts
+							WHERE post_id = $1`
+		err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&count)
+		return count, err
+	}
+
+	parentID, err := strconv.ParseInt(path[separatorIdx+1:], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	querySelectComment := `SELECT reply_count
+							FROM comments
+							WHERE comment_id = $1 AND post_id = $2`
+	err = databaseAccessor.storage.QueryRowContext(ctx, querySelectComment, parentID, postID).Scan(&count)
+	return count, err
+}
+
+// ReconcileCommentCounters recalculates denormalized comment counters from the comments table
+// and returns the number of posts and comments whose counters have drifted.
+func (databaseAccessor *DatabaseAccessor) ReconcileCommentCounters(ctx context.Context) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	queryUpdatePosts := `UPDATE posts SET comment_count = counts.comment_count, root_comment_count = counts.root_comment_count
+						FROM (
+							SELECT posts.post_id,
+								COUNT(comments.comment_id) AS comment_count,
+								COUNT(comments.comment_id) FILTER (WHERE comments.parent_id IS NULL) AS root_comment_count
+							FROM posts
+							LEFT JOIN comments ON comments.post_id = posts.post_id AND comments.status = $1 AND comments.deleted_at IS NULL
+							GROUP BY posts.post_id
+						) AS counts
+						WHERE posts.post_id = counts.post_id
+							AND (posts.comment_count <> counts.comment_count OR posts.root_comment_count <> counts.root_comment_count)`
+
+	result, err := tx.ExecContext(ctx, queryUpdatePosts, model.CommentStatusApproved)
+
+	if err != nil {
+		return 0, err
+	}
+
+	repairedPosts, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	queryUpdateComments := `UPDATE comments SET reply_count = counts.reply_count
+							FROM (
+								SELECT parents.comment_id, COUNT(replies.comment_id) AS reply_count
+								FROM comments AS parents
+								LEFT JOIN comments AS replies ON replies.parent_id = parents.comment_id AND replies.status = $1 AND replies.deleted_at IS NULL
+								GROUP BY parents.comment_id
+							) AS counts
+							WHERE comments.comment_id = counts.comment_id AND comments.reply_count <> counts.reply_count`
+
+	result, err = tx.ExecContext(ctx, queryUpdateComments, model.CommentStatusApproved)
+
+	if err != nil {
+		return 0, err
+	}
+
+	repairedComments, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return repairedPosts + repairedComments, nil
+}
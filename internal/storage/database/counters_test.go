@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentCounters(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("Successful Get Root Comments Count", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT root_comment_count
+							FROM posts
+							WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"root_comment_count"}).AddRow(int32(3)))
+
+		count, err := mockAccessor.GetCommentsCount(ctx, 1, "1")
+		assertions.Nil(err)
+		assertions.Equal(int32(3), count)
+	})
+
+	t.Run("Successful Get Replies Count", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT reply_count
+							FROM comments
+							WHERE comment_id = \$1 AND post_id = \$2`).
+			WithArgs(int64(5), int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"reply_count"}).AddRow(int32(2)))
+
+		count, err := mockAccessor.GetCommentsCount(ctx, 1, "1.2.5")
+		assertions.Nil(err)
+		assertions.Equal(int32(2), count)
+	})
+
+	t.Run("Unsuccessful Get Comments Count Incorrect Path", func(t *testing.T) {
+		mockAccessor, _ := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		count, err := mockAccessor.GetCommentsCount(ctx, 1, "1.abc")
+		assertions.NotNil(err)
+		assertions.Zero(count)
+	})
+
+	t.Run("Successful Reconcile Comment Counters", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE posts SET comment_count = counts.comment_count, root_comment_count = counts.root_comment_count`)).
+			WithArgs(model.CommentStatusApproved).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE comments SET reply_count = counts.reply_count`)).
+			WithArgs(model.CommentStatusApproved).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		mock.ExpectCommit()
+
+		repaired, err := mockAccessor.ReconcileCommentCounters(ctx)
+		assertions.Nil(err)
+		assertions.Equal(int64(3), repaired)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Reconcile Comment Counters", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+
+		mock.ExpectExec(regexp.QuoteMeta(`UPDATE posts SET comment_count = counts.comment_count`)).
+			WithArgs(model.CommentStatusApproved).
+			WillReturnError(errors.New("Test Error"))
+
+		mock.ExpectRollback()
+
+		repaired, err := mockAccessor.ReconcileCommentCounters(ctx)
+		assertions.NotNil(err)
+		assertions.Zero(repaired)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
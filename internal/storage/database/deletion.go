@@ -0,0 +1,296 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+func (databaseAccessor *DatabaseAccessor) DeletePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool) (*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPost := `SELECT author_id
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL`
+
+	var authorID uuid.UUID
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&authorID)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if authorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	deletedAt := time.Now()
+	queryUpdatePost := `UPDATE posts SET deleted_at = $1
+						WHERE post_id = $2 AND deleted_at IS NULL
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+
+	post, err := scanPost(databaseAccessor.storage.QueryRowContext(ctx, queryUpdatePost, deletedAt, postID))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	post.DeletedAt = &deletedAt
+
+	return post, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) RestorePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPost := `SELECT author_id, deleted_at
+						FROM posts
+						WHERE post_id = $1`
+
+	var authorID uuid.UUID
+	var deletedAt *time.Time
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&authorID, &deletedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if authorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	if deletedAt == nil {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " is not deleted.")
+	}
+
+	if deletedAt.Before(deletedAfter) {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " can no longer be restored.")
+	}
+
+	queryUpdatePost := `UPDATE posts SET deleted_at = NULL
+						WHERE post_id = $1
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+
+	return scanPost(databaseAccessor.storage.QueryRowContext(ctx, queryUpdatePost, postID))
+}
+
+func (databaseAccessor *DatabaseAccessor) DeleteComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	querySelectComment := `SELECT comments.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = $1 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							FOR UPDATE OF comments`
+
+	var authorID uuid.UUID
+	var status model.CommentStatus
+	err = tx.QueryRowContext(ctx, querySelectComment, commentID).Scan(&authorID, &status)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if authorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	deletedAt := time.Now()
+	queryUpdateComment := `UPDATE comments SET deleted_at = $1, pinned_at = NULL
+							WHERE comment_id = $2
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+
+	comment, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, deletedAt, commentID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	comment.DeletedAt = &deletedAt
+
+	if status == model.CommentStatusApproved {
+		if err = changeCommentCounters(ctx, tx, comment.PostID, comment.ParentID, -1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) RestoreComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	querySelectComment := `SELECT comments.author_id, comments.status, comments.deleted_at
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = $1 AND posts.deleted_at IS NULL
+							FOR UPDATE OF comments`
+
+	var authorID uuid.UUID
+	var status model.CommentStatus
+	var deletedAt *time.Time
+	err = tx.QueryRowContext(ctx, querySelectComment, commentID).Scan(&authorID, &status, &deletedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if authorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	if deletedAt == nil {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not deleted.")
+	}
+
+	if deletedAt.Before(deletedAfter) {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " can no longer be restored.")
+	}
+
+	queryUpdateComment := `UPDATE comments SET deleted_at = NULL
+							WHERE comment_id = $1
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+
+	comment, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, commentID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if status == model.CommentStatusApproved {
+		if err = changeCommentCounters(ctx, tx, comment.PostID, comment.ParentID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// PurgeDeleted permanently removes posts and comments deleted before deletedBefore and returns their number.
+// Rows related to them are removed by cascades, except reactions, which have no foreign key to their targets.
+// A deleted comment stays while any of its replies is not purged, so its placeholder keeps the thread navigable.
+func (databaseAccessor *DatabaseAccessor) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	queryDeletePosts := `DELETE FROM posts
+						WHERE deleted_at < $1`
+
+	result, err := tx.ExecContext(ctx, queryDeletePosts, deletedBefore)
+
+	if err != nil {
+		return 0, err
+	}
+
+	purgedPosts, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	queryDeleteComments := `DELETE FROM comments
+							WHERE deleted_at < $1 AND NOT EXISTS (
+								SELECT 1
+								FROM comments AS replies
+								WHERE replies.path <@ (comments.path || comments.comment_id::text)
+									AND (replies.deleted_at IS NULL OR replies.deleted_at >= $1)
+							)`
+
+	result, err = tx.ExecContext(ctx, queryDeleteComments, deletedBefore)
+
+	if err != nil {
+		return 0, err
+	}
+
+	purgedComments, err := result.RowsAffected()
+
+	if err != nil {
+		return 0, err
+	}
+
+	queryDeleteReactions := `DELETE FROM reactions
+							WHERE (target_type = $1 AND NOT EXISTS (SELECT 1 FROM posts WHERE post_id = target_id))
+								OR (target_type = $2 AND NOT EXISTS (SELECT 1 FROM comments WHERE comment_id = target_id))`
+
+	if _, err = tx.ExecContext(ctx, queryDeleteReactions, model.ReactionTargetPost, model.ReactionTargetComment); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return purgedPosts + purgedComments, nil
+}
@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletion(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Delete Post", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id
+						FROM posts
+						WHERE post_id = \$1 AND deleted_at IS NULL`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+		mock.ExpectQuery(`UPDATE posts SET deleted_at = \$1
+						WHERE post_id = \$2 AND deleted_at IS NULL`).
+			WithArgs(AnyTime{}, int64(1)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(1), authorID, "Title", "Text", time.Now(), true, "OPEN", int32(0), "PUBLISHED", time.Now()))
+
+		post, err := mockAccessor.DeletePost(ctx, 1, authorID, false)
+		assertions.Nil(err)
+		assertions.NotNil(post.DeletedAt)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Delete Post Not Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(uuid.New()))
+
+		post, err := mockAccessor.DeletePost(ctx, 1, authorID, false)
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Restore Post Not Deleted", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id, deleted_at
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "deleted_at"}).AddRow(authorID, nil))
+
+		post, err := mockAccessor.RestorePost(ctx, 1, authorID, false, time.Now().Add(-time.Hour))
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Restore Post After Window", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT author_id, deleted_at`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "deleted_at"}).AddRow(authorID, time.Now().Add(-2*time.Hour)))
+
+		post, err := mockAccessor.RestorePost(ctx, 1, uuid.New(), true, time.Now().Add(-time.Hour))
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Delete Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		parentID := int64(1)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.author_id, comments.status
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = \$1 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							FOR UPDATE OF comments`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status"}).AddRow(authorID, "APPROVED"))
+		mock.ExpectQuery(`UPDATE comments SET deleted_at = \$1, pinned_at = NULL
+							WHERE comment_id = \$2`).
+			WithArgs(AnyTime{}, int64(2)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(2), authorID, int64(1), parentID, "Text", time.Now(), "APPROVED", int32(0)))
+		mock.ExpectExec(`UPDATE posts SET comment_count = comment_count \+ \$1`).
+			WithArgs(int32(-1), int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE comments SET reply_count = reply_count \+ \$1`).
+			WithArgs(int32(-1), parentID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.DeleteComment(ctx, 2, authorID, false)
+		assertions.Nil(err)
+		assertions.NotNil(comment.DeletedAt)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Restore Comment Pending", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.author_id, comments.status, comments.deleted_at`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status", "deleted_at"}).AddRow(authorID, "PENDING", time.Now()))
+		mock.ExpectQuery(`UPDATE comments SET deleted_at = NULL
+							WHERE comment_id = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(2), authorID, int64(1), nil, "Text", time.Now(), "PENDING", int32(0)))
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.RestoreComment(ctx, 2, authorID, false, time.Now().Add(-time.Hour))
+		assertions.Nil(err)
+		assertions.Equal(model.CommentStatusPending, comment.Status)
+		assertions.Nil(comment.DeletedAt)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Purge Deleted", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		deletedBefore := time.Now()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM posts
+						WHERE deleted_at < \$1`).
+			WithArgs(deletedBefore).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM comments
+							WHERE deleted_at < \$1 AND NOT EXISTS`).
+			WithArgs(deletedBefore).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE FROM reactions`).
+			WithArgs(model.ReactionTargetPost, model.ReactionTargetComment).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		purged, err := mockAccessor.PurgeDeleted(ctx, deletedBefore)
+		assertions.Nil(err)
+		assertions.Equal(int64(4), purged)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
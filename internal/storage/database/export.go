@@ -0,0 +1,197 @@
+package database
+
+import (
+	"context"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// exportBatchSize is the number of rows read by one query of the export. Rows are read in batches by key,
+// so the export does not keep a long-running query open while the caller processes the rows.
+const exportBatchSize = 500
+
+// IteratePosts yields all posts selected by the filter in the order of their IDs, including drafts and deleted posts.
+func (databaseAccessor *DatabaseAccessor) IteratePosts(ctx context.Context, filter *storage.ExportFilter) iter.Seq2[*storage.ExportPost, error] {
+	return func(yield func(*storage.ExportPost, error) bool) {
+		lastPostID := int64(-1)
+		for {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+
+			default:
+			}
+
+			querySelectPosts, args := getExportPostsQuery(filter, lastPostID)
+			posts, err := databaseAccessor.queryExportPosts(ctx, querySelectPosts, args)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, post := range posts {
+				if !yield(post, nil) {
+					return
+				}
+			}
+
+			if len(posts) < exportBatchSize {
+				return
+			}
+
+			lastPostID = posts[len(posts)-1].Post.ID
+		}
+	}
+}
+
+// getExportPostsQuery builds a query for the batch of exported posts following the post with lastPostID.
+func getExportPostsQuery(filter *storage.ExportFilter, lastPostID int64) (string, queryArgs) {
+	args := make(queryArgs, 0)
+	conditions := []string{`post_id > ` + args.add(lastPostID)}
+
+	if filter != nil {
+		if filter.PostID != nil {
+			conditions = append(conditions, `post_id = `+args.add(*filter.PostID))
+		}
+
+		if filter.CreatedAfter != nil {
+			conditions = append(conditions, `create_date > `+args.add(*filter.CreatedAfter))
+		}
+
+		if filter.CreatedBefore != nil {
+			conditions = append(conditions, `create_date < `+args.add(*filter.CreatedBefore))
+		}
+	}
+
+	querySelectPosts := `SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at, deleted_at, max_reply_depth
+						FROM posts
+						WHERE ` + strings.Join(conditions, " AND ") + `
+						ORDER BY post_id
+						LIMIT ` + args.add(exportBatchSize)
+
+	return querySelectPosts, args
+}
+
+func (databaseAccessor *DatabaseAccessor) queryExportPosts(ctx context.Context, querySelectPosts string, args queryArgs) ([]*storage.ExportPost, error) {
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPosts, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]*storage.ExportPost, 0, exportBatchSize)
+
+	defer rows.Close()
+	for rows.Next() {
+		var exportPost storage.ExportPost
+		var deletedAt *time.Time
+		post, err := scanPost(rows, &deletedAt, &exportPost.MaxReplyDepth)
+		if err != nil {
+			return nil, err
+		}
+
+		post.DeletedAt = deletedAt
+		exportPost.Post = post
+		posts = append(posts, &exportPost)
+	}
+
+	return posts, rows.Err()
+}
+
+// IteratePostComments yields all comments of the post with their paths in the order of their IDs,
+// so every comment follows its parent. Deleted comments are included.
+func (databaseAccessor *DatabaseAccessor) IteratePostComments(ctx context.Context, postID int64) iter.Seq2[*storage.TreeComment, error] {
+	return func(yield func(*storage.TreeComment, error) bool) {
+		lastCommentID := int64(-1)
+		for {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+
+			default:
+			}
+
+			comments, err := databaseAccessor.queryExportComments(ctx, postID, lastCommentID)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, comment := range comments {
+				if !yield(comment, nil) {
+					return
+				}
+			}
+
+			if len(comments) < exportBatchSize {
+				return
+			}
+
+			lastCommentID = comments[len(comments)-1].Comment.ID
+		}
+	}
+}
+
+func (databaseAccessor *DatabaseAccessor) queryExportComments(ctx context.Context, postID int64, lastCommentID int64) ([]*storage.TreeComment, error) {
+	querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at, path, pinned_at
+							FROM comments
+							WHERE post_id = $1 AND comment_id > $2
+							ORDER BY comment_id
+							LIMIT $3`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, postID, lastCommentID, exportBatchSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*storage.TreeComment, 0, exportBatchSize)
+
+	defer rows.Close()
+	for rows.Next() {
+		var treeComment storage.TreeComment
+		var deletedAt *time.Time
+		comment, err := scanComment(rows, &deletedAt, &treeComment.Path, &treeComment.PinnedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		comment.DeletedAt = deletedAt
+		treeComment.Comment = comment
+		comments = append(comments, &treeComment)
+	}
+
+	return comments, rows.Err()
+}
+
+// CountOtherRecords counts records other than posts and comments, which archives and migrations do not carry.
+func (databaseAccessor *DatabaseAccessor) CountOtherRecords(ctx context.Context) (*storage.OtherRecords, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectCounts := `SELECT (SELECT COUNT(*) FROM users),
+								(SELECT COUNT(*) FROM post_revisions WHERE revision_number > 1) +
+									(SELECT COUNT(*) FROM comment_revisions WHERE revision_number > 1),
+								(SELECT COUNT(*) FROM reactions),
+								(SELECT COUNT(*) FROM bans),
+								(SELECT COUNT(*) FROM notifications),
+								(SELECT COUNT(*) FROM webhook_subscriptions)`
+
+	var records storage.OtherRecords
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectCounts).Scan(&records.Users, &records.Revisions, &records.Reactions,
+		&records.Bans, &records.Notifications, &records.WebhookSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return &records, nil
+}
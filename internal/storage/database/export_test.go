@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	createDate := time.Now()
+
+	postColumns := []string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at", "deleted_at", "max_reply_depth"}
+	commentColumns := []string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "deleted_at", "path", "pinned_at"}
+
+	t.Run("Successful Iterate Posts With Filter", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		postID := int64(3)
+		createdAfter := createDate.Add(-time.Hour)
+
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at, deleted_at, max_reply_depth
+						FROM posts
+						WHERE post_id > \$1 AND post_id = \$2 AND create_date > \$3
+						ORDER BY post_id
+						LIMIT \$4`).
+			WithArgs(int64(-1), postID, createdAfter, exportBatchSize).
+			WillReturnRows(sqlmock.NewRows(postColumns).
+				AddRow(postID, authorID, "Title", "Text", createDate, true, "OPEN", 1, "PUBLISHED", createDate, createDate, int32(2)))
+
+		posts := make([]*storage.ExportPost, 0)
+		for post, err := range mockAccessor.IteratePosts(ctx, &storage.ExportFilter{PostID: &postID, CreatedAfter: &createdAfter}) {
+			assertions.Nil(err)
+			posts = append(posts, post)
+		}
+
+		assertions.Len(posts, 1)
+		assertions.Equal(postID, posts[0].Post.ID)
+		assertions.NotNil(posts[0].Post.DeletedAt)
+		assertions.Equal(int32(2), *posts[0].MaxReplyDepth)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Iterate Posts Query Error", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id`).
+			WillReturnError(errors.New("Test"))
+
+		for post, err := range mockAccessor.IteratePosts(ctx, nil) {
+			assertions.Nil(post)
+			assertions.NotNil(err)
+		}
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Iterate Post Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		parentID := int64(5)
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, deleted_at, path, pinned_at
+							FROM comments
+							WHERE post_id = \$1 AND comment_id > \$2
+							ORDER BY comment_id
+							LIMIT \$3`).
+			WithArgs(int64(3), int64(-1), exportBatchSize).
+			WillReturnRows(sqlmock.NewRows(commentColumns).
+				AddRow(int64(5), authorID, int64(3), nil, "Root", createDate, model.CommentStatusApproved, 1, nil, "3", createDate).
+				AddRow(int64(6), authorID, int64(3), parentID, "Reply", createDate, model.CommentStatusApproved, 0, nil, "3.5", nil))
+
+		comments := make([]*storage.TreeComment, 0)
+		for comment, err := range mockAccessor.IteratePostComments(ctx, 3) {
+			assertions.Nil(err)
+			comments = append(comments, comment)
+		}
+
+		assertions.Len(comments, 2)
+		assertions.Equal("3", comments[0].Path)
+		assertions.Equal("3.5", comments[1].Path)
+		assertions.Equal(parentID, *comments[1].Comment.ParentID)
+		assertions.NotNil(comments[0].PinnedAt)
+		assertions.Nil(comments[1].PinnedAt)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Count Other Records", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT \(SELECT COUNT\(\*\) FROM users\),
+								\(SELECT COUNT\(\*\) FROM post_revisions WHERE revision_number > 1\) \+
+									\(SELECT COUNT\(\*\) FROM comment_revisions WHERE revision_number > 1\),`).
+			WillReturnRows(sqlmock.NewRows([]string{"users", "revisions", "reactions", "bans", "notifications", "webhooks"}).
+				AddRow(int64(2), int64(3), int64(0), int64(0), int64(4), int64(0)))
+
+		records, err := mockAccessor.CountOtherRecords(ctx)
+		assertions.Nil(err)
+		assertions.Equal(&storage.OtherRecords{Users: 2, Revisions: 3, Notifications: 4}, records)
+		assertions.False(records.IsEmpty())
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
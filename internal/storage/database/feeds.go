@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/lib/pq"
+)
+
+// GetRecentComments returns the newest approved comments of the post at any level of the tree.
+func (databaseAccessor *DatabaseAccessor) GetRecentComments(ctx context.Context, postID int64, limit int32) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+							FROM comments
+							WHERE post_id = $1 AND status = $2 AND deleted_at IS NULL
+							ORDER BY create_date DESC, comment_id DESC
+							LIMIT $3`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, postID, model.CommentStatusApproved, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*model.Comment, 0, limit)
+
+	defer rows.Close()
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// GetPostsEditDates returns the dates of the latest revisions of the posts, which are their create dates
+// for posts that were never edited.
+func (databaseAccessor *DatabaseAccessor) GetPostsEditDates(ctx context.Context, postIDs []int64) (map[int64]time.Time, error) {
+	querySelectDates := `SELECT post_id, MAX(create_date)
+						FROM post_revisions
+						WHERE post_id = ANY($1)
+						GROUP BY post_id`
+
+	return databaseAccessor.getEditDates(ctx, querySelectDates, postIDs)
+}
+
+// GetCommentsEditDates returns the dates of the latest revisions of the comments, which are their create dates
+// for comments that were never edited.
+func (databaseAccessor *DatabaseAccessor) GetCommentsEditDates(ctx context.Context, commentIDs []int64) (map[int64]time.Time, error) {
+	querySelectDates := `SELECT comment_id, MAX(create_date)
+						FROM comment_revisions
+						WHERE comment_id = ANY($1)
+						GROUP BY comment_id`
+
+	return databaseAccessor.getEditDates(ctx, querySelectDates, commentIDs)
+}
+
+func (databaseAccessor *DatabaseAccessor) getEditDates(ctx context.Context, querySelectDates string, ids []int64) (map[int64]time.Time, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectDates, pq.Array(ids))
+
+	if err != nil {
+		return nil, err
+	}
+
+	editDates := make(map[int64]time.Time, len(ids))
+
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		var editDate time.Time
+		if err := rows.Scan(&id, &editDate); err != nil {
+			return nil, err
+		}
+
+		editDates[id] = editDate
+	}
+
+	return editDates, rows.Err()
+}
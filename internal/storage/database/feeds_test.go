@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeds(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	createDate := time.Now()
+
+	t.Run("Successful Get Recent Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count
+							FROM comments
+							WHERE post_id = \$1 AND status = \$2 AND deleted_at IS NULL
+							ORDER BY create_date DESC, comment_id DESC
+							LIMIT \$3`).
+			WithArgs(int64(3), model.CommentStatusApproved, int32(10)).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(6), authorID, int64(3), int64(5), "Reply", createDate, model.CommentStatusApproved, 0).
+				AddRow(int64(5), authorID, int64(3), nil, "Root", createDate, model.CommentStatusApproved, 1))
+
+		comments, err := mockAccessor.GetRecentComments(ctx, 3, 10)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(int64(6), comments[0].ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Get Recent Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id`).
+			WillReturnError(errors.New("Test"))
+
+		comments, err := mockAccessor.GetRecentComments(ctx, 3, 10)
+		assertions.Nil(comments)
+		assertions.NotNil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Posts Edit Dates", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id, MAX\(create_date\)
+							FROM post_revisions
+							WHERE post_id = ANY\(\$1\)
+							GROUP BY post_id`).
+			WithArgs(pq.Array([]int64{3, 4})).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "max"}).AddRow(int64(3), createDate))
+
+		editDates, err := mockAccessor.GetPostsEditDates(ctx, []int64{3, 4})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]time.Time{3: createDate}, editDates)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Comments Edit Dates", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id, MAX\(create_date\)
+							FROM comment_revisions`).
+			WithArgs(pq.Array([]int64{5})).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id", "max"}).AddRow(int64(5), createDate))
+
+		editDates, err := mockAccessor.GetCommentsEditDates(ctx, []int64{5})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]time.Time{5: createDate}, editDates)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
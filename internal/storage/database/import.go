@@ -0,0 +1,399 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+	"github.com/lib/pq"
+)
+
+// importedComment is the position of an imported comment in the comment tree.
+type importedComment struct {
+	commentID    int64
+	path         string
+	repliesLevel int
+}
+
+// GetImportedIDs returns IDs of the records imported from the source, keyed by their IDs in the source.
+// Records that were not imported are absent from the result.
+func (databaseAccessor *DatabaseAccessor) GetImportedIDs(ctx context.Context, source string, kind storage.ImportKind, sourceIDs []int64) (map[int64]int64, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	return getImportedIDs(ctx, databaseAccessor.storage, source, kind, sourceIDs)
+}
+
+func getImportedIDs(ctx context.Context, tx queryExecutor, source string, kind storage.ImportKind, sourceIDs []int64) (map[int64]int64, error) {
+	querySelectMappings := `SELECT source_id, post_id
+							FROM imported_posts
+							WHERE source = $1 AND source_id = ANY($2)`
+	if kind == storage.ImportKindComment {
+		querySelectMappings = `SELECT source_id, comment_id
+								FROM imported_comments
+								WHERE source = $1 AND source_id = ANY($2)`
+	}
+
+	rows, err := tx.QueryContext(ctx, querySelectMappings, source, pq.Array(sourceIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	importedIDs := make(map[int64]int64, len(sourceIDs))
+
+	defer rows.Close()
+	for rows.Next() {
+		var sourceID, targetID int64
+		if err := rows.Scan(&sourceID, &targetID); err != nil {
+			return nil, err
+		}
+
+		importedIDs[sourceID] = targetID
+	}
+
+	return importedIDs, rows.Err()
+}
+
+// ImportPosts adds posts of another storage with their IDs in that storage, keeping their create dates and reply depth limits.
+// Posts already imported from the source are skipped, so the import can be repeated. It returns the number of added posts.
+func (databaseAccessor *DatabaseAccessor) ImportPosts(ctx context.Context, source string, posts []*storage.ExportPost) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	importedIDs, err := getImportedIDs(ctx, tx, source, storage.ImportKindPost, getPostIDs(posts))
+	if err != nil {
+		return 0, err
+	}
+
+	posts = slices.DeleteFunc(slices.Clone(posts), func(post *storage.ExportPost) bool {
+		_, ok := importedIDs[post.Post.ID]
+		return ok
+	})
+
+	if len(posts) == 0 {
+		return 0, tx.Commit()
+	}
+
+	postIDs, err := reserveIDs(ctx, tx, "posts", "post_id", len(posts))
+	if err != nil {
+		return 0, err
+	}
+
+	postRows := make([][]any, 0, len(posts))
+	revisionRows := make([][]any, 0, len(posts))
+	tagRows := make([][]any, 0)
+	mappingRows := make([][]any, 0, len(posts))
+	for i, exportPost := range posts {
+		post := exportPost.Post
+		postRows = append(postRows, []any{postIDs[i], post.AuthorID, post.Title, post.Text, post.CreateDate, post.CommentsEnabled,
+			post.ModerationMode, post.Status, post.PublishAt, post.DeletedAt, exportPost.MaxReplyDepth, databaseAccessor.searchLanguage})
+		revisionRows = append(revisionRows, []any{postIDs[i], 1, post.AuthorID, post.Title, post.Text, post.CreateDate})
+		for _, tag := range textrefs.Extract(post.Title, post.Text).Tags {
+			tagRows = append(tagRows, []any{postIDs[i], tag})
+		}
+		mappingRows = append(mappingRows, []any{source, post.ID, postIDs[i]})
+	}
+
+	if err = copyRows(ctx, tx, "posts", []string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled",
+		"moderation_mode", "status", "publish_at", "deleted_at", "max_reply_depth", "search_language"}, postRows); err != nil {
+		return 0, err
+	}
+
+	if err = copyRows(ctx, tx, "post_revisions", []string{"post_id", "revision_number", "editor_id", "title", "text", "create_date"}, revisionRows); err != nil {
+		return 0, err
+	}
+
+	if err = copyRows(ctx, tx, "post_tags", []string{"post_id", "tag"}, tagRows); err != nil {
+		return 0, err
+	}
+
+	if err = copyRows(ctx, tx, "imported_posts", []string{"source", "source_id", "post_id"}, mappingRows); err != nil {
+		return 0, err
+	}
+
+	for i, post := range posts {
+		if err = addMentions(ctx, tx, postIDs[i], nil, textrefs.Extract(post.Post.Title, post.Post.Text).Mentions); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(posts)), nil
+}
+
+// ImportComments adds comments of another storage. IDs of the comments, of their posts and of their parents are IDs
+// in that storage, and the posts and the parents must be imported before the comments or in the same batch.
+// Paths and reply levels are rebuilt from the new IDs and pinned comments stay pinned. Comments already imported
+// from the source are skipped. It returns the number of added comments.
+func (databaseAccessor *DatabaseAccessor) ImportComments(ctx context.Context, source string, comments []*storage.TreeComment) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer tx.Rollback()
+
+	commentIDs := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		commentIDs = append(commentIDs, comment.Comment.ID)
+	}
+
+	importedIDs, err := getImportedIDs(ctx, tx, source, storage.ImportKindComment, commentIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	comments = slices.DeleteFunc(slices.Clone(comments), func(comment *storage.TreeComment) bool {
+		_, ok := importedIDs[comment.Comment.ID]
+		return ok
+	})
+
+	if len(comments) == 0 {
+		return 0, tx.Commit()
+	}
+
+	postIDs := make([]int64, 0, len(comments))
+	parentIDs := make([]int64, 0, len(comments))
+	for _, treeComment := range comments {
+		comment := treeComment.Comment
+		postIDs = append(postIDs, comment.PostID)
+		if comment.ParentID != nil {
+			parentIDs = append(parentIDs, *comment.ParentID)
+		}
+	}
+
+	importedPostIDs, err := getImportedIDs(ctx, tx, source, storage.ImportKindPost, postIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	parents, err := getImportedComments(ctx, tx, source, parentIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	newIDs, err := reserveIDs(ctx, tx, "comments", "comment_id", len(comments))
+	if err != nil {
+		return 0, err
+	}
+
+	commentRows := make([][]any, 0, len(comments))
+	revisionRows := make([][]any, 0, len(comments))
+	mappingRows := make([][]any, 0, len(comments))
+	changedPostIDs := make([]int64, 0, len(comments))
+	for i, treeComment := range comments {
+		comment := treeComment.Comment
+		postID, ok := importedPostIDs[comment.PostID]
+		if !ok {
+			return 0, errors.New("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " was not imported")
+		}
+
+		imported := &importedComment{commentID: newIDs[i], path: strconv.FormatInt(postID, 10)}
+		var parentID *int64
+		if comment.ParentID != nil {
+			parent, ok := parents[*comment.ParentID]
+			if !ok {
+				return 0, errors.New("Parent comment with ID " + strconv.FormatInt(*comment.ParentID, 10) + " was not imported")
+			}
+
+			parentID = &parent.commentID
+			imported.path = parent.path + "." + strconv.FormatInt(parent.commentID, 10)
+			imported.repliesLevel = parent.repliesLevel + 1
+		}
+		parents[comment.ID] = imported
+
+		commentRows = append(commentRows, []any{imported.commentID, comment.AuthorID, postID, parentID, comment.Text, comment.CreateDate,
+			imported.path, imported.repliesLevel, comment.Status, comment.DeletedAt, treeComment.PinnedAt, databaseAccessor.searchLanguage})
+		revisionRows = append(revisionRows, []any{imported.commentID, 1, comment.AuthorID, comment.Text, comment.CreateDate})
+		mappingRows = append(mappingRows, []any{source, comment.ID, imported.commentID})
+		changedPostIDs = append(changedPostIDs, postID)
+	}
+
+	if err = copyRows(ctx, tx, "comments", []string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date",
+		"path", "replies_level", "status", "deleted_at", "pinned_at", "search_language"}, commentRows); err != nil {
+		return 0, err
+	}
+
+	if err = copyRows(ctx, tx, "comment_revisions", []string{"comment_id", "revision_number", "editor_id", "text", "create_date"}, revisionRows); err != nil {
+		return 0, err
+	}
+
+	if err = copyRows(ctx, tx, "imported_comments", []string{"source", "source_id", "comment_id"}, mappingRows); err != nil {
+		return 0, err
+	}
+
+	for i, comment := range comments {
+		if err = addMentions(ctx, tx, importedPostIDs[comment.Comment.PostID], &newIDs[i], textrefs.Extract(comment.Comment.Text).Mentions); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = recountPostComments(ctx, tx, slices.Compact(slices.Sorted(slices.Values(changedPostIDs)))); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return int64(len(comments)), nil
+}
+
+// getImportedComments returns positions of the comments imported from the source, keyed by their IDs in the source.
+func getImportedComments(ctx context.Context, tx queryExecutor, source string, sourceIDs []int64) (map[int64]*importedComment, error) {
+	querySelectComments := `SELECT imported_comments.source_id, comments.comment_id, comments.path, comments.replies_level
+							FROM imported_comments
+							JOIN comments ON comments.comment_id = imported_comments.comment_id
+							WHERE imported_comments.source = $1 AND imported_comments.source_id = ANY($2)`
+
+	rows, err := tx.QueryContext(ctx, querySelectComments, source, pq.Array(sourceIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make(map[int64]*importedComment, len(sourceIDs))
+
+	defer rows.Close()
+	for rows.Next() {
+		var sourceID int64
+		var comment importedComment
+		if err := rows.Scan(&sourceID, &comment.commentID, &comment.path, &comment.repliesLevel); err != nil {
+			return nil, err
+		}
+
+		comments[sourceID] = &comment
+	}
+
+	return comments, rows.Err()
+}
+
+// reserveIDs takes count values of the sequence of the column, so rows referencing each other can be copied together.
+func reserveIDs(ctx context.Context, tx queryExecutor, table string, column string, count int) ([]int64, error) {
+	querySelectIDs := `SELECT nextval(pg_get_serial_sequence($1, $2))
+						FROM generate_series(1, $3)`
+
+	rows, err := tx.QueryContext(ctx, querySelectIDs, table, column, count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, count)
+
+	defer rows.Close()
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(ids) != count {
+		return nil, errors.New("Only " + strconv.Itoa(len(ids)) + " of " + strconv.Itoa(count) + " IDs were reserved in " + table)
+	}
+
+	return ids, nil
+}
+
+// copyRows inserts the rows into the table with COPY, which is much faster than separate INSERT statements.
+func copyRows(ctx context.Context, tx transaction, table string, columns []string, rows [][]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
+
+// recountPostComments recalculates comment counters of the posts and of their comments.
+func recountPostComments(ctx context.Context, tx queryExecutor, postIDs []int64) error {
+	queryUpdatePosts := `UPDATE posts SET comment_count = counts.comment_count, root_comment_count = counts.root_comment_count
+						FROM (
+							SELECT posts.post_id,
+								COUNT(comments.comment_id) AS comment_count,
+								COUNT(comments.comment_id) FILTER (WHERE comments.parent_id IS NULL) AS root_comment_count
+							FROM posts
+							LEFT JOIN comments ON comments.post_id = posts.post_id AND comments.status = $1 AND comments.deleted_at IS NULL
+							WHERE posts.post_id = ANY($2)
+							GROUP BY posts.post_id
+						) AS counts
+						WHERE posts.post_id = counts.post_id`
+
+	if _, err := tx.ExecContext(ctx, queryUpdatePosts, model.CommentStatusApproved, pq.Array(postIDs)); err != nil {
+		return err
+	}
+
+	queryUpdateComments := `UPDATE comments SET reply_count = counts.reply_count
+							FROM (
+								SELECT parents.comment_id, COUNT(replies.comment_id) AS reply_count
+								FROM comments AS parents
+								LEFT JOIN comments AS replies ON replies.parent_id = parents.comment_id AND replies.status = $1 AND replies.deleted_at IS NULL
+								WHERE parents.post_id = ANY($2)
+								GROUP BY parents.comment_id
+							) AS counts
+							WHERE comments.comment_id = counts.comment_id AND comments.reply_count <> counts.reply_count`
+
+	_, err := tx.ExecContext(ctx, queryUpdateComments, model.CommentStatusApproved, pq.Array(postIDs))
+	return err
+}
+
+func getPostIDs(posts []*storage.ExportPost) []int64 {
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.Post.ID)
+	}
+
+	return postIDs
+}
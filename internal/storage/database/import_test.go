@@ -0,0 +1,179 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	createDate := time.Now().Add(-24 * time.Hour)
+
+	t.Run("Successful Import Posts", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		maxReplyDepth := int32(2)
+		posts := []*storage.ExportPost{
+			{Post: &model.Post{ID: 10, AuthorID: authorID, Title: "Imported", Text: "Text", CreateDate: createDate, CommentsEnabled: true,
+				ModerationMode: model.ModerationModeOpen, Status: model.PostStatusPublished}, MaxReplyDepth: &maxReplyDepth},
+			{Post: &model.Post{ID: 11, AuthorID: authorID, Title: "Skipped", Text: "Text", CreateDate: createDate, CommentsEnabled: true,
+				ModerationMode: model.ModerationModeOpen, Status: model.PostStatusPublished}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT source_id, post_id
+							FROM imported_posts
+							WHERE source = \$1 AND source_id = ANY\(\$2\)`).
+			WithArgs("test", pq.Array([]int64{10, 11})).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "post_id"}).AddRow(int64(11), int64(4)))
+		mock.ExpectQuery(`SELECT nextval\(pg_get_serial_sequence\(\$1, \$2\)\)
+							FROM generate_series\(1, \$3\)`).
+			WithArgs("posts", "post_id", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(7)))
+
+		copyPosts := mock.ExpectPrepare(`COPY "posts" \("post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "status", "publish_at", "deleted_at", "max_reply_depth", "search_language"\) FROM STDIN`)
+		copyPosts.ExpectExec().
+			WithArgs(int64(7), authorID, "Imported", "Text", createDate, true, model.ModerationModeOpen, model.PostStatusPublished, nil, nil, maxReplyDepth, testSearchLanguage).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		copyPosts.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+
+		copyRevisions := mock.ExpectPrepare(`COPY "post_revisions"`)
+		copyRevisions.ExpectExec().
+			WithArgs(int64(7), 1, authorID, "Imported", "Text", createDate).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		copyRevisions.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+
+		copyMappings := mock.ExpectPrepare(`COPY "imported_posts" \("source", "source_id", "post_id"\) FROM STDIN`)
+		copyMappings.ExpectExec().
+			WithArgs("test", int64(10), int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		copyMappings.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		imported, err := mockAccessor.ImportPosts(ctx, "test", posts)
+		assertions.Nil(err)
+		assertions.Equal(int64(1), imported)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Import Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		parentID, replyID := int64(20), int64(21)
+		comments := []*storage.TreeComment{
+			{Comment: &model.Comment{ID: 21, AuthorID: authorID, PostID: 10, ParentID: &parentID, Text: "Reply", CreateDate: createDate, Status: model.CommentStatusApproved}},
+			{Comment: &model.Comment{ID: 22, AuthorID: authorID, PostID: 10, ParentID: &replyID, Text: "Nested", CreateDate: createDate, Status: model.CommentStatusApproved}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT source_id, comment_id
+							FROM imported_comments`).
+			WithArgs("test", pq.Array([]int64{21, 22})).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "comment_id"}))
+		mock.ExpectQuery(`SELECT source_id, post_id
+							FROM imported_posts`).
+			WithArgs("test", pq.Array([]int64{10, 10})).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "post_id"}).AddRow(int64(10), int64(7)))
+		mock.ExpectQuery(`SELECT imported_comments.source_id, comments.comment_id, comments.path, comments.replies_level
+							FROM imported_comments
+							JOIN comments ON comments.comment_id = imported_comments.comment_id`).
+			WithArgs("test", pq.Array([]int64{20, 21})).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "comment_id", "path", "replies_level"}).AddRow(int64(20), int64(30), "7", 0))
+		mock.ExpectQuery(`SELECT nextval`).
+			WithArgs("comments", "comment_id", 2).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(31)).AddRow(int64(32)))
+
+		copyComments := mock.ExpectPrepare(`COPY "comments"`)
+		copyComments.ExpectExec().
+			WithArgs(int64(31), authorID, int64(7), int64(30), "Reply", createDate, "7.30", 1, model.CommentStatusApproved, nil, nil, testSearchLanguage).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		copyComments.ExpectExec().
+			WithArgs(int64(32), authorID, int64(7), int64(31), "Nested", createDate, "7.30.31", 2, model.CommentStatusApproved, nil, nil, testSearchLanguage).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		copyComments.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 2))
+
+		copyRevisions := mock.ExpectPrepare(`COPY "comment_revisions"`)
+		copyRevisions.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		copyRevisions.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 1))
+		copyRevisions.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 2))
+
+		copyMappings := mock.ExpectPrepare(`COPY "imported_comments"`)
+		copyMappings.ExpectExec().WithArgs("test", int64(21), int64(31)).WillReturnResult(sqlmock.NewResult(0, 1))
+		copyMappings.ExpectExec().WithArgs("test", int64(22), int64(32)).WillReturnResult(sqlmock.NewResult(0, 1))
+		copyMappings.ExpectExec().WillReturnResult(sqlmock.NewResult(0, 2))
+
+		mock.ExpectExec(`UPDATE posts SET comment_count = counts.comment_count`).
+			WithArgs(model.CommentStatusApproved, pq.Array([]int64{7})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE comments SET reply_count = counts.reply_count`).
+			WithArgs(model.CommentStatusApproved, pq.Array([]int64{7})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		imported, err := mockAccessor.ImportComments(ctx, "test", comments)
+		assertions.Nil(err)
+		assertions.Equal(int64(2), imported)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Import Comments Post Not Imported", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		comments := []*storage.TreeComment{
+			{Comment: &model.Comment{ID: 20, AuthorID: authorID, PostID: 10, Text: "Root", CreateDate: createDate, Status: model.CommentStatusApproved}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT source_id, comment_id`).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "comment_id"}))
+		mock.ExpectQuery(`SELECT source_id, post_id`).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "post_id"}))
+		mock.ExpectQuery(`SELECT imported_comments.source_id`).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "comment_id", "path", "replies_level"}))
+		mock.ExpectQuery(`SELECT nextval`).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(31)))
+		mock.ExpectRollback()
+
+		imported, err := mockAccessor.ImportComments(ctx, "test", comments)
+		assertions.NotNil(err)
+		assertions.Equal(int64(0), imported)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Import Posts Copy Error", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		posts := []*storage.ExportPost{
+			{Post: &model.Post{ID: 10, AuthorID: authorID, Title: "Imported", Text: "Text", CreateDate: createDate, Status: model.PostStatusPublished}},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT source_id, post_id`).
+			WillReturnRows(sqlmock.NewRows([]string{"source_id", "post_id"}))
+		mock.ExpectQuery(`SELECT nextval`).
+			WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(int64(7)))
+		mock.ExpectPrepare(`COPY "posts"`).
+			WillReturnError(errors.New("Test"))
+		mock.ExpectRollback()
+
+		imported, err := mockAccessor.ImportPosts(ctx, "test", posts)
+		assertions.NotNil(err)
+		assertions.Equal(int64(0), imported)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// addReplyNotification notifies the author of the parent comment, or the author of the post for root comments,
+// in the same transaction the comment becomes visible in. Users replying to themselves are not notified.
+func addReplyNotification(ctx context.Context, tx queryExecutor, comment *model.Comment) error {
+	queryInsertNotification := `INSERT INTO notifications (user_id, kind, post_id, comment_id, actor_id, create_date)
+								SELECT COALESCE(parents.author_id, posts.author_id), $1, posts.post_id, $2, $3, $4
+								FROM posts
+								LEFT JOIN comments AS parents ON parents.comment_id = $5
+								WHERE posts.post_id = $6 AND COALESCE(parents.author_id, posts.author_id) <> $3`
+
+	_, err := tx.ExecContext(ctx, queryInsertNotification,
+		model.NotificationKindReply,
+		comment.ID,
+		comment.AuthorID,
+		time.Now(),
+		comment.ParentID,
+		comment.PostID)
+
+	return err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, after *cursor.NotificationCursor, limit *int32) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{userID}
+	querySelectNotifications := `SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE user_id = $1`
+	if unreadOnly {
+		querySelectNotifications += ` AND read_at IS NULL`
+	}
+	if after != nil {
+		querySelectNotifications += ` AND notification_id < ` + args.add(after.NotificationID)
+	}
+	querySelectNotifications += `
+								ORDER BY notification_id DESC`
+	if limit != nil {
+		querySelectNotifications += `
+								LIMIT ` + args.add(*limit)
+	}
+
+	return databaseAccessor.queryNotifications(ctx, querySelectNotifications, args...)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetCommentNotifications(ctx context.Context, commentID int64) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectNotifications := `SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE comment_id = $1
+								ORDER BY notification_id`
+
+	return databaseAccessor.queryNotifications(ctx, querySelectNotifications, commentID)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetPostNotifications(ctx context.Context, postID int64) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectNotifications := `SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE post_id = $1 AND comment_id IS NULL
+								ORDER BY notification_id`
+
+	return databaseAccessor.queryNotifications(ctx, querySelectNotifications, postID)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetRevisionNotifications(ctx context.Context, postID int64, commentID *int64, revisionNumber int32) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectNotifications := `SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE post_id = $1 AND comment_id IS NOT DISTINCT FROM $2 AND revision_number = $3
+								ORDER BY notification_id`
+
+	return databaseAccessor.queryNotifications(ctx, querySelectNotifications, postID, commentID, revisionNumber)
+}
+
+func (databaseAccessor *DatabaseAccessor) queryNotifications(ctx context.Context, query string, args ...any) ([]*model.Notification, error) {
+	rows, err := databaseAccessor.storage.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*model.Notification, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var notification model.Notification
+		if err = rows.Scan(&notification.ID,
+			&notification.UserID,
+			&notification.Kind,
+			&notification.PostID,
+			&notification.CommentID,
+			&notification.ActorID,
+			&notification.CreateDate,
+			&notification.Read); err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, &notification)
+	}
+
+	return notifications, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, notificationIDs []int64) (int32, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{time.Now(), userID}
+	queryUpdateNotifications := `UPDATE notifications SET read_at = $1
+								WHERE user_id = $2 AND read_at IS NULL`
+	if notificationIDs != nil {
+		queryUpdateNotifications += ` AND notification_id = ANY(` + args.add(pq.Array(notificationIDs)) + `)`
+	}
+
+	result, err := databaseAccessor.storage.ExecContext(ctx, queryUpdateNotifications, args...)
+
+	if err != nil {
+		return 0, err
+	}
+
+	marked, err := result.RowsAffected()
+
+	return int32(marked), err
+}
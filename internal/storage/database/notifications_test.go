@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifications(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+	actorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Get Unread Notifications After Cursor", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		limit := int32(2)
+
+		mock.ExpectQuery(`SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE user_id = \$1 AND read_at IS NULL AND notification_id < \$2
+								ORDER BY notification_id DESC
+								LIMIT \$3`).
+			WithArgs(userID, int64(10), limit).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"notification_id", "user_id", "kind", "post_id", "comment_id", "actor_id", "create_date", "read"}).
+				AddRow(int64(9), userID, "REPLY", int64(1), int64(4), actorID, time.Now(), false))
+
+		notifications, err := mockAccessor.GetNotifications(ctx, userID, true, &cursor.NotificationCursor{NotificationID: 10}, &limit)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(model.NotificationKindReply, notifications[0].Kind)
+		assertions.Equal(actorID, notifications[0].ActorID)
+	})
+
+	t.Run("Successful Get Comment Notifications", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE comment_id = \$1
+								ORDER BY notification_id`).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"notification_id", "user_id", "kind", "post_id", "comment_id", "actor_id", "create_date", "read"}).
+				AddRow(int64(9), userID, "REPLY", int64(1), int64(4), actorID, time.Now(), false))
+
+		notifications, err := mockAccessor.GetCommentNotifications(ctx, 4)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+	})
+
+	t.Run("Successful Get Revision Notifications", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		commentID := int64(4)
+		mock.ExpectQuery(`SELECT notification_id, user_id, kind, post_id, comment_id, actor_id, create_date, read_at IS NOT NULL
+								FROM notifications
+								WHERE post_id = \$1 AND comment_id IS NOT DISTINCT FROM \$2 AND revision_number = \$3
+								ORDER BY notification_id`).
+			WithArgs(int64(1), &commentID, int32(2)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"notification_id", "user_id", "kind", "post_id", "comment_id", "actor_id", "create_date", "read"}).
+				AddRow(int64(10), userID, "MENTION", int64(1), int64(4), actorID, time.Now(), false))
+
+		notifications, err := mockAccessor.GetRevisionNotifications(ctx, 1, &commentID, 2)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(model.NotificationKindMention, notifications[0].Kind)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Mark Notifications Read", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`UPDATE notifications SET read_at = \$1
+								WHERE user_id = \$2 AND read_at IS NULL AND notification_id = ANY\(\$3\)`).
+			WithArgs(AnyTime{}, userID, pq.Array([]int64{1, 2})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		marked, err := mockAccessor.MarkNotificationsRead(ctx, userID, []int64{1, 2})
+		assertions.Nil(err)
+		assertions.Equal(int32(2), marked)
+	})
+
+	t.Run("Unsuccessful Mark All Notifications Read", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`UPDATE notifications SET read_at = \$1
+								WHERE user_id = \$2 AND read_at IS NULL`).
+			WithArgs(AnyTime{}, userID).
+			WillReturnError(errors.New("Test Error"))
+
+		marked, err := mockAccessor.MarkNotificationsRead(ctx, userID, nil)
+		assertions.NotNil(err)
+		assertions.Zero(marked)
+	})
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// outboxLockKey is the key of the advisory lock which serializes claims of events by dispatchers.
+const outboxLockKey = 7132504865
+
+// AddEvent stores the event in the outbox. Called through an accessor from WithTransaction,
+// it is committed together with the change it describes.
+func (databaseAccessor *DatabaseAccessor) AddEvent(ctx context.Context, envelope *events.Envelope) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	envelope.CreateDate = time.Now()
+
+	queryInsertEvent := `INSERT INTO outbox (kind, payload, create_date)
+						VALUES ($1, $2, $3)
+						RETURNING event_id`
+
+	return databaseAccessor.storage.QueryRowContext(ctx, queryInsertEvent,
+		envelope.Kind,
+		string(envelope.Payload),
+		envelope.CreateDate).Scan(&envelope.ID)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetPendingEvents(ctx context.Context, limit int32) ([]*events.Envelope, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectEvents := `SELECT event_id, kind, payload, create_date
+						FROM outbox
+						WHERE dispatch_date IS NULL
+						ORDER BY event_id
+						LIMIT $1`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectEvents, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanEvents(rows)
+}
+
+// ClaimPendingEvents leases the first pending events to the owner until leaseUntil. While another owner holds
+// an active lease, nothing is claimed, so only one dispatcher of all instances delivers at a time and events keep
+// their order. Claims are serialized by an advisory lock. Events of a dispatcher that stopped are claimed again
+// after the lease.
+func (databaseAccessor *DatabaseAccessor) ClaimPendingEvents(ctx context.Context, ownerID uuid.UUID, now time.Time, leaseUntil time.Time, limit int32) ([]*events.Envelope, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, outboxLockKey); err != nil {
+		return nil, err
+	}
+
+	queryClaimEvents := `WITH claimed AS (
+							UPDATE outbox SET lease_owner = $1, lease_until = $3
+							WHERE event_id IN (
+								SELECT event_id
+								FROM outbox
+								WHERE dispatch_date IS NULL
+								ORDER BY event_id
+								LIMIT $4)
+							AND NOT EXISTS (
+								SELECT 1
+								FROM outbox
+								WHERE dispatch_date IS NULL AND lease_owner <> $1 AND lease_until > $2)
+							RETURNING event_id, kind, payload, create_date)
+						SELECT event_id, kind, payload, create_date
+						FROM claimed
+						ORDER BY event_id`
+
+	rows, err := tx.QueryContext(ctx, queryClaimEvents, ownerID, now, leaseUntil, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	envelopes, err := scanEvents(rows)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return envelopes, nil
+}
+
+// scanEvents reads events from the rows and closes them.
+func scanEvents(rows *sql.Rows) ([]*events.Envelope, error) {
+	envelopes := make([]*events.Envelope, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var envelope events.Envelope
+		var payload []byte
+		if err := rows.Scan(&envelope.ID, &envelope.Kind, &payload, &envelope.CreateDate); err != nil {
+			return nil, err
+		}
+
+		envelope.Payload = payload
+		envelopes = append(envelopes, &envelope)
+	}
+
+	return envelopes, rows.Err()
+}
+
+func (databaseAccessor *DatabaseAccessor) MarkEventsDispatched(ctx context.Context, eventIDs []int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	queryUpdateEvents := `UPDATE outbox SET dispatch_date = $1
+						WHERE event_id = ANY($2)`
+
+	_, err := databaseAccessor.storage.ExecContext(ctx, queryUpdateEvents, time.Now(), pq.Array(eventIDs))
+	return err
+}
@@ -0,0 +1,225 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutbox(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("Successful Add Event", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		envelope := &events.Envelope{Kind: events.KindCommentsToggled, Payload: []byte(`{"postID":1}`)}
+
+		mock.ExpectQuery(`INSERT INTO outbox \(kind, payload, create_date\)
+						VALUES \(\$1, \$2, \$3\)
+						RETURNING event_id`).
+			WithArgs(events.KindCommentsToggled, `{"postID":1}`, AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow(int64(5)))
+
+		err := mockAccessor.AddEvent(ctx, envelope)
+		assertions.Nil(err)
+		assertions.Equal(int64(5), envelope.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Pending Events", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT event_id, kind, payload, create_date
+						FROM outbox
+						WHERE dispatch_date IS NULL
+						ORDER BY event_id
+						LIMIT \$1`).
+			WithArgs(int32(10)).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "kind", "payload", "create_date"}).
+				AddRow(int64(1), "POST_CREATED", []byte(`{"postID":1}`), time.Now()).
+				AddRow(int64(2), "COMMENTS_TOGGLED", []byte(`{"postID":1}`), time.Now()))
+
+		envelopes, err := mockAccessor.GetPendingEvents(ctx, 10)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 2)
+		assertions.Equal(events.KindPostCreated, envelopes[0].Kind)
+		assertions.Equal(int64(2), envelopes[1].ID)
+		assertions.JSONEq(`{"postID":1}`, string(envelopes[1].Payload))
+	})
+
+	t.Run("Successful Claim Pending Events", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		ownerID := uuid.New()
+		now := time.Now()
+		leaseUntil := now.Add(time.Minute)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_advisory_xact_lock\(\$1\)`).
+			WithArgs(outboxLockKey).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`UPDATE outbox SET lease_owner = \$1, lease_until = \$3
+							WHERE event_id IN \(
+								SELECT event_id
+								FROM outbox
+								WHERE dispatch_date IS NULL
+								ORDER BY event_id
+								LIMIT \$4\)
+							AND NOT EXISTS \(
+								SELECT 1
+								FROM outbox
+								WHERE dispatch_date IS NULL AND lease_owner <> \$1 AND lease_until > \$2\)`).
+			WithArgs(ownerID, now, leaseUntil, int32(10)).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "kind", "payload", "create_date"}).
+				AddRow(int64(1), "POST_CREATED", []byte(`{"postID":1}`), time.Now()))
+		mock.ExpectCommit()
+
+		envelopes, err := mockAccessor.ClaimPendingEvents(ctx, ownerID, now, leaseUntil, 10)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 1)
+		assertions.Equal(int64(1), envelopes[0].ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Claim Pending Events leased by another owner", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`SELECT pg_advisory_xact_lock`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`UPDATE outbox SET lease_owner`).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id", "kind", "payload", "create_date"}))
+		mock.ExpectCommit()
+
+		envelopes, err := mockAccessor.ClaimPendingEvents(ctx, uuid.New(), time.Now(), time.Now().Add(time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Empty(envelopes)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Mark Events Dispatched", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`UPDATE outbox SET dispatch_date = \$1
+						WHERE event_id = ANY\(\$2\)`).
+			WithArgs(AnyTime{}, pq.Array([]int64{1, 2})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := mockAccessor.MarkEventsDispatched(ctx, []int64{1, 2})
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
+
+func TestWithTransaction(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful With Transaction", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO posts`).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+		mock.ExpectQuery(`INSERT INTO post_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectQuery(`INSERT INTO outbox`).
+			WithArgs(events.KindPostCreated, sqlmock.AnyArg(), AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow(int64(1)))
+		mock.ExpectCommit()
+
+		err := mockAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+			post, err := accessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+			if err != nil {
+				return err
+			}
+
+			envelope, err := events.NewEnvelope(events.NewPostCreated(post))
+			if err != nil {
+				return err
+			}
+
+			return accessor.AddEvent(ctx, envelope)
+		})
+
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful With Transaction joins nested transaction", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status`).
+			WithArgs(int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "parent_id", "author_id", "status"}).
+				AddRow(int64(1), nil, authorID, model.CommentStatusPending))
+		mock.ExpectQuery(`UPDATE comments SET status = \$1`).
+			WithArgs(model.CommentStatusRejected, int64(4)).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(4), uuid.New(), int64(1), nil, "Comment", time.Now(), model.CommentStatusRejected, int32(0)))
+		mock.ExpectQuery(`INSERT INTO outbox`).
+			WithArgs(events.KindCommentStatusChanged, sqlmock.AnyArg(), AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"event_id"}).AddRow(int64(1)))
+		mock.ExpectCommit()
+
+		err := mockAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+			comment, err := accessor.UpdateCommentStatus(ctx, 4, authorID, model.CommentStatusRejected)
+			if err != nil {
+				return err
+			}
+
+			envelope, err := events.NewEnvelope(events.NewCommentStatusChanged(comment))
+			if err != nil {
+				return err
+			}
+
+			return accessor.AddEvent(ctx, envelope)
+		})
+
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful With Transaction rolls back", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO posts`).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+		mock.ExpectQuery(`INSERT INTO post_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectRollback()
+
+		expectedErr := errors.New("Event was not stored")
+		err := mockAccessor.WithTransaction(ctx, func(accessor storage.Accessor) error {
+			if _, err := accessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true}); err != nil {
+				return err
+			}
+
+			return expectedErr
+		})
+
+		assertions.Equal(expectedErr, err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
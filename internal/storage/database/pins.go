@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// pinnableComment is the state of a comment needed to pin or unpin it.
+type pinnableComment struct {
+	postID   int64
+	parentID *int64
+	status   model.CommentStatus
+	pinned   bool
+}
+
+// PinComment pins the root comment to the top of its post. Only the author of the post may pin comments,
+// and the post may have no more than maxPinned pinned comments.
+func (databaseAccessor *DatabaseAccessor) PinComment(ctx context.Context, commentID int64, authorID uuid.UUID, maxPinned int32) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	// The post row is locked, so concurrent pins of the same post cannot exceed the limit together.
+	comment, err := getPinnableComment(ctx, tx, commentID, authorID, `FOR UPDATE OF posts`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.parentID != nil {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is a reply, only root comments can be pinned.")
+	}
+
+	if comment.status != model.CommentStatusApproved {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not approved.")
+	}
+
+	if comment.pinned {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is already pinned.")
+	}
+
+	querySelectCount := `SELECT COUNT(*)
+						FROM comments
+						WHERE post_id = $1 AND pinned_at IS NOT NULL`
+
+	var pinnedCount int32
+	if err = tx.QueryRowContext(ctx, querySelectCount, comment.postID).Scan(&pinnedCount); err != nil {
+		return nil, err
+	}
+
+	if pinnedCount >= maxPinned {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(comment.postID, 10) + " already has " + strconv.Itoa(int(maxPinned)) + " pinned comments.")
+	}
+
+	queryUpdateComment := `UPDATE comments SET pinned_at = $1
+							WHERE comment_id = $2
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+
+	pinned, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, time.Now(), commentID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return pinned, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) UnpinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	comment, err := getPinnableComment(ctx, tx, commentID, authorID, `FOR UPDATE OF comments`)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !comment.pinned {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not pinned.")
+	}
+
+	queryUpdateComment := `UPDATE comments SET pinned_at = NULL
+							WHERE comment_id = $1
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+
+	unpinned, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, commentID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return unpinned, nil
+}
+
+// GetPinnedComments returns pinned comments of the post in the order they were pinned.
+func (databaseAccessor *DatabaseAccessor) GetPinnedComments(ctx context.Context, postID int64) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectComments := `SELECT comments.comment_id, comments.author_id, comments.post_id, comments.parent_id, comments.text, comments.create_date, comments.status, comments.reply_count
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.post_id = $1 AND comments.pinned_at IS NOT NULL AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							ORDER BY comments.pinned_at, comments.comment_id`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, postID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*model.Comment, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+// GetCommentsPinned reports which of the comments are pinned. Comments which are not pinned may be missing from the map.
+func (databaseAccessor *DatabaseAccessor) GetCommentsPinned(ctx context.Context, commentIDs []int64) (map[int64]bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectPinned := `SELECT comment_id
+							FROM comments
+							WHERE comment_id = ANY($1) AND pinned_at IS NOT NULL`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPinned, pq.Array(commentIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := make(map[int64]bool, len(commentIDs))
+
+	defer rows.Close()
+	for rows.Next() {
+		var commentID int64
+		if err = rows.Scan(&commentID); err != nil {
+			return nil, err
+		}
+
+		pinned[commentID] = true
+	}
+
+	return pinned, nil
+}
+
+// getPinnableComment locks and returns the comment if it exists and the user is the author of its post.
+func getPinnableComment(ctx context.Context, tx queryExecutor, commentID int64, authorID uuid.UUID, lock string) (*pinnableComment, error) {
+	querySelectComment := `SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status, comments.pinned_at IS NOT NULL
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = $1 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							` + lock
+
+	var comment pinnableComment
+	var postAuthorID uuid.UUID
+	err := tx.QueryRowContext(ctx, querySelectComment, commentID).Scan(&comment.postID, &comment.parentID, &postAuthorID, &comment.status, &comment.pinned)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if postAuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(comment.postID, 10) + ".")
+	}
+
+	return &comment, nil
+}
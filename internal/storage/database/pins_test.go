@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPins(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	createDate := time.Now()
+
+	commentColumns := []string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}
+	pinnableColumns := []string{"post_id", "parent_id", "author_id", "status", "pinned"}
+
+	t.Run("Successful Pin Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id, comments.parent_id, posts.author_id, comments.status, comments.pinned_at IS NOT NULL
+							FROM comments
+							JOIN posts ON posts.post_id = comments.post_id
+							WHERE comments.comment_id = \$1 AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							FOR UPDATE OF posts`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), nil, authorID, model.CommentStatusApproved, false))
+		mock.ExpectQuery(`SELECT COUNT\(\*\)
+							FROM comments
+							WHERE post_id = \$1 AND pinned_at IS NOT NULL`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int32(1)))
+		mock.ExpectQuery(`UPDATE comments SET pinned_at = \$1
+							WHERE comment_id = \$2`).
+			WithArgs(AnyTime{}, int64(5)).
+			WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(int64(5), uuid.New(), int64(1), nil, "Comment", createDate, model.CommentStatusApproved, int32(0)))
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.PinComment(ctx, 5, authorID, 2)
+		assertions.Nil(err)
+		assertions.Equal(int64(5), comment.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Pin Comment Limit Reached", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), nil, authorID, model.CommentStatusApproved, false))
+		mock.ExpectQuery(`SELECT COUNT\(\*\)`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(int32(2)))
+		mock.ExpectRollback()
+
+		comment, err := mockAccessor.PinComment(ctx, 5, authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Pin Comment Not Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), nil, uuid.New(), model.CommentStatusApproved, false))
+		mock.ExpectRollback()
+
+		comment, err := mockAccessor.PinComment(ctx, 5, authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Pin Reply", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), int64(4), authorID, model.CommentStatusApproved, false))
+		mock.ExpectRollback()
+
+		comment, err := mockAccessor.PinComment(ctx, 5, authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Pin Comment Not Found", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id`).
+			WithArgs(int64(5)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		comment, err := mockAccessor.PinComment(ctx, 5, authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Unpin Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id.+FOR UPDATE OF comments`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), nil, authorID, model.CommentStatusApproved, true))
+		mock.ExpectQuery(`UPDATE comments SET pinned_at = NULL
+							WHERE comment_id = \$1`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(commentColumns).AddRow(int64(5), uuid.New(), int64(1), nil, "Comment", createDate, model.CommentStatusApproved, int32(0)))
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.UnpinComment(ctx, 5, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int64(5), comment.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Unpin Comment Not Pinned", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT comments.post_id`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows(pinnableColumns).AddRow(int64(1), nil, authorID, model.CommentStatusApproved, false))
+		mock.ExpectRollback()
+
+		comment, err := mockAccessor.UnpinComment(ctx, 5, authorID)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Pinned Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`WHERE comments.post_id = \$1 AND comments.pinned_at IS NOT NULL AND comments.deleted_at IS NULL AND posts.deleted_at IS NULL
+							ORDER BY comments.pinned_at, comments.comment_id`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows(commentColumns).
+				AddRow(int64(7), uuid.New(), int64(1), nil, "Second", createDate, model.CommentStatusApproved, int32(0)).
+				AddRow(int64(3), uuid.New(), int64(1), nil, "First", createDate, model.CommentStatusApproved, int32(2)))
+
+		comments, err := mockAccessor.GetPinnedComments(ctx, 1)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(int64(7), comments[0].ID)
+		assertions.Equal(int64(3), comments[1].ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Comments Pinned", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id
+							FROM comments
+							WHERE comment_id = ANY\(\$1\) AND pinned_at IS NOT NULL`).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id"}).AddRow(int64(3)))
+
+		pinned, err := mockAccessor.GetCommentsPinned(ctx, []int64{3, 4})
+		assertions.Nil(err)
+		assertions.True(pinned[3])
+		assertions.False(pinned[4])
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
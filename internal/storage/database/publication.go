@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// PublishPost publishes the draft right away or, if publishAt is in the future, schedules its publication.
+func (databaseAccessor *DatabaseAccessor) PublishPost(ctx context.Context, postID int64, authorID uuid.UUID, publishAt *time.Time) (*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	querySelectPost := `SELECT author_id, status
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL
+						FOR UPDATE`
+
+	var postAuthorID uuid.UUID
+	var status model.PostStatus
+	err = tx.QueryRowContext(ctx, querySelectPost, postID).Scan(&postAuthorID, &status)
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if postAuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	if status == model.PostStatusPublished {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " is already published.")
+	}
+
+	now := time.Now()
+	newStatus := model.PostStatusPublished
+	newStatus, newPublishAt := helpers.GetPostPublication(&newStatus, publishAt, now)
+
+	// Posts are ordered by the create date, so a published draft gets the date of its publication
+	// to appear first in lists and feeds instead of behind the cursors clients already passed.
+	var newCreateDate *time.Time
+	if newStatus == model.PostStatusPublished {
+		newCreateDate = &now
+	}
+
+	queryUpdatePost := `UPDATE posts SET status = $1, publish_at = $2, create_date = COALESCE($3, create_date)
+						WHERE post_id = $4
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+
+	post, err := scanPost(tx.QueryRowContext(ctx, queryUpdatePost, newStatus, newPublishAt, newCreateDate, postID))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Status == model.PostStatusPublished {
+		if err = addMentionNotifications(ctx, tx, post.ID, nil, post.AuthorID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+// PublishDuePosts publishes up to limit drafts scheduled no later than now, the earliest first.
+// Locked rows are skipped, so several instances of the service can run the scheduler at once.
+// Published posts get now as the create date, like posts published with PublishPost.
+func (databaseAccessor *DatabaseAccessor) PublishDuePosts(ctx context.Context, now time.Time, limit int32) ([]*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback()
+
+	queryUpdatePosts := `UPDATE posts SET status = $1, create_date = $3
+						WHERE post_id IN (
+							SELECT post_id
+							FROM posts
+							WHERE status = $2 AND publish_at <= $3 AND deleted_at IS NULL
+							ORDER BY publish_at, post_id
+							LIMIT $4
+							FOR UPDATE SKIP LOCKED
+						)
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+
+	rows, err := tx.QueryContext(ctx, queryUpdatePosts, model.PostStatusPublished, model.PostStatusDraft, now, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]*model.Post, 0)
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		posts = append(posts, post)
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, post := range posts {
+		if err = addMentionNotifications(ctx, tx, post.ID, nil, post.AuthorID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublication(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	now := time.Now()
+
+	postColumns := []string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}
+
+	t.Run("Successful Publish Post", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id, status
+						FROM posts
+						WHERE post_id = \$1 AND deleted_at IS NULL
+						FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status"}).AddRow(authorID, model.PostStatusDraft))
+		mock.ExpectQuery(`UPDATE posts SET status = \$1, publish_at = \$2, create_date = COALESCE\(\$3, create_date\)
+						WHERE post_id = \$4`).
+			WithArgs(model.PostStatusPublished, AnyTime{}, AnyTime{}, int64(1)).
+			WillReturnRows(sqlmock.NewRows(postColumns).AddRow(int64(1), authorID, "Title", "Text", now, true, "OPEN", 0, model.PostStatusPublished, now))
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindMention, authorID, AnyTime{}, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		post, err := mockAccessor.PublishPost(ctx, 1, authorID, nil)
+		assertions.Nil(err)
+		assertions.Equal(model.PostStatusPublished, post.Status)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Schedule Post", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		publishAt := now.Add(time.Hour)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id, status`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status"}).AddRow(authorID, model.PostStatusDraft))
+		mock.ExpectQuery(`UPDATE posts SET status = \$1, publish_at = \$2`).
+			WithArgs(model.PostStatusDraft, publishAt, nil, int64(1)).
+			WillReturnRows(sqlmock.NewRows(postColumns).AddRow(int64(1), authorID, "Title", "Text", now, true, "OPEN", 0, model.PostStatusDraft, publishAt))
+		mock.ExpectCommit()
+
+		post, err := mockAccessor.PublishPost(ctx, 1, authorID, &publishAt)
+		assertions.Nil(err)
+		assertions.Equal(model.PostStatusDraft, post.Status)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Publish Post Already Published", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id, status`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status"}).AddRow(authorID, model.PostStatusPublished))
+		mock.ExpectRollback()
+
+		post, err := mockAccessor.PublishPost(ctx, 1, authorID, nil)
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Publish Post Not Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id, status`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id", "status"}).AddRow(uuid.New(), model.PostStatusDraft))
+		mock.ExpectRollback()
+
+		post, err := mockAccessor.PublishPost(ctx, 1, authorID, nil)
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Publish Due Posts", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`UPDATE posts SET status = \$1, create_date = \$3
+						WHERE post_id IN \(
+							SELECT post_id
+							FROM posts
+							WHERE status = \$2 AND publish_at <= \$3 AND deleted_at IS NULL
+							ORDER BY publish_at, post_id
+							LIMIT \$4
+							FOR UPDATE SKIP LOCKED
+						\)`).
+			WithArgs(model.PostStatusPublished, model.PostStatusDraft, now, int32(10)).
+			WillReturnRows(sqlmock.NewRows(postColumns).
+				AddRow(int64(1), authorID, "Title", "Text", now, true, "OPEN", 0, model.PostStatusPublished, now).
+				AddRow(int64(2), authorID, "Title", "Text", now, true, "OPEN", 0, model.PostStatusPublished, now))
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindMention, authorID, AnyTime{}, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindMention, authorID, AnyTime{}, int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		posts, err := mockAccessor.PublishDuePosts(ctx, now, 10)
+		assertions.Nil(err)
+		assertions.Len(posts, 2)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+)
+
+// queryArgs collects arguments of a dynamically built query and returns their placeholders.
+type queryArgs []any
+
+func (args *queryArgs) add(value any) string {
+	*args = append(*args, value)
+	return "$" + strconv.Itoa(len(*args))
+}
+
+// queryExecutor is implemented by both *sql.DB and *sql.Tx, so queries run the same way in and out of a transaction.
+type queryExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+type transaction interface {
+	queryExecutor
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	Commit() error
+	Rollback() error
+}
+
+// nestedTransaction joins a transaction that is already open. The outer transaction decides
+// whether the changes are committed, so Commit and Rollback do nothing here.
+type nestedTransaction struct {
+	*sql.Tx
+}
+
+func (nestedTransaction) Commit() error {
+	return nil
+}
+
+func (nestedTransaction) Rollback() error {
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanPost reads a post selected as "post_id, author_id, title, text, create_date, comments_enabled,
+// moderation_mode, comment_count, status, publish_at", followed by the extra columns.
+func scanPost(row rowScanner, extra ...any) (*model.Post, error) {
+	var post model.Post
+	dest := append([]any{&post.ID,
+		&post.AuthorID,
+		&post.Title,
+		&post.Text,
+		&post.CreateDate,
+		&post.CommentsEnabled,
+		&post.ModerationMode,
+		&post.CommentCount,
+		&post.Status,
+		&post.PublishAt}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+// scanComment reads a comment selected as "comment_id, author_id, post_id, parent_id, text, create_date,
+// status, reply_count", followed by the extra columns.
+func scanComment(row rowScanner, extra ...any) (*model.Comment, error) {
+	var comment model.Comment
+	dest := append([]any{&comment.ID,
+		&comment.AuthorID,
+		&comment.PostID,
+		&comment.ParentID,
+		&comment.Text,
+		&comment.CreateDate,
+		&comment.Status,
+		&comment.ReplyCount}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// scanUser reads a user selected as "user_id, username, display_name, avatar_url, role, create_date".
+func scanUser(row rowScanner) (*model.User, error) {
+	var user model.User
+	if err := row.Scan(&user.ID, &user.Username, &user.DisplayName, &user.AvatarURL, &user.Role, &user.CreateDate); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (databaseAccessor *DatabaseAccessor) AddReaction(ctx context.Context, reaction *model.ReactionInput) error {
+	err := databaseAccessor.checkReactionTarget(ctx, reaction.TargetType, reaction.TargetID, reaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	queryInsertReaction := `INSERT INTO reactions (target_type, target_id, user_id, kind, create_date)
+							VALUES ($1, $2, $3, $4, $5)
+							ON CONFLICT (target_type, target_id, user_id, kind) DO NOTHING`
+
+	_, err = databaseAccessor.storage.ExecContext(ctx, queryInsertReaction,
+		reaction.TargetType,
+		reaction.TargetID,
+		reaction.UserID,
+		reaction.Kind,
+		time.Now())
+
+	return err
+}
+
+func (databaseAccessor *DatabaseAccessor) DeleteReaction(ctx context.Context, reaction *model.ReactionInput) error {
+	err := databaseAccessor.checkReactionTarget(ctx, reaction.TargetType, reaction.TargetID, reaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	queryDeleteReaction := `DELETE FROM reactions
+							WHERE target_type = $1 AND target_id = $2 AND user_id = $3 AND kind = $4`
+
+	_, err = databaseAccessor.storage.ExecContext(ctx, queryDeleteReaction,
+		reaction.TargetType,
+		reaction.TargetID,
+		reaction.UserID,
+		reaction.Kind)
+
+	return err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64) (map[int64][]*model.ReactionCount, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectCounts := `SELECT target_id, kind, COUNT(*)
+							FROM reactions
+							WHERE target_type = $1 AND target_id = ANY($2)
+							GROUP BY target_id, kind`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectCounts, targetType, pq.Array(targetIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]map[model.ReactionKind]int32, len(targetIDs))
+	for _, targetID := range targetIDs {
+		counts[targetID] = make(map[model.ReactionKind]int32)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var targetID int64
+		var kind model.ReactionKind
+		var count int32
+		if err = rows.Scan(&targetID, &kind, &count); err != nil {
+			return nil, err
+		}
+
+		if _, ok := counts[targetID]; ok {
+			counts[targetID][kind] = count
+		}
+	}
+
+	reactionCounts := make(map[int64][]*model.ReactionCount, len(counts))
+	for targetID, targetCounts := range counts {
+		reactionCounts[targetID] = helpers.GetReactionCounts(targetCounts)
+	}
+
+	return reactionCounts, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetViewerReactions(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64, viewerID uuid.UUID) (map[int64][]model.ReactionKind, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectReactions := `SELECT target_id, kind
+							FROM reactions
+							WHERE target_type = $1 AND target_id = ANY($2) AND user_id = $3`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectReactions, targetType, pq.Array(targetIDs), viewerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	kinds := make(map[int64]map[model.ReactionKind]bool, len(targetIDs))
+	defer rows.Close()
+	for rows.Next() {
+		var targetID int64
+		var kind model.ReactionKind
+		if err = rows.Scan(&targetID, &kind); err != nil {
+			return nil, err
+		}
+
+		if _, ok := kinds[targetID]; !ok {
+			kinds[targetID] = make(map[model.ReactionKind]bool)
+		}
+		kinds[targetID][kind] = true
+	}
+
+	viewerReactions := make(map[int64][]model.ReactionKind, len(targetIDs))
+	for _, targetID := range targetIDs {
+		viewerReactions[targetID] = make([]model.ReactionKind, 0)
+		for _, kind := range model.AllReactionKind {
+			if kinds[targetID][kind] {
+				viewerReactions[targetID] = append(viewerReactions[targetID], kind)
+			}
+		}
+	}
+
+	return viewerReactions, nil
+}
+
+// checkReactionTarget reports whether the user may see the target: drafts are visible only to their authors
+// and comments only when they are approved.
+func (databaseAccessor *DatabaseAccessor) checkReactionTarget(ctx context.Context, targetType model.ReactionTarget, targetID int64, userID uuid.UUID) error {
+	var targetName, querySelectTarget string
+	var args []any
+	switch targetType {
+	case model.ReactionTargetPost:
+		targetName = "Post"
+		querySelectTarget = `SELECT post_id
+							FROM posts
+							WHERE post_id = $1 AND deleted_at IS NULL AND (status = $2 OR author_id = $3)`
+		args = []any{targetID, model.PostStatusPublished, userID}
+	case model.ReactionTargetComment:
+		targetName = "Comment"
+		querySelectTarget = `SELECT comment_id
+							FROM comments
+							WHERE comment_id = $1 AND status = $2 AND deleted_at IS NULL`
+		args = []any{targetID, model.CommentStatusApproved}
+	default:
+		return errors.New("Unsupported reaction target: " + targetType.String())
+	}
+
+	var dbTargetID int64
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectTarget, args...).Scan(&dbTargetID)
+
+	if err == sql.ErrNoRows {
+		return storage.NotFound(targetName + " with ID " + strconv.FormatInt(targetID, 10) + " was not found")
+	}
+
+	return err
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactions(t *testing.T) {
+	assertions := assert.New(t)
+	viewerID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Reaction", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		reaction := &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   1,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		}
+
+		mock.ExpectQuery(`SELECT post_id
+							FROM posts
+							WHERE post_id = \$1 AND deleted_at IS NULL AND \(status = \$2 OR author_id = \$3\)`).
+			WithArgs(int64(1), model.PostStatusPublished, viewerID).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+
+		mock.ExpectExec(`INSERT INTO reactions \(target_type, target_id, user_id, kind, create_date\)
+							VALUES \(\$1, \$2, \$3, \$4, \$5\)
+							ON CONFLICT \(target_type, target_id, user_id, kind\) DO NOTHING`).
+			WithArgs(model.ReactionTargetPost, int64(1), viewerID, model.ReactionKindHeart, AnyTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := mockAccessor.AddReaction(ctx, reaction)
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add Reaction Target Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id
+							FROM comments
+							WHERE comment_id = \$1 AND status = \$2 AND deleted_at IS NULL`).
+			WithArgs(int64(123), model.CommentStatusApproved).
+			WillReturnError(sql.ErrNoRows)
+
+		err := mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetComment,
+			TargetID:   123,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.NotNil(err)
+	})
+
+	t.Run("Successful Delete Reaction", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT comment_id
+							FROM comments
+							WHERE comment_id = \$1 AND status = \$2 AND deleted_at IS NULL`).
+			WithArgs(int64(2), model.CommentStatusApproved).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id"}).AddRow(int64(2)))
+
+		mock.ExpectExec(`DELETE FROM reactions
+							WHERE target_type = \$1 AND target_id = \$2 AND user_id = \$3 AND kind = \$4`).
+			WithArgs(model.ReactionTargetComment, int64(2), viewerID, model.ReactionKindSad).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := mockAccessor.DeleteReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetComment,
+			TargetID:   2,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindSad,
+		})
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Reaction Counts", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT target_id, kind, COUNT\(\*\)
+							FROM reactions
+							WHERE target_type = \$1 AND target_id = ANY\(\$2\)
+							GROUP BY target_id, kind`).
+			WithArgs(model.ReactionTargetComment, pq.Array([]int64{0, 1})).
+			WillReturnRows(sqlmock.NewRows([]string{"target_id", "kind", "count"}).
+				AddRow(int64(0), "HEART", int32(2)).
+				AddRow(int64(0), "THUMBS_UP", int32(1)))
+
+		reactionCounts, err := mockAccessor.GetReactionCounts(ctx, model.ReactionTargetComment, []int64{0, 1})
+		assertions.Nil(err)
+		assertions.Equal(map[int64][]*model.ReactionCount{
+			0: {
+				{Kind: model.ReactionKindThumbsUp, Count: 1},
+				{Kind: model.ReactionKindHeart, Count: 2},
+			},
+			1: {},
+		}, reactionCounts)
+	})
+
+	t.Run("Successful Get Viewer Reactions", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT target_id, kind
+							FROM reactions
+							WHERE target_type = \$1 AND target_id = ANY\(\$2\) AND user_id = \$3`).
+			WithArgs(model.ReactionTargetPost, pq.Array([]int64{0, 1}), viewerID).
+			WillReturnRows(sqlmock.NewRows([]string{"target_id", "kind"}).
+				AddRow(int64(1), "SAD"))
+
+		viewerReactions, err := mockAccessor.GetViewerReactions(ctx, model.ReactionTargetPost, []int64{0, 1}, viewerID)
+		assertions.Nil(err)
+		assertions.Equal(map[int64][]model.ReactionKind{
+			0: {},
+			1: {model.ReactionKindSad},
+		}, viewerReactions)
+	})
+}
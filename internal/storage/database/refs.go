@@ -0,0 +1,167 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// addPostTags links the post with the tags found in its title and text.
+func addPostTags(ctx context.Context, tx queryExecutor, postID int64, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	queryInsertTags := `INSERT INTO post_tags (post_id, tag)
+						SELECT $1, UNNEST($2::text[])
+						ON CONFLICT DO NOTHING`
+
+	_, err := tx.ExecContext(ctx, queryInsertTags, postID, pq.Array(tags))
+	return err
+}
+
+// addMentions links the post or the comment with mentioned users. Mentions of unknown usernames are ignored.
+func addMentions(ctx context.Context, tx queryExecutor, postID int64, commentID *int64, mentions []string) error {
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	queryInsertMentions := `INSERT INTO mentions (post_id, comment_id, user_id)
+							SELECT $1, $2, user_id
+							FROM users
+							WHERE LOWER(username) = ANY($3)
+							ON CONFLICT DO NOTHING`
+
+	_, err := tx.ExecContext(ctx, queryInsertMentions, postID, commentID, pq.Array(mentions))
+	return err
+}
+
+// replaceMentions links the post, or the comment when commentID is set, with the users mentioned in its new text
+// and drops the users who are not mentioned anymore. It returns the users who were not mentioned in it before.
+func replaceMentions(ctx context.Context, tx queryExecutor, postID int64, commentID *int64, mentions []string) ([]uuid.UUID, error) {
+	queryDeleteMentions := `DELETE FROM mentions
+							WHERE post_id = $1 AND comment_id IS NOT DISTINCT FROM $2 AND user_id NOT IN (
+								SELECT user_id
+								FROM users
+								WHERE LOWER(username) = ANY($3)
+							)`
+
+	if _, err := tx.ExecContext(ctx, queryDeleteMentions, postID, commentID, pq.Array(mentions)); err != nil {
+		return nil, err
+	}
+
+	userIDs := make([]uuid.UUID, 0)
+	if len(mentions) == 0 {
+		return userIDs, nil
+	}
+
+	queryInsertMentions := `INSERT INTO mentions (post_id, comment_id, user_id)
+							SELECT $1, $2, user_id
+							FROM users
+							WHERE LOWER(username) = ANY($3)
+							ON CONFLICT DO NOTHING
+							RETURNING user_id`
+
+	rows, err := tx.QueryContext(ctx, queryInsertMentions, postID, commentID, pq.Array(mentions))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var userID uuid.UUID
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, rows.Err()
+}
+
+// addUserMentionNotifications notifies the users about being mentioned in the post, or in the comment when commentID is set.
+// Notifications keep the number of the revision that added the mentions. Users mentioning themselves are not notified.
+func addUserMentionNotifications(ctx context.Context, tx queryExecutor, postID int64, commentID *int64, actorID uuid.UUID, revisionNumber int32, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	queryInsertNotifications := `INSERT INTO notifications (user_id, kind, post_id, comment_id, actor_id, create_date, revision_number)
+								SELECT user_id, $1, $2, $3, $4, $5, $6
+								FROM UNNEST($7::uuid[]) AS user_id
+								WHERE user_id <> $4`
+
+	_, err := tx.ExecContext(ctx, queryInsertNotifications, model.NotificationKindMention, postID, commentID, actorID, time.Now(), revisionNumber, pq.Array(userIDs))
+	return err
+}
+
+// addMentionNotifications notifies users mentioned in the post, or in the comment when commentID is set,
+// in the same transaction the text becomes visible in. Users mentioning themselves are not notified.
+func addMentionNotifications(ctx context.Context, tx queryExecutor, postID int64, commentID *int64, actorID uuid.UUID) error {
+	if commentID == nil {
+		queryInsertNotifications := `INSERT INTO notifications (user_id, kind, post_id, comment_id, actor_id, create_date)
+									SELECT user_id, $1, post_id, NULL, $2, $3
+									FROM mentions
+									WHERE post_id = $4 AND comment_id IS NULL AND user_id <> $2`
+
+		_, err := tx.ExecContext(ctx, queryInsertNotifications, model.NotificationKindMention, actorID, time.Now(), postID)
+		return err
+	}
+
+	queryInsertNotifications := `INSERT INTO notifications (user_id, kind, post_id, comment_id, actor_id, create_date)
+								SELECT user_id, $1, post_id, comment_id, $2, $3
+								FROM mentions
+								WHERE comment_id = $4 AND user_id <> $2`
+
+	_, err := tx.ExecContext(ctx, queryInsertNotifications, model.NotificationKindMention, actorID, time.Now(), *commentID)
+	return err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetPostsTags(ctx context.Context, postIDs []int64) (map[int64][]string, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectTags := `SELECT post_id, tag
+						FROM post_tags
+						WHERE post_id = ANY($1)
+						ORDER BY post_id, tag`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectTags, pq.Array(postIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[int64][]string, len(postIDs))
+	for _, postID := range postIDs {
+		tags[postID] = make([]string, 0)
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var postID int64
+		var tag string
+		if err = rows.Scan(&postID, &tag); err != nil {
+			return nil, err
+		}
+
+		tags[postID] = append(tags[postID], tag)
+	}
+
+	return tags, nil
+}
+
+// hasMentions reports whether the text may mention someone, so queries for mentions can be skipped otherwise.
+func hasMentions(text string) bool {
+	return len(textrefs.Extract(text).Mentions) > 0
+}
@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefs(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Post With Tags And Mentions", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO posts`).
+			WithArgs(authorID, "Release #Go", "Thanks @Alice, see `#code`", AnyTime{}, true, model.ModerationModeOpen, nil, model.PostStatusPublished, AnyTime{}, testSearchLanguage).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(3)))
+		mock.ExpectQuery(`INSERT INTO post_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectExec(`INSERT INTO post_tags \(post_id, tag\)
+						SELECT \$1, UNNEST\(\$2::text\[\]\)
+						ON CONFLICT DO NOTHING`).
+			WithArgs(int64(3), pq.Array([]string{"go"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO mentions \(post_id, comment_id, user_id\)
+							SELECT \$1, \$2, user_id
+							FROM users
+							WHERE LOWER\(username\) = ANY\(\$3\)
+							ON CONFLICT DO NOTHING`).
+			WithArgs(int64(3), nil, pq.Array([]string{"alice"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO notifications \(user_id, kind, post_id, comment_id, actor_id, create_date\)
+									SELECT user_id, \$1, post_id, NULL, \$2, \$3
+									FROM mentions
+									WHERE post_id = \$4 AND comment_id IS NULL AND user_id <> \$2`).
+			WithArgs(model.NotificationKindMention, authorID, AnyTime{}, int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Release #Go",
+			Text:            "Thanks @Alice, see `#code`",
+			CommentsEnabled: true,
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(3), post.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Add Comment With Mentions", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		commentID := int64(5)
+
+		mock.ExpectQuery(`SELECT moderation_mode, status`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"moderation_mode", "status"}).AddRow("OPEN", "PUBLISHED"))
+		mock.ExpectQuery(`SELECT path, replies_level`).
+			WillReturnRows(sqlmock.NewRows([]string{"path", "replies_level"}))
+		mock.ExpectBegin()
+		mock.ExpectQuery(`INSERT INTO comments`).
+			WillReturnRows(sqlmock.NewRows([]string{"comment_id"}).AddRow(commentID))
+		mock.ExpectQuery(`INSERT INTO comment_revisions`).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(1), int32(1)))
+		mock.ExpectExec(`INSERT INTO mentions`).
+			WithArgs(int64(1), &commentID, pq.Array([]string{"bob"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE posts SET comment_count`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindReply, commentID, authorID, AnyTime{}, nil, int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO notifications \(user_id, kind, post_id, comment_id, actor_id, create_date\)
+								SELECT user_id, \$1, post_id, comment_id, \$2, \$3
+								FROM mentions
+								WHERE comment_id = \$4 AND user_id <> \$2`).
+			WithArgs(model.NotificationKindMention, authorID, AnyTime{}, commentID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: 1, Text: "Hi @bob"})
+		assertions.Nil(err)
+		assertions.Equal(commentID, comment.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Posts Tags", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id, tag
+						FROM post_tags
+						WHERE post_id = ANY\(\$1\)
+						ORDER BY post_id, tag`).
+			WithArgs(pq.Array([]int64{1, 2})).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "tag"}).AddRow(int64(1), "go").AddRow(int64(1), "release"))
+
+		tags, err := mockAccessor.GetPostsTags(ctx, []int64{1, 2})
+		assertions.Nil(err)
+		assertions.Equal(map[int64][]string{1: {"go", "release"}, 2: {}}, tags)
+	})
+
+	t.Run("Successful Get Posts By Tag", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		tag := "#Go"
+
+		mock.ExpectQuery(`SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at
+						FROM posts
+						WHERE deleted_at IS NULL AND \(status = \$1 OR author_id = \$2\) AND post_id IN \(SELECT post_id FROM post_tags WHERE tag = \$3\)
+						ORDER BY create_date DESC, post_id DESC`).
+			WithArgs(model.PostStatusPublished, uuid.Nil, "go").
+			WillReturnRows(sqlmock.NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}))
+
+		posts, err := mockAccessor.GetPosts(ctx, &model.PostsFilter{Tag: &tag}, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(posts)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add User Username Taken", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		username := "alice"
+
+		mock.ExpectExec(`INSERT INTO users`).
+			WillReturnError(&pq.Error{Code: "23505"})
+
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{Username: &username, DisplayName: "Alice"})
+		assertions.EqualError(err, "Username alice is already taken.")
+		assertions.Nil(user)
+	})
+}
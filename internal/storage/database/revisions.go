@@ -0,0 +1,357 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+	"github.com/google/uuid"
+)
+
+// addPostRevision stores the current title and text of the post as its next revision.
+// The first revision is the post as it was created.
+func addPostRevision(ctx context.Context, tx queryExecutor, post *model.Post, editorID uuid.UUID, editDate time.Time) (*model.Revision, error) {
+	revision := &model.Revision{
+		EditorID:   editorID,
+		Title:      &post.Title,
+		Text:       post.Text,
+		CreateDate: editDate,
+	}
+
+	queryInsertRevision := `INSERT INTO post_revisions (post_id, revision_number, editor_id, title, text, create_date)
+							SELECT $1, COALESCE(MAX(revision_number), 0) + 1, $2, $3, $4, $5
+							FROM post_revisions
+							WHERE post_id = $1
+							RETURNING revision_id, revision_number`
+
+	err := tx.QueryRowContext(ctx, queryInsertRevision,
+		post.ID,
+		revision.EditorID,
+		post.Title,
+		revision.Text,
+		revision.CreateDate).Scan(&revision.ID, &revision.Number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// addCommentRevision stores the current text of the comment as its next revision.
+// The first revision is the comment as it was added.
+func addCommentRevision(ctx context.Context, tx queryExecutor, comment *model.Comment, editorID uuid.UUID, editDate time.Time) (*model.Revision, error) {
+	revision := &model.Revision{
+		EditorID:   editorID,
+		Text:       comment.Text,
+		CreateDate: editDate,
+	}
+
+	queryInsertRevision := `INSERT INTO comment_revisions (comment_id, revision_number, editor_id, text, create_date)
+							SELECT $1, COALESCE(MAX(revision_number), 0) + 1, $2, $3, $4
+							FROM comment_revisions
+							WHERE comment_id = $1
+							RETURNING revision_id, revision_number`
+
+	err := tx.QueryRowContext(ctx, queryInsertRevision,
+		comment.ID,
+		revision.EditorID,
+		revision.Text,
+		revision.CreateDate).Scan(&revision.ID, &revision.Number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) EditPost(ctx context.Context, postID int64, editorID uuid.UUID, changes *model.PostEditInput) (*model.Post, *model.Revision, error) {
+	if err := helpers.CheckPostChanges(changes); err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer tx.Rollback()
+
+	// The row lock orders concurrent edits of the post, so revision numbers never collide.
+	querySelectPost := `SELECT author_id
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL
+						FOR UPDATE`
+
+	var authorID uuid.UUID
+	err = tx.QueryRowContext(ctx, querySelectPost, postID).Scan(&authorID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if authorID != editorID {
+		return nil, nil, errors.New("User with ID " + strconv.FormatUint(uint64(editorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	queryUpdatePost := `UPDATE posts SET title = COALESCE($1, title), text = COALESCE($2, text)
+						WHERE post_id = $3
+						RETURNING post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at`
+
+	post, err := scanPost(tx.QueryRowContext(ctx, queryUpdatePost, changes.Title, changes.Text, postID))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := addPostRevision(ctx, tx, post, editorID, time.Now())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	queryDeleteTags := `DELETE FROM post_tags
+						WHERE post_id = $1`
+
+	if _, err = tx.ExecContext(ctx, queryDeleteTags, postID); err != nil {
+		return nil, nil, err
+	}
+
+	refs := textrefs.Extract(post.Title, post.Text)
+	if err = addPostTags(ctx, tx, postID, refs.Tags); err != nil {
+		return nil, nil, err
+	}
+
+	// Only users mentioned by the edit are notified, and only if the post is visible. Drafts notify on publication.
+	newUserIDs, err := replaceMentions(ctx, tx, postID, nil, refs.Mentions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if post.Status == model.PostStatusPublished {
+		if err = addUserMentionNotifications(ctx, tx, postID, nil, post.AuthorID, revision.Number, newUserIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return post, revision, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) EditComment(ctx context.Context, commentID int64, editorID uuid.UUID, text string) (*model.Comment, *model.Revision, error) {
+	if err := helpers.CheckCommentText(text); err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+
+	default:
+	}
+
+	tx, err := databaseAccessor.beginTx(ctx)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer tx.Rollback()
+
+	querySelectComment := `SELECT author_id
+							FROM comments
+							WHERE comment_id = $1 AND deleted_at IS NULL
+							FOR UPDATE`
+
+	var authorID uuid.UUID
+	err = tx.QueryRowContext(ctx, querySelectComment, commentID).Scan(&authorID)
+
+	if err == sql.ErrNoRows {
+		return nil, nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if authorID != editorID {
+		return nil, nil, errors.New("User with ID " + strconv.FormatUint(uint64(editorID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	queryUpdateComment := `UPDATE comments SET text = $1
+							WHERE comment_id = $2
+							RETURNING comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count`
+
+	comment, err := scanComment(tx.QueryRowContext(ctx, queryUpdateComment, text, commentID))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	revision, err := addCommentRevision(ctx, tx, comment, editorID, time.Now())
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Comments on moderation notify mentioned users when they are approved.
+	newUserIDs, err := replaceMentions(ctx, tx, comment.PostID, &comment.ID, textrefs.Extract(text).Mentions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if comment.Status == model.CommentStatusApproved {
+		if err = addUserMentionNotifications(ctx, tx, comment.PostID, &comment.ID, comment.AuthorID, revision.Number, newUserIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	return comment, revision, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetPostRevisions(ctx context.Context, postID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{postID}
+	querySelectRevisions := `SELECT revision_id, revision_number, editor_id, title, text, create_date
+							FROM post_revisions
+							WHERE post_id = $1`
+	if after != nil {
+		querySelectRevisions += ` AND revision_number < ` + args.add(after.Number)
+	}
+	querySelectRevisions += `
+							ORDER BY revision_number DESC`
+	if limit != nil {
+		querySelectRevisions += `
+							LIMIT ` + args.add(*limit)
+	}
+
+	return databaseAccessor.queryRevisions(ctx, querySelectRevisions, args...)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetCommentRevisions(ctx context.Context, commentID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{commentID}
+	querySelectRevisions := `SELECT revision_id, revision_number, editor_id, NULL, text, create_date
+							FROM comment_revisions
+							WHERE comment_id = $1`
+	if after != nil {
+		querySelectRevisions += ` AND revision_number < ` + args.add(after.Number)
+	}
+	querySelectRevisions += `
+							ORDER BY revision_number DESC`
+	if limit != nil {
+		querySelectRevisions += `
+							LIMIT ` + args.add(*limit)
+	}
+
+	return databaseAccessor.queryRevisions(ctx, querySelectRevisions, args...)
+}
+
+func (databaseAccessor *DatabaseAccessor) GetPostRevision(ctx context.Context, postID int64, number int32) (*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectRevision := `SELECT revision_id, revision_number, editor_id, title, text, create_date
+							FROM post_revisions
+							WHERE post_id = $1 AND revision_number = $2`
+
+	revision, err := scanRevision(databaseAccessor.storage.QueryRowContext(ctx, querySelectRevision, postID, number))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Revision " + strconv.FormatInt(int64(number), 10) + " of the post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	return revision, err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetCommentRevision(ctx context.Context, commentID int64, number int32) (*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectRevision := `SELECT revision_id, revision_number, editor_id, NULL, text, create_date
+							FROM comment_revisions
+							WHERE comment_id = $1 AND revision_number = $2`
+
+	revision, err := scanRevision(databaseAccessor.storage.QueryRowContext(ctx, querySelectRevision, commentID, number))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("Revision " + strconv.FormatInt(int64(number), 10) + " of the comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	return revision, err
+}
+
+func (databaseAccessor *DatabaseAccessor) queryRevisions(ctx context.Context, query string, args ...any) ([]*model.Revision, error) {
+	rows, err := databaseAccessor.storage.QueryContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*model.Revision, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+// scanRevision reads a revision selected as "revision_id, revision_number, editor_id, title, text, create_date".
+func scanRevision(row rowScanner) (*model.Revision, error) {
+	var revision model.Revision
+	if err := row.Scan(&revision.ID, &revision.Number, &revision.EditorID, &revision.Title, &revision.Text, &revision.CreateDate); err != nil {
+		return nil, err
+	}
+
+	return &revision, nil
+}
@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevisions(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Edit Post", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		text := "New text #go"
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id
+						FROM posts
+						WHERE post_id = \$1 AND deleted_at IS NULL
+						FOR UPDATE`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+		mock.ExpectQuery(`UPDATE posts SET title = COALESCE\(\$1, title\), text = COALESCE\(\$2, text\)
+						WHERE post_id = \$3`).
+			WithArgs(nil, &text, int64(1)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at"}).
+				AddRow(int64(1), authorID, "Title", text, time.Now(), true, "OPEN", int32(0), "PUBLISHED", time.Now()))
+		mock.ExpectQuery(`INSERT INTO post_revisions \(post_id, revision_number, editor_id, title, text, create_date\)
+							SELECT \$1, COALESCE\(MAX\(revision_number\), 0\) \+ 1, \$2, \$3, \$4, \$5
+							FROM post_revisions
+							WHERE post_id = \$1
+							RETURNING revision_id, revision_number`).
+			WithArgs(int64(1), authorID, "Title", text, AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(7), int32(2)))
+		mock.ExpectExec(`DELETE FROM post_tags`).
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO post_tags`).
+			WithArgs(int64(1), pq.Array([]string{"go"})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM mentions`).
+			WithArgs(int64(1), nil, pq.Array([]string{})).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		post, revision, err := mockAccessor.EditPost(ctx, 1, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+		assertions.Equal(text, post.Text)
+		assertions.Equal(int64(7), revision.ID)
+		assertions.Equal(int32(2), revision.Number)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Edit Post Not Author", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		title := "Title"
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(uuid.New()))
+		mock.ExpectRollback()
+
+		post, revision, err := mockAccessor.EditPost(ctx, 1, authorID, &model.PostEditInput{Title: &title})
+		assertions.NotNil(err)
+		assertions.Nil(post)
+		assertions.Nil(revision)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Edit Comment Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id`).
+			WithArgs(int64(5)).
+			WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		comment, revision, err := mockAccessor.EditComment(ctx, 5, authorID, "Text")
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(revision)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Edit Comment", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		text := "Edited @bob"
+		commentID := int64(5)
+		mentionedID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT author_id`).
+			WithArgs(int64(5)).
+			WillReturnRows(sqlmock.NewRows([]string{"author_id"}).AddRow(authorID))
+		mock.ExpectQuery(`UPDATE comments SET text = \$1`).
+			WithArgs(text, int64(5)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count"}).
+				AddRow(int64(5), authorID, int64(1), nil, text, time.Now(), "APPROVED", int32(0)))
+		mock.ExpectQuery(`INSERT INTO comment_revisions`).
+			WithArgs(int64(5), authorID, text, AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"revision_id", "revision_number"}).AddRow(int64(3), int32(2)))
+		mock.ExpectExec(`DELETE FROM mentions`).
+			WithArgs(int64(1), &commentID, pq.Array([]string{"bob"})).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(`INSERT INTO mentions \(post_id, comment_id, user_id\)`).
+			WithArgs(int64(1), &commentID, pq.Array([]string{"bob"})).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(mentionedID))
+		mock.ExpectExec(`INSERT INTO notifications`).
+			WithArgs(model.NotificationKindMention, int64(1), &commentID, authorID, AnyTime{}, int32(2), pq.Array([]uuid.UUID{mentionedID})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		comment, revision, err := mockAccessor.EditComment(ctx, 5, authorID, text)
+		assertions.Nil(err)
+		assertions.Equal(text, comment.Text)
+		assertions.Equal(int32(2), revision.Number)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Edit Comment Too Long", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		comment, revision, err := mockAccessor.EditComment(ctx, 5, authorID, strings.Repeat("a", 2001))
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+		assertions.Nil(revision)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Post Revisions", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		title := "Title"
+		limit := int32(2)
+
+		mock.ExpectQuery(`SELECT revision_id, revision_number, editor_id, title, text, create_date
+							FROM post_revisions
+							WHERE post_id = \$1 AND revision_number < \$2
+							ORDER BY revision_number DESC
+							LIMIT \$3`).
+			WithArgs(int64(1), int32(3), int32(2)).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"revision_id", "revision_number", "editor_id", "title", "text", "create_date"}).
+				AddRow(int64(2), int32(2), authorID, &title, "Second", time.Now()).
+				AddRow(int64(1), int32(1), authorID, &title, "First", time.Now()))
+
+		revisions, err := mockAccessor.GetPostRevisions(ctx, 1, &cursor.RevisionCursor{Number: 3}, &limit)
+		assertions.Nil(err)
+		assertions.Len(revisions, 2)
+		assertions.Equal("Second", revisions[0].Text)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Get Comment Revision Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT revision_id, revision_number, editor_id, NULL, text, create_date
+							FROM comment_revisions
+							WHERE comment_id = \$1 AND revision_number = \$2`).
+			WithArgs(int64(5), int32(9)).
+			WillReturnError(sql.ErrNoRows)
+
+		revision, err := mockAccessor.GetCommentRevision(ctx, 5, 9)
+		assertions.NotNil(err)
+		assertions.Nil(revision)
+	})
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+)
+
+const headlineOptions = "StartSel=<b>, StopSel=</b>, MaxWords=35, MinWords=15"
+
+func (databaseAccessor *DatabaseAccessor) SearchPosts(ctx context.Context, query string, after *cursor.SearchCursor, limit *int32) ([]*model.PostSearchEdge, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{databaseAccessor.searchLanguage, query, headlineOptions, model.PostStatusPublished}
+	querySelectPosts := `SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at, rank,
+								ts_headline($1::regconfig, text, websearch_to_tsquery($1::regconfig, $2), $3)
+							FROM (
+								SELECT post_id, author_id, title, text, create_date, comments_enabled, moderation_mode, comment_count, status, publish_at,
+									ts_rank(search_vector, websearch_to_tsquery($1::regconfig, $2)) AS rank
+								FROM posts
+								WHERE deleted_at IS NULL AND status = $4 AND search_vector @@ websearch_to_tsquery($1::regconfig, $2)
+							) AS results`
+	querySelectPosts, args = addSearchPagination(querySelectPosts, args, "post_id", after, limit)
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectPosts, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.PostSearchEdge, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var edge model.PostSearchEdge
+		post, err := scanPost(rows, &edge.Rank, &edge.Snippet)
+		if err != nil {
+			return nil, err
+		}
+
+		edge.Node = post
+		edges = append(edges, &edge)
+	}
+
+	return edges, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) SearchComments(ctx context.Context, postID int64, query string, after *cursor.SearchCursor, limit *int32) ([]*model.CommentSearchEdge, error) {
+	var dbPostID int64
+
+	querySelectPost := `SELECT post_id
+						FROM posts
+						WHERE post_id = $1 AND deleted_at IS NULL`
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectPost, postID).Scan(&dbPostID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	args := queryArgs{databaseAccessor.searchLanguage, query, headlineOptions, postID, model.CommentStatusApproved}
+	querySelectComments := `SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count, rank,
+								ts_headline($1::regconfig, text, websearch_to_tsquery($1::regconfig, $2), $3)
+							FROM (
+								SELECT comment_id, author_id, post_id, parent_id, text, create_date, status, reply_count,
+									ts_rank(search_vector, websearch_to_tsquery($1::regconfig, $2)) AS rank
+								FROM comments
+								WHERE post_id = $4 AND status = $5 AND deleted_at IS NULL AND search_vector @@ websearch_to_tsquery($1::regconfig, $2)
+							) AS results`
+	querySelectComments, args = addSearchPagination(querySelectComments, args, "comment_id", after, limit)
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectComments, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	edges := make([]*model.CommentSearchEdge, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var edge model.CommentSearchEdge
+		comment, err := scanComment(rows, &edge.Rank, &edge.Snippet)
+		if err != nil {
+			return nil, err
+		}
+
+		edge.Node = comment
+		edges = append(edges, &edge)
+	}
+
+	return edges, nil
+}
+
+// addSearchPagination continues search results after the cursor. Results are ordered by rank and then by ID,
+// so the pair from the cursor defines the exact position.
+func addSearchPagination(query string, args queryArgs, idColumn string, after *cursor.SearchCursor, limit *int32) (string, queryArgs) {
+	if after != nil {
+		rank, id := args.add(after.Rank), args.add(after.ID)
+		query += `
+							WHERE rank < ` + rank + ` OR (rank = ` + rank + ` AND ` + idColumn + ` > ` + id + `)`
+	}
+
+	query += `
+							ORDER BY rank DESC, ` + idColumn
+
+	if limit != nil {
+		query += `
+							LIMIT ` + args.add(*limit)
+	}
+
+	return query, args
+}
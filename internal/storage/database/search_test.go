@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Search Posts", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		limit := int32(2)
+		after := &cursor.SearchCursor{Rank: 0.5, ID: 3}
+
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE deleted_at IS NULL AND status = $4 AND search_vector @@ websearch_to_tsquery($1::regconfig, $2)
+							) AS results
+							WHERE rank < $5 OR (rank = $5 AND post_id > $6)
+							ORDER BY rank DESC, post_id
+							LIMIT $7`)).
+			WithArgs(testSearchLanguage, "channels", headlineOptions, model.PostStatusPublished, 0.5, int64(3), limit).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"post_id", "author_id", "title", "text", "create_date", "comments_enabled", "moderation_mode", "comment_count", "status", "publish_at", "rank", "ts_headline"}).
+				AddRow(int64(4), authorID, "Test Title", "Go channels", time.Now(), true, "OPEN", 0, "PUBLISHED", time.Now(), 0.25, "Go <b>channels</b>"))
+
+		edges, err := mockAccessor.SearchPosts(ctx, "channels", after, &limit)
+		assertions.Nil(err)
+		assertions.Len(edges, 1)
+		assertions.Equal(int64(4), edges[0].Node.ID)
+		assertions.Equal(0.25, edges[0].Rank)
+		assertions.Equal("Go <b>channels</b>", edges[0].Snippet)
+	})
+
+	t.Run("Successful Search Comments", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"post_id"}).AddRow(int64(1)))
+
+		mock.ExpectQuery(regexp.QuoteMeta(`WHERE post_id = $4 AND status = $5 AND deleted_at IS NULL AND search_vector @@ websearch_to_tsquery($1::regconfig, $2)
+							) AS results
+							ORDER BY rank DESC, comment_id`)).
+			WithArgs(testSearchLanguage, "channels", headlineOptions, int64(1), model.CommentStatusApproved).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"comment_id", "author_id", "post_id", "parent_id", "text", "create_date", "status", "reply_count", "rank", "ts_headline"}).
+				AddRow(int64(0), authorID, int64(1), nil, "I like channels", time.Now(), "APPROVED", 0, 0.1, "I like <b>channels</b>"))
+
+		edges, err := mockAccessor.SearchComments(ctx, 1, "channels", nil, nil)
+		assertions.Nil(err)
+		assertions.Len(edges, 1)
+		assertions.Equal("I like <b>channels</b>", edges[0].Snippet)
+	})
+
+	t.Run("Unsuccessful Search Comments Post Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT post_id
+						FROM posts
+						WHERE post_id = \$1`).
+			WithArgs(int64(-1)).
+			WillReturnError(sql.ErrNoRows)
+
+		edges, err := mockAccessor.SearchComments(ctx, -1, "channels", nil, nil)
+		assertions.NotNil(err)
+		assertions.Nil(edges)
+	})
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (databaseAccessor *DatabaseAccessor) AddUser(ctx context.Context, newUser *model.UserInput) (*model.User, error) {
+	if err := helpers.CheckDisplayName(newUser.DisplayName); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckAvatarURL(newUser.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckUsername(newUser.Username); err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		ID:          uuid.New(),
+		Username:    newUser.Username,
+		DisplayName: newUser.DisplayName,
+		AvatarURL:   newUser.AvatarURL,
+		Role:        model.RoleUser,
+		CreateDate:  time.Now(),
+	}
+
+	if newUser.ID != nil {
+		user.ID = *newUser.ID
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	queryInsertUser := `INSERT INTO users (user_id, username, display_name, avatar_url, role, create_date)
+						VALUES ($1, $2, $3, $4, $5, $6)
+						ON CONFLICT (user_id) DO NOTHING`
+
+	result, err := databaseAccessor.storage.ExecContext(ctx, queryInsertUser,
+		user.ID,
+		user.Username,
+		user.DisplayName,
+		user.AvatarURL,
+		user.Role,
+		user.CreateDate)
+
+	if isUniqueViolation(err) {
+		return nil, errors.New("Username " + *user.Username + " is already taken.")
+	} else if err != nil {
+		return nil, err
+	}
+
+	inserted, err := result.RowsAffected()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if inserted == 0 {
+		return nil, errors.New("User with ID " + user.ID.String() + " already exists.")
+	}
+
+	return user, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectUser := `SELECT user_id, username, display_name, avatar_url, role, create_date
+						FROM users
+						WHERE user_id = $1`
+
+	user, err := scanUser(databaseAccessor.storage.QueryRowContext(ctx, querySelectUser, userID))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	}
+
+	return user, err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*model.User, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	dbUserIDs := make([]string, len(userIDs))
+	for idx, userID := range userIDs {
+		dbUserIDs[idx] = userID.String()
+	}
+
+	querySelectUsers := `SELECT user_id, username, display_name, avatar_url, role, create_date
+						FROM users
+						WHERE user_id = ANY($1::uuid[])`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectUsers, pq.Array(dbUserIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[uuid.UUID]*model.User, len(userIDs))
+
+	defer rows.Close()
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		users[user.ID] = user
+	}
+
+	return users, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) UpdateUser(ctx context.Context, userID uuid.UUID, changes *model.UserUpdateInput) (*model.User, error) {
+	if changes.DisplayName != nil {
+		if err := helpers.CheckDisplayName(*changes.DisplayName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := helpers.CheckAvatarURL(changes.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckUsername(changes.Username); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	queryUpdateUser := `UPDATE users SET display_name = COALESCE($1, display_name), avatar_url = COALESCE($2, avatar_url), username = COALESCE($3, username)
+						WHERE user_id = $4
+						RETURNING user_id, username, display_name, avatar_url, role, create_date`
+
+	user, err := scanUser(databaseAccessor.storage.QueryRowContext(ctx, queryUpdateUser,
+		changes.DisplayName,
+		changes.AvatarURL,
+		changes.Username,
+		userID))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	} else if isUniqueViolation(err) {
+		return nil, errors.New("Username " + *changes.Username + " is already taken.")
+	}
+
+	return user, err
+}
+
+func (databaseAccessor *DatabaseAccessor) UpdateUserRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error) {
+	if !role.IsValid() {
+		return nil, errors.New("Role " + role.String() + " is not valid.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	queryUpdateUser := `UPDATE users SET role = $1
+						WHERE user_id = $2
+						RETURNING user_id, username, display_name, avatar_url, role, create_date`
+
+	user, err := scanUser(databaseAccessor.storage.QueryRowContext(ctx, queryUpdateUser, role, userID))
+
+	if err == sql.ErrNoRows {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	}
+
+	return user, err
+}
+
+// isUniqueViolation reports whether err is a violation of a unique constraint, like a taken username.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
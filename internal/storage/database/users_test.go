@@ -0,0 +1,156 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsers(t *testing.T) {
+	assertions := assert.New(t)
+	userID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add User", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		avatarURL := "https://example.com/avatar.png"
+
+		mock.ExpectExec(`INSERT INTO users \(user_id, username, display_name, avatar_url, role, create_date\)
+						VALUES \(\$1, \$2, \$3, \$4, \$5, \$6\)
+						ON CONFLICT \(user_id\) DO NOTHING`).
+			WithArgs(userID, nil, "Test User", &avatarURL, model.RoleUser, AnyTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			ID:          &userID,
+			DisplayName: "Test User",
+			AvatarURL:   &avatarURL,
+		})
+		assertions.Nil(err)
+		assertions.Equal(userID, user.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add User Already Exists", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`INSERT INTO users`).
+			WithArgs(userID, nil, "Test User", nil, model.RoleUser, AnyTime{}).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			ID:          &userID,
+			DisplayName: "Test User",
+		})
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Unsuccessful Add User Empty Display Name", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			DisplayName: "",
+		})
+		assertions.NotNil(err)
+		assertions.Nil(user)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Users", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		unknownID := uuid.New()
+
+		mock.ExpectQuery(`SELECT user_id, username, display_name, avatar_url, role, create_date
+						FROM users
+						WHERE user_id = ANY\(\$1::uuid\[\]\)`).
+			WithArgs(pq.Array([]string{userID.String(), unknownID.String()})).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"user_id", "username", "display_name", "avatar_url", "role", "create_date"}).
+				AddRow(userID, nil, "Test User", nil, "USER", time.Now()))
+
+		users, err := mockAccessor.GetUsers(ctx, []uuid.UUID{userID, unknownID})
+		assertions.Nil(err)
+		assertions.Len(users, 1)
+		assertions.Equal("Test User", users[userID].DisplayName)
+	})
+
+	t.Run("Successful Update User", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		displayName := "Renamed User"
+
+		mock.ExpectQuery(`UPDATE users SET display_name = COALESCE\(\$1, display_name\), avatar_url = COALESCE\(\$2, avatar_url\), username = COALESCE\(\$3, username\)
+						WHERE user_id = \$4
+						RETURNING user_id, username, display_name, avatar_url, role, create_date`).
+			WithArgs(&displayName, nil, nil, userID).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"user_id", "username", "display_name", "avatar_url", "role", "create_date"}).
+				AddRow(userID, nil, displayName, nil, "USER", time.Now()))
+
+		user, err := mockAccessor.UpdateUser(ctx, userID, &model.UserUpdateInput{
+			DisplayName: &displayName,
+		})
+		assertions.Nil(err)
+		assertions.Equal(displayName, user.DisplayName)
+	})
+
+	t.Run("Unsuccessful Update User Does Not Exist", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		displayName := "Renamed User"
+
+		mock.ExpectQuery(`UPDATE users SET display_name`).
+			WithArgs(&displayName, nil, nil, userID).
+			WillReturnError(sql.ErrNoRows)
+
+		user, err := mockAccessor.UpdateUser(ctx, userID, &model.UserUpdateInput{
+			DisplayName: &displayName,
+		})
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Successful Update User Role", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`UPDATE users SET role = \$1
+						WHERE user_id = \$2
+						RETURNING user_id, username, display_name, avatar_url, role, create_date`).
+			WithArgs(model.RoleModerator, userID).
+			WillReturnRows(sqlmock.
+				NewRows([]string{"user_id", "username", "display_name", "avatar_url", "role", "create_date"}).
+				AddRow(userID, nil, "Test User", nil, "MODERATOR", time.Now()))
+
+		user, err := mockAccessor.UpdateUserRole(ctx, userID, model.RoleModerator)
+		assertions.Nil(err)
+		assertions.Equal(model.RoleModerator, user.Role)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Update User Role Invalid Role", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		user, err := mockAccessor.UpdateUserRole(ctx, userID, model.Role("OWNER"))
+		assertions.NotNil(err)
+		assertions.Nil(user)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,354 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (databaseAccessor *DatabaseAccessor) AddWebhookSubscription(ctx context.Context, newSubscription *model.WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	if err := helpers.CheckWebhookSubscription(newSubscription); err != nil {
+		return nil, err
+	}
+
+	subscription := &model.WebhookSubscription{
+		OwnerID:    newSubscription.OwnerID,
+		URL:        newSubscription.URL,
+		EventKinds: newSubscription.EventKinds,
+		CreateDate: time.Now(),
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	eventKinds := make([]string, len(subscription.EventKinds))
+	for idx, eventKind := range subscription.EventKinds {
+		eventKinds[idx] = eventKind.String()
+	}
+
+	queryInsertSubscription := `INSERT INTO webhook_subscriptions (owner_id, url, secret, event_kinds, create_date)
+								VALUES ($1, $2, $3, $4, $5)
+								RETURNING subscription_id`
+
+	err := databaseAccessor.storage.QueryRowContext(ctx, queryInsertSubscription,
+		subscription.OwnerID,
+		subscription.URL,
+		newSubscription.Secret,
+		pq.Array(eventKinds),
+		subscription.CreateDate).Scan(&subscription.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	if err := databaseAccessor.checkWebhookOwner(ctx, subscriptionID, ownerID); err != nil {
+		return err
+	}
+
+	queryDeleteSubscription := `DELETE FROM webhook_subscriptions
+								WHERE subscription_id = $1`
+
+	_, err := databaseAccessor.storage.ExecContext(ctx, queryDeleteSubscription, subscriptionID)
+	return err
+}
+
+func (databaseAccessor *DatabaseAccessor) GetWebhookSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]*model.WebhookSubscription, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	querySelectSubscriptions := `SELECT subscription_id, owner_id, url, event_kinds, create_date
+								FROM webhook_subscriptions
+								WHERE owner_id = $1
+								ORDER BY subscription_id`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectSubscriptions, ownerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*model.WebhookSubscription, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var subscription model.WebhookSubscription
+		var eventKinds []string
+		if err = rows.Scan(&subscription.ID,
+			&subscription.OwnerID,
+			&subscription.URL,
+			pq.Array(&eventKinds),
+			&subscription.CreateDate); err != nil {
+			return nil, err
+		}
+
+		subscription.EventKinds = make([]model.EventKind, len(eventKinds))
+		for idx, eventKind := range eventKinds {
+			subscription.EventKinds[idx] = model.EventKind(eventKind)
+		}
+
+		subscriptions = append(subscriptions, &subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) GetWebhookDeliveries(ctx context.Context, subscriptionID int64, ownerID uuid.UUID, status *model.WebhookDeliveryStatus, after *cursor.DeliveryCursor, limit *int32) ([]*model.WebhookDelivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	if err := databaseAccessor.checkWebhookOwner(ctx, subscriptionID, ownerID); err != nil {
+		return nil, err
+	}
+
+	args := queryArgs{subscriptionID}
+	querySelectDeliveries := `SELECT webhook_deliveries.delivery_id, webhook_deliveries.subscription_id, webhook_deliveries.event_id, outbox.kind,
+									webhook_deliveries.status, webhook_deliveries.attempts, webhook_deliveries.last_status_code, webhook_deliveries.last_error,
+									webhook_deliveries.next_attempt_date, webhook_deliveries.create_date, webhook_deliveries.delivery_date
+								FROM webhook_deliveries
+								JOIN outbox ON outbox.event_id = webhook_deliveries.event_id
+								WHERE webhook_deliveries.subscription_id = $1`
+	if status != nil {
+		querySelectDeliveries += ` AND webhook_deliveries.status = ` + args.add(*status)
+	}
+	if after != nil {
+		querySelectDeliveries += ` AND webhook_deliveries.delivery_id < ` + args.add(after.DeliveryID)
+	}
+	querySelectDeliveries += `
+								ORDER BY webhook_deliveries.delivery_id DESC`
+	if limit != nil {
+		querySelectDeliveries += `
+								LIMIT ` + args.add(*limit)
+	}
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, querySelectDeliveries, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+// AddWebhookDeliveries creates a pending delivery of the event for every subscription to its kind.
+// A delivery already created for the subscription and the event is kept as is.
+func (databaseAccessor *DatabaseAccessor) AddWebhookDeliveries(ctx context.Context, envelope *events.Envelope) (int32, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	queryInsertDeliveries := `INSERT INTO webhook_deliveries (subscription_id, event_id, status, next_attempt_date, create_date)
+								SELECT subscription_id, $1, $2, $3, $3
+								FROM webhook_subscriptions
+								WHERE $4 = ANY(event_kinds)
+								ON CONFLICT (subscription_id, event_id) DO NOTHING`
+
+	result, err := databaseAccessor.storage.ExecContext(ctx, queryInsertDeliveries,
+		envelope.ID,
+		model.WebhookDeliveryStatusPending,
+		time.Now(),
+		envelope.Kind)
+
+	if err != nil {
+		return 0, err
+	}
+
+	added, err := result.RowsAffected()
+
+	return int32(added), err
+}
+
+// ClaimDueWebhookDeliveries returns deliveries due at now and moves their next attempt to leaseUntil in the same statement.
+// Rows locked by another worker are skipped, so concurrent workers never send the same delivery twice, and a delivery
+// of a worker that stopped before updating it becomes due again after the lease.
+func (databaseAccessor *DatabaseAccessor) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int32) ([]*webhooks.PendingDelivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	queryClaimDeliveries := `WITH claimed AS (
+									UPDATE webhook_deliveries SET next_attempt_date = $4
+									WHERE delivery_id IN (
+										SELECT delivery_id
+										FROM webhook_deliveries
+										WHERE status = $1 AND next_attempt_date <= $2
+										ORDER BY next_attempt_date, delivery_id
+										LIMIT $3
+										FOR UPDATE SKIP LOCKED)
+									RETURNING delivery_id, subscription_id, event_id, status, attempts, last_status_code, last_error,
+										next_attempt_date, create_date, delivery_date)
+								SELECT claimed.delivery_id, claimed.subscription_id, claimed.event_id, outbox.kind,
+									claimed.status, claimed.attempts, claimed.last_status_code, claimed.last_error,
+									claimed.next_attempt_date, claimed.create_date, claimed.delivery_date,
+									webhook_subscriptions.url, webhook_subscriptions.secret, outbox.payload, outbox.create_date
+								FROM claimed
+								JOIN webhook_subscriptions ON webhook_subscriptions.subscription_id = claimed.subscription_id
+								JOIN outbox ON outbox.event_id = claimed.event_id
+								ORDER BY claimed.delivery_id`
+
+	rows, err := databaseAccessor.storage.QueryContext(ctx, queryClaimDeliveries, model.WebhookDeliveryStatusPending, now, limit, leaseUntil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pendingDeliveries := make([]*webhooks.PendingDelivery, 0)
+
+	defer rows.Close()
+	for rows.Next() {
+		var pendingDelivery webhooks.PendingDelivery
+		var envelope events.Envelope
+		var payload []byte
+		delivery, err := scanWebhookDelivery(rows,
+			&pendingDelivery.URL,
+			&pendingDelivery.Secret,
+			&payload,
+			&envelope.CreateDate)
+		if err != nil {
+			return nil, err
+		}
+
+		envelope.ID = delivery.EventID
+		envelope.Kind = events.Kind(delivery.EventKind)
+		envelope.Payload = payload
+
+		pendingDelivery.Delivery = delivery
+		pendingDelivery.Envelope = &envelope
+		pendingDeliveries = append(pendingDeliveries, &pendingDelivery)
+	}
+
+	return pendingDeliveries, nil
+}
+
+func (databaseAccessor *DatabaseAccessor) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	queryUpdateDelivery := `UPDATE webhook_deliveries SET status = $1, attempts = $2, last_status_code = $3, last_error = $4,
+								next_attempt_date = $5, delivery_date = $6
+							WHERE delivery_id = $7`
+
+	result, err := databaseAccessor.storage.ExecContext(ctx, queryUpdateDelivery,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.NextAttemptDate,
+		delivery.DeliveryDate,
+		delivery.ID)
+
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if updated == 0 {
+		return storage.NotFound("Webhook delivery with ID " + strconv.FormatInt(delivery.ID, 10) + " was not found")
+	}
+
+	return nil
+}
+
+func (databaseAccessor *DatabaseAccessor) checkWebhookOwner(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) error {
+	querySelectSubscription := `SELECT owner_id
+								FROM webhook_subscriptions
+								WHERE subscription_id = $1`
+
+	var subscriptionOwnerID uuid.UUID
+	err := databaseAccessor.storage.QueryRowContext(ctx, querySelectSubscription, subscriptionID).Scan(&subscriptionOwnerID)
+
+	if err == sql.ErrNoRows {
+		return storage.NotFound("Webhook subscription with ID " + strconv.FormatInt(subscriptionID, 10) + " was not found")
+	} else if err != nil {
+		return err
+	}
+
+	if subscriptionOwnerID != ownerID {
+		return errors.New("User with ID " + strconv.FormatUint(uint64(ownerID.ID()), 10) + " is not the owner of the webhook subscription with ID " + strconv.FormatInt(subscriptionID, 10) + ".")
+	}
+
+	return nil
+}
+
+// scanWebhookDelivery reads a delivery selected as "delivery_id, subscription_id, event_id, kind, status, attempts,
+// last_status_code, last_error, next_attempt_date, create_date, delivery_date", followed by the extra columns.
+func scanWebhookDelivery(row rowScanner, extra ...any) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	dest := append([]any{&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventKind,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.NextAttemptDate,
+		&delivery.CreateDate,
+		&delivery.DeliveryDate}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSubscriptions(t *testing.T) {
+	assertions := assert.New(t)
+	ownerID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add Webhook Subscription", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`INSERT INTO webhook_subscriptions \(owner_id, url, secret, event_kinds, create_date\)
+								VALUES \(\$1, \$2, \$3, \$4, \$5\)
+								RETURNING subscription_id`).
+			WithArgs(ownerID, "https://example.com/hook", "0123456789abcdef", pq.Array([]string{"POST_CREATED"}), AnyTime{}).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id"}).AddRow(int64(2)))
+
+		subscription, err := mockAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+			OwnerID:    ownerID,
+			URL:        "https://example.com/hook",
+			EventKinds: []model.EventKind{model.EventKindPostCreated},
+			Secret:     "0123456789abcdef",
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(2), subscription.ID)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unsuccessful Add Webhook Subscription without event kinds", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		subscription, err := mockAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+			OwnerID: ownerID,
+			URL:     "https://example.com/hook",
+			Secret:  "0123456789abcdef",
+		})
+		assertions.NotNil(err)
+		assertions.Nil(subscription)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Webhook Subscriptions", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT subscription_id, owner_id, url, event_kinds, create_date
+								FROM webhook_subscriptions
+								WHERE owner_id = \$1
+								ORDER BY subscription_id`).
+			WithArgs(ownerID).
+			WillReturnRows(sqlmock.NewRows([]string{"subscription_id", "owner_id", "url", "event_kinds", "create_date"}).
+				AddRow(int64(2), ownerID, "https://example.com/hook", "{POST_CREATED,COMMENT_ADDED}", time.Now()))
+
+		subscriptions, err := mockAccessor.GetWebhookSubscriptions(ctx, ownerID)
+		assertions.Nil(err)
+		assertions.Len(subscriptions, 1)
+		assertions.Equal([]model.EventKind{model.EventKindPostCreated, model.EventKindCommentAdded}, subscriptions[0].EventKinds)
+	})
+
+	t.Run("Unsuccessful Delete Webhook Subscription not owner", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT owner_id
+								FROM webhook_subscriptions
+								WHERE subscription_id = \$1`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"owner_id"}).AddRow(uuid.New()))
+
+		err := mockAccessor.DeleteWebhookSubscription(ctx, 2, ownerID)
+		assertions.NotNil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Delete Webhook Subscription", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT owner_id`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"owner_id"}).AddRow(ownerID))
+		mock.ExpectExec(`DELETE FROM webhook_subscriptions
+								WHERE subscription_id = \$1`).
+			WithArgs(int64(2)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := mockAccessor.DeleteWebhookSubscription(ctx, 2, ownerID)
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	assertions := assert.New(t)
+	ownerID := uuid.New()
+	ctx := context.Background()
+	deliveryColumns := []string{"delivery_id", "subscription_id", "event_id", "kind", "status", "attempts",
+		"last_status_code", "last_error", "next_attempt_date", "create_date", "delivery_date"}
+
+	t.Run("Successful Add Webhook Deliveries", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectExec(`INSERT INTO webhook_deliveries \(subscription_id, event_id, status, next_attempt_date, create_date\)
+								SELECT subscription_id, \$1, \$2, \$3, \$3
+								FROM webhook_subscriptions
+								WHERE \$4 = ANY\(event_kinds\)
+								ON CONFLICT \(subscription_id, event_id\) DO NOTHING`).
+			WithArgs(int64(7), model.WebhookDeliveryStatusPending, AnyTime{}, events.KindCommentAdded).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		added, err := mockAccessor.AddWebhookDeliveries(ctx, &events.Envelope{ID: 7, Kind: events.KindCommentAdded})
+		assertions.Nil(err)
+		assertions.Equal(int32(2), added)
+	})
+
+	t.Run("Successful Claim Due Webhook Deliveries", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		now := time.Now()
+		leaseUntil := now.Add(time.Minute)
+		mock.ExpectQuery(`UPDATE webhook_deliveries SET next_attempt_date = \$4
+									WHERE delivery_id IN \(
+										SELECT delivery_id
+										FROM webhook_deliveries
+										WHERE status = \$1 AND next_attempt_date <= \$2
+										ORDER BY next_attempt_date, delivery_id
+										LIMIT \$3
+										FOR UPDATE SKIP LOCKED\)`).
+			WithArgs(model.WebhookDeliveryStatusPending, now, int32(10), leaseUntil).
+			WillReturnRows(sqlmock.NewRows(append(deliveryColumns, "url", "secret", "payload", "event_create_date")).
+				AddRow(int64(3), int64(2), int64(7), "COMMENT_ADDED", "PENDING", int32(1), int32(500), "Webhook responded with status 500.", leaseUntil, now, nil,
+					"https://example.com/hook", "0123456789abcdef", []byte(`{"commentID":4}`), now))
+
+		pendingDeliveries, err := mockAccessor.ClaimDueWebhookDeliveries(ctx, now, leaseUntil, 10)
+		assertions.Nil(err)
+		assertions.Len(pendingDeliveries, 1)
+		assertions.Equal("https://example.com/hook", pendingDeliveries[0].URL)
+		assertions.Equal(int64(7), pendingDeliveries[0].Envelope.ID)
+		assertions.Equal(events.KindCommentAdded, pendingDeliveries[0].Envelope.Kind)
+		assertions.Equal(int32(500), *pendingDeliveries[0].Delivery.LastStatusCode)
+		assertions.True(leaseUntil.Equal(pendingDeliveries[0].Delivery.NextAttemptDate))
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Update Webhook Delivery", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		now := time.Now()
+		statusCode := int32(200)
+		delivery := &model.WebhookDelivery{
+			ID:              3,
+			Status:          model.WebhookDeliveryStatusSucceeded,
+			Attempts:        2,
+			LastStatusCode:  &statusCode,
+			NextAttemptDate: now,
+			DeliveryDate:    &now,
+		}
+
+		mock.ExpectExec(`UPDATE webhook_deliveries SET status = \$1, attempts = \$2, last_status_code = \$3, last_error = \$4,
+								next_attempt_date = \$5, delivery_date = \$6
+							WHERE delivery_id = \$7`).
+			WithArgs(model.WebhookDeliveryStatusSucceeded, int32(2), &statusCode, nil, now, &now, int64(3)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := mockAccessor.UpdateWebhookDelivery(ctx, delivery)
+		assertions.Nil(err)
+		assertions.Nil(mock.ExpectationsWereMet())
+	})
+
+	t.Run("Successful Get Webhook Deliveries", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		limit := int32(2)
+		status := model.WebhookDeliveryStatusDead
+
+		mock.ExpectQuery(`SELECT owner_id`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"owner_id"}).AddRow(ownerID))
+		mock.ExpectQuery(`FROM webhook_deliveries
+								JOIN outbox ON outbox.event_id = webhook_deliveries.event_id
+								WHERE webhook_deliveries.subscription_id = \$1 AND webhook_deliveries.status = \$2 AND webhook_deliveries.delivery_id < \$3
+								ORDER BY webhook_deliveries.delivery_id DESC
+								LIMIT \$4`).
+			WithArgs(int64(2), status, int64(10), limit).
+			WillReturnRows(sqlmock.NewRows(deliveryColumns).
+				AddRow(int64(3), int64(2), int64(7), "COMMENT_ADDED", "DEAD", int32(5), nil, "connection refused", time.Now(), time.Now(), nil))
+
+		deliveries, err := mockAccessor.GetWebhookDeliveries(ctx, 2, ownerID, &status, &cursor.DeliveryCursor{DeliveryID: 10}, &limit)
+		assertions.Nil(err)
+		assertions.Len(deliveries, 1)
+		assertions.Equal(model.WebhookDeliveryStatusDead, deliveries[0].Status)
+		assertions.Nil(deliveries[0].LastStatusCode)
+		assertions.Equal("connection refused", *deliveries[0].LastError)
+	})
+
+	t.Run("Unsuccessful Get Webhook Deliveries not found", func(t *testing.T) {
+		mockAccessor, mock := getMockAccessor(t)
+		defer mockAccessor.CloseStorage()
+
+		mock.ExpectQuery(`SELECT owner_id`).
+			WithArgs(int64(2)).
+			WillReturnRows(sqlmock.NewRows([]string{"owner_id"}))
+
+		deliveries, err := mockAccessor.GetWebhookDeliveries(ctx, 2, ownerID, nil, nil, nil)
+		assertions.NotNil(err)
+		assertions.Nil(deliveries)
+	})
+}
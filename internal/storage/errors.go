@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+)
+
+// ErrNotFound is returned when the record does not exist or the user may not see it.
+var ErrNotFound = errors.New("Record was not found.")
+
+// notFoundError names the missing record and matches ErrNotFound in errors.Is.
+type notFoundError struct {
+	message string
+}
+
+func (err *notFoundError) Error() string {
+	return err.message
+}
+
+func (err *notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// NotFound returns an error with the message, which is treated as ErrNotFound.
+func NotFound(message string) error {
+	return &notFoundError{message: message}
+}
+
+// IsInternal reports whether the error is a failure of the storage itself, like a lost connection or a failed query,
+// and not a problem of the request. Errors of Postgres are recognized by their SQLSTATE code.
+func IsInternal(err error) bool {
+	var stateErr interface{ SQLState() string }
+	var netErr net.Error
+
+	return errors.As(err, &stateErr) ||
+		errors.As(err, &netErr) ||
+		errors.Is(err, sql.ErrConnDone) ||
+		errors.Is(err, sql.ErrTxDone) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
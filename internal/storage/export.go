@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+)
+
+// ExportFilter selects posts to export. Nil fields do not restrict the selection.
+type ExportFilter struct {
+	PostID        *int64
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// ExportPost is a post with its settings that are not fields of the post. MaxReplyDepth is nil when the post
+// follows the policy of the service.
+type ExportPost struct {
+	Post          *model.Post
+	MaxReplyDepth *int32
+}
+
+// TreeComment is a comment with its path in the comment tree: the ID of the post followed by the IDs
+// of the ancestors of the comment, separated by dots. PinnedAt is set when the comment is pinned.
+type TreeComment struct {
+	Comment  *model.Comment
+	Path     string
+	PinnedAt *time.Time
+}
+
+// OtherRecords counts records that archives and storage migrations do not carry, because they keep only posts
+// and comments. Revisions count edits, the first revision of every post and comment is recreated from its text.
+type OtherRecords struct {
+	Users                int64
+	Revisions            int64
+	Reactions            int64
+	Bans                 int64
+	Notifications        int64
+	WebhookSubscriptions int64
+}
+
+// IsEmpty reports whether there are no such records.
+func (records *OtherRecords) IsEmpty() bool {
+	return *records == OtherRecords{}
+}
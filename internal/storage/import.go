@@ -0,0 +1,9 @@
+package storage
+
+// ImportKind is the kind of imported records, which have separate ID mappings.
+type ImportKind string
+
+const (
+	ImportKindPost    ImportKind = "POST"
+	ImportKindComment ImportKind = "COMMENT"
+)
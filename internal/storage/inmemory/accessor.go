@@ -1,41 +1,96 @@
 package inmemory
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"slices"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
 	"github.com/google/uuid"
 )
 
-const (
-	maxCommentTextLength = 2000
-)
+// commentHook reacts to a domain event of the add-comment flow.
+type commentHook func(comment *model.Comment)
 
 type InMemoryAccessor struct {
-	storage       *InMemoryStorage
-	lastPostID    int64
-	lastCommentID int64
+	storage              *InMemoryStorage
+	lastPostID           int64
+	lastCommentID        int64
+	lastNotificationID   int64
+	lastEventID          int64
+	lastWebhookID        int64
+	lastDeliveryID       int64
+	lastRevisionID       int64
+	lastBanID            int64
+	eventsMutex          *sync.Mutex
+	webhooksMutex        *sync.Mutex
+	revisionsMutex       *sync.Mutex
+	bansMutex            *sync.Mutex
+	pinsMutex            *sync.Mutex
+	publicationMutex     *sync.Mutex
+	importMutex          *sync.Mutex
+	keepImportedIDs      bool
+	commentApprovedHooks []commentHook
 }
 
 func NewInMemoryAccessor(storage *InMemoryStorage) *InMemoryAccessor {
-	return &InMemoryAccessor{
-		storage:       storage,
-		lastPostID:    -1,
-		lastCommentID: -1,
+	inMemoryAccessor := &InMemoryAccessor{
+		storage:            storage,
+		lastPostID:         -1,
+		lastCommentID:      -1,
+		lastNotificationID: -1,
+		lastEventID:        -1,
+		lastWebhookID:      -1,
+		lastDeliveryID:     -1,
+		lastRevisionID:     -1,
+		lastBanID:          -1,
+		eventsMutex:        &sync.Mutex{},
+		webhooksMutex:      &sync.Mutex{},
+		revisionsMutex:     &sync.Mutex{},
+		bansMutex:          &sync.Mutex{},
+		pinsMutex:          &sync.Mutex{},
+		publicationMutex:   &sync.Mutex{},
+		importMutex:        &sync.Mutex{},
+	}
+
+	inMemoryAccessor.commentApprovedHooks = []commentHook{
+		inMemoryAccessor.addReplyNotification,
+		inMemoryAccessor.addCommentMentionNotifications,
+	}
+
+	return inMemoryAccessor
+}
+
+// onCommentApproved runs hooks when a comment becomes visible: right after it is added or after approval.
+func (inMemoryAccessor *InMemoryAccessor) onCommentApproved(comment *model.Comment) {
+	for _, hook := range inMemoryAccessor.commentApprovedHooks {
+		hook(comment)
 	}
 }
 
 func (inMemoryAccessor *InMemoryAccessor) AddPost(ctx context.Context, newPost *model.PostInput) (*model.Post, error) {
+	if err := helpers.CheckMaxReplyDepth(newPost.MaxReplyDepth); err != nil {
+		return nil, err
+	}
+
+	moderationMode := helpers.GetModerationMode(newPost.CommentsEnabled, newPost.ModerationMode)
 	post := &model.Post{
 		AuthorID:        newPost.AuthorID,
 		Title:           newPost.Title,
 		Text:            newPost.Text,
-		CommentsEnabled: newPost.CommentsEnabled,
+		CommentsEnabled: moderationMode != model.ModerationModeClosed,
+		ModerationMode:  moderationMode,
 		CreateDate:      time.Now(),
 	}
+	post.Status, post.PublishAt = helpers.GetPostPublication(newPost.Status, newPost.PublishAt, post.CreateDate)
 
 	select {
 	case <-ctx.Done():
@@ -47,12 +102,25 @@ func (inMemoryAccessor *InMemoryAccessor) AddPost(ctx context.Context, newPost *
 	inMemoryAccessor.lastPostID++
 	post.ID = inMemoryAccessor.lastPostID
 
+	refs := textrefs.Extract(post.Title, post.Text)
+	inMemoryAccessor.storage.postTags.Set(post.ID, slices.Sorted(slices.Values(refs.Tags)))
+	inMemoryAccessor.storage.postMentions.Set(post.ID, inMemoryAccessor.getMentionedUsers(refs.Mentions))
+	if newPost.MaxReplyDepth != nil {
+		inMemoryAccessor.storage.postReplyDepths.Set(post.ID, *newPost.MaxReplyDepth)
+	}
+
 	inMemoryAccessor.storage.posts.Set(post.ID, post)
+	inMemoryAccessor.storage.postsIndex.Add(post.ID, post.Title+" "+post.Text)
+	inMemoryAccessor.addPostRevision(post, post.AuthorID, post.CreateDate)
+	if post.Status == model.PostStatusPublished {
+		inMemoryAccessor.addPostMentionNotifications(post)
+	}
 
 	return post, nil
 }
 
-func (inMemoryAccessor *InMemoryAccessor) GetPost(ctx context.Context, postID int64) (*model.Post, error) {
+// GetPost returns the post if the viewer may see it: drafts are visible only to their authors.
+func (inMemoryAccessor *InMemoryAccessor) GetPost(ctx context.Context, postID int64, viewerID uuid.UUID) (*model.Post, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -60,16 +128,131 @@ func (inMemoryAccessor *InMemoryAccessor) GetPost(ctx context.Context, postID in
 	default:
 	}
 
-	post, ok := inMemoryAccessor.storage.posts.Get(postID)
+	post, ok := inMemoryAccessor.getPost(postID)
 
-	if ok {
+	if ok && helpers.IsPostVisible(post, viewerID) {
 		return post, nil
 	} else {
-		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+}
+
+// GetPostMaxReplyDepth returns the maximum reply depth set for the post or nil if the post uses the global one.
+func (inMemoryAccessor *InMemoryAccessor) GetPostMaxReplyDepth(ctx context.Context, postID int64) (*int32, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	if _, ok := inMemoryAccessor.getPost(postID); !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	maxReplyDepth, ok := inMemoryAccessor.storage.postReplyDepths.Get(postID)
+	if !ok {
+		return nil, nil
+	}
+
+	return &maxReplyDepth, nil
+}
+
+func (InMemoryAccessor *InMemoryAccessor) GetAllPosts(ctx context.Context, viewerID uuid.UUID) ([]*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	posts := slices.DeleteFunc(InMemoryAccessor.storage.posts.GetValues(), func(post *model.Post) bool {
+		return post.DeletedAt != nil || !helpers.IsPostVisible(post, viewerID)
+	})
+	slices.SortFunc(posts, func(a, b *model.Post) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return posts, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPosts(ctx context.Context, filter *model.PostsFilter, viewerID uuid.UUID, after *cursor.PostCursor, limit *int32) ([]*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	posts := make([]*model.Post, 0)
+	for _, post := range inMemoryAccessor.storage.posts.GetValues() {
+		tags, _ := inMemoryAccessor.storage.postTags.Get(post.ID)
+		if post.DeletedAt != nil || !helpers.IsPostVisible(post, viewerID) || !matchesPostsFilter(post, tags, filter) {
+			continue
+		}
+
+		if after != nil && comparePostPositions(post.CreateDate.UnixNano(), post.ID, after.SortKey, after.PostID) <= 0 {
+			continue
+		}
+
+		posts = append(posts, post)
+	}
+
+	slices.SortFunc(posts, func(a, b *model.Post) int {
+		return comparePostPositions(a.CreateDate.UnixNano(), a.ID, b.CreateDate.UnixNano(), b.ID)
+	})
+
+	if limit != nil && len(posts) > int(*limit) {
+		posts = posts[:*limit]
 	}
+
+	return posts, nil
+}
+
+func matchesPostsFilter(post *model.Post, tags []string, filter *model.PostsFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.AuthorID != nil && post.AuthorID != *filter.AuthorID {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && !post.CreateDate.After(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.CreatedBefore != nil && !post.CreateDate.Before(*filter.CreatedBefore) {
+		return false
+	}
+
+	if filter.CommentsEnabled != nil && post.CommentsEnabled != *filter.CommentsEnabled {
+		return false
+	}
+
+	if filter.Tag != nil && !slices.Contains(tags, textrefs.NormalizeTag(*filter.Tag)) {
+		return false
+	}
+
+	return true
+}
+
+// comparePostPositions orders posts from the newest to the oldest.
+func comparePostPositions(sortKeyA int64, postIDA int64, sortKeyB int64, postIDB int64) int {
+	return cmp.Or(cmp.Compare(sortKeyB, sortKeyA), cmp.Compare(postIDB, postIDA))
 }
 
-func (InMemoryAccessor *InMemoryAccessor) GetAllPosts(ctx context.Context) ([]*model.Post, error) {
+func (inMemoryAccessor *InMemoryAccessor) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, privileged bool, newCommentsEnabled bool) (*model.Post, error) {
+	post, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if post.AuthorID != authorID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -77,14 +260,16 @@ func (InMemoryAccessor *InMemoryAccessor) GetAllPosts(ctx context.Context) ([]*m
 	default:
 	}
 
-	return InMemoryAccessor.storage.posts.GetValues(), nil
+	post.ModerationMode = helpers.SwitchCommentsEnabled(post.ModerationMode, newCommentsEnabled)
+	post.CommentsEnabled = newCommentsEnabled
+	return post, nil
 }
 
-func (inMemoryAccessor *InMemoryAccessor) UpdateCommentsEnabled(ctx context.Context, postID int64, authorID uuid.UUID, newCommentsEnabled bool) (*model.Post, error) {
-	post, ok := inMemoryAccessor.storage.posts.Get(postID)
+func (inMemoryAccessor *InMemoryAccessor) UpdateModerationMode(ctx context.Context, postID int64, authorID uuid.UUID, newModerationMode model.ModerationMode) (*model.Post, error) {
+	post, ok := inMemoryAccessor.getPost(postID)
 
 	if !ok {
-		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
 	}
 
 	if post.AuthorID != authorID {
@@ -98,23 +283,28 @@ func (inMemoryAccessor *InMemoryAccessor) UpdateCommentsEnabled(ctx context.Cont
 	default:
 	}
 
-	post.CommentsEnabled = newCommentsEnabled
+	post.ModerationMode = newModerationMode
+	post.CommentsEnabled = newModerationMode != model.ModerationModeClosed
 	return post, nil
 }
 
 func (inMemoryAccessor *InMemoryAccessor) AddComment(ctx context.Context, newComment *model.CommentInput) (*model.Comment, error) {
-	post, ok := inMemoryAccessor.storage.posts.Get(newComment.PostID)
+	post, ok := inMemoryAccessor.getPost(newComment.PostID)
 
 	if !ok {
-		return nil, errors.New("Post with ID " + strconv.FormatInt(newComment.PostID, 10) + " was not found")
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(newComment.PostID, 10) + " was not found")
+	}
+
+	if post.Status != model.PostStatusPublished {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(post.ID, 10) + " is not published.")
 	}
 
 	if !post.CommentsEnabled {
 		return nil, errors.New("Comments on post " + strconv.FormatInt(post.ID, 10) + " are disabled.")
 	}
 
-	if len(newComment.Text) > maxCommentTextLength {
-		return nil, errors.New("Length of the text ext in the new comment is too big (greater than " + strconv.Itoa(maxCommentTextLength) + ")")
+	if err := helpers.CheckCommentText(newComment.Text); err != nil {
+		return nil, err
 	}
 
 	comment := &model.Comment{
@@ -123,6 +313,7 @@ func (inMemoryAccessor *InMemoryAccessor) AddComment(ctx context.Context, newCom
 		ParentID:   newComment.ParentID,
 		Text:       newComment.Text,
 		CreateDate: time.Now(),
+		Status:     helpers.GetNewCommentStatus(post.ModerationMode),
 	}
 
 	select {
@@ -132,6 +323,14 @@ func (inMemoryAccessor *InMemoryAccessor) AddComment(ctx context.Context, newCom
 	default:
 	}
 
+	// Pending and rejected comments are hidden from other users, so only approved comments of the post accept replies.
+	if newComment.ParentID != nil {
+		parent, ok := inMemoryAccessor.getComment(*newComment.ParentID)
+		if !ok || parent.PostID != newComment.PostID || parent.Status != model.CommentStatusApproved {
+			return nil, storage.NotFound("Parent comment with ID " + strconv.FormatInt(*newComment.ParentID, 10) + " was not found")
+		}
+	}
+
 	newCommentPath, err := inMemoryAccessor.GetCommentPath(ctx, newComment.PostID, newComment.ParentID)
 
 	if err != nil {
@@ -150,16 +349,82 @@ func (inMemoryAccessor *InMemoryAccessor) AddComment(ctx context.Context, newCom
 
 	inMemoryAccessor.storage.commentsByPath.Set(newCommentPath, append(commentsByPath, comment.ID))
 	inMemoryAccessor.storage.commentPaths.Set(comment.ID, newCommentPath)
+	authorComments, _ := inMemoryAccessor.storage.commentsByAuthor.Get(comment.AuthorID)
+	inMemoryAccessor.storage.commentsByAuthor.Set(comment.AuthorID, append(slices.Clone(authorComments), comment.ID))
 	inMemoryAccessor.storage.comments.Set(comment.ID, comment)
+	inMemoryAccessor.storage.commentsIndex.Add(comment.ID, comment.Text)
+	inMemoryAccessor.addCommentRevision(comment, comment.AuthorID, comment.CreateDate)
+	inMemoryAccessor.storage.commentMentions.Set(comment.ID, inMemoryAccessor.getMentionedUsers(textrefs.Extract(comment.Text).Mentions))
+
+	if comment.Status == model.CommentStatusApproved {
+		inMemoryAccessor.changeCommentCounters(comment, 1)
+		inMemoryAccessor.onCommentApproved(comment)
+	}
 
 	return comment, nil
 }
 
+// GetComment returns the comment with any status unless it is deleted.
+func (inMemoryAccessor *InMemoryAccessor) GetComment(ctx context.Context, commentID int64) (*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comment, ok := inMemoryAccessor.getComment(commentID)
+
+	if !ok {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	return comment, nil
+}
+
+// changeCommentCounters shifts counters of the post, of the parent comment and of the comment level by delta.
+// Counters include only approved comments, so they must be changed whenever a comment becomes visible or hidden.
+func (inMemoryAccessor *InMemoryAccessor) changeCommentCounters(comment *model.Comment, delta int32) {
+	post, ok := inMemoryAccessor.storage.posts.Get(comment.PostID)
+	if ok {
+		post.CommentCount += delta
+	}
+
+	if comment.ParentID != nil {
+		parent, ok := inMemoryAccessor.storage.comments.Get(*comment.ParentID)
+		if ok {
+			parent.ReplyCount += delta
+		}
+	}
+
+	path, _ := inMemoryAccessor.storage.commentPaths.Get(comment.ID)
+	levelCount, _ := inMemoryAccessor.storage.levelCounts.Get(path)
+	inMemoryAccessor.storage.levelCounts.Set(path, levelCount+delta)
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsCount(ctx context.Context, postID int64, path string) (int32, error) {
+	_, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return 0, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	count, _ := inMemoryAccessor.storage.levelCounts.Get(path)
+	return count, nil
+}
+
 func (inMemoryAccessor *InMemoryAccessor) GetCommentPath(ctx context.Context, postID int64, parentID *int64) (string, error) {
-	_, ok := inMemoryAccessor.storage.posts.Get(postID)
+	_, ok := inMemoryAccessor.getPost(postID)
 
 	if !ok {
-		return "", errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+		return "", storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
 	}
 
 	select {
@@ -171,14 +436,14 @@ func (inMemoryAccessor *InMemoryAccessor) GetCommentPath(ctx context.Context, po
 
 	var commentPath string
 	if parentID != nil {
-		_, ok := inMemoryAccessor.storage.comments.Get(*parentID)
+		_, ok := inMemoryAccessor.getComment(*parentID)
 		if !ok {
-			return "", errors.New("Parent comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
+			return "", storage.NotFound("Parent comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
 		}
 
 		oldCommentPath, ok := inMemoryAccessor.storage.commentPaths.Get(*parentID)
 		if !ok {
-			return "", errors.New("Path for the comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
+			return "", storage.NotFound("Path for the comment with ID " + strconv.FormatInt(*parentID, 10) + " was not found")
 		}
 
 		commentPath = oldCommentPath + "." + strconv.FormatInt(*parentID, 10)
@@ -189,17 +454,152 @@ func (inMemoryAccessor *InMemoryAccessor) GetCommentPath(ctx context.Context, po
 	return commentPath, nil
 }
 
-func (inMemoryAccessor *InMemoryAccessor) GetCommentsLevel(ctx context.Context, postID int64, path string) ([]*model.Comment, error) {
-	_, ok := inMemoryAccessor.storage.posts.Get(postID)
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsLevel(ctx context.Context, postID int64, path string, order model.CommentsOrder, after *cursor.Cursor, limit *int32) ([]*model.Comment, error) {
+	_, ok := inMemoryAccessor.getPost(postID)
 
 	if !ok {
-		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	commentIDs, _ := inMemoryAccessor.storage.commentsByPath.Get(path)
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(postID)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comments := make([]*model.Comment, 0, len(commentIDs))
+	sortKeys := make(map[int64]int64, len(commentIDs))
+	for _, commentID := range commentIDs {
+		comment, _ := inMemoryAccessor.storage.comments.Get(commentID)
+		if comment.Status != model.CommentStatusApproved || slices.Contains(pinnedIDs, commentID) {
+			continue
+		}
+
+		if comment.DeletedAt != nil {
+			if !inMemoryAccessor.hasVisibleReplies(comment) {
+				continue
+			}
+
+			comment = helpers.NewCommentPlaceholder(comment)
+		}
+
+		sortKey := inMemoryAccessor.getCommentSortKey(comment, order)
+		if after != nil && compareCommentPositions(order, sortKey, comment.ID, after.SortKey, after.CommentID) <= 0 {
+			continue
+		}
+
+		sortKeys[comment.ID] = sortKey
+		comments = append(comments, comment)
+	}
+
+	slices.SortFunc(comments, func(a, b *model.Comment) int {
+		return compareCommentPositions(order, sortKeys[a.ID], a.ID, sortKeys[b.ID], b.ID)
+	})
+
+	if limit != nil && len(comments) > int(*limit) {
+		comments = comments[:*limit]
+	}
+
+	return comments, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) getCommentSortKey(comment *model.Comment, order model.CommentsOrder) int64 {
+	if order == model.CommentsOrderTop {
+		reactions, _ := inMemoryAccessor.storage.reactions.Get(reactionTarget{targetType: model.ReactionTargetComment, targetID: comment.ID})
+		return int64(len(reactions))
+	}
+
+	return comment.CreateDate.UnixNano()
+}
+
+// compareCommentPositions orders comments the same way as ORDER BY clauses of the database accessor.
+func compareCommentPositions(order model.CommentsOrder, sortKeyA int64, commentIDA int64, sortKeyB int64, commentIDB int64) int {
+	switch order {
+	case model.CommentsOrderNewest:
+		return cmp.Or(cmp.Compare(sortKeyB, sortKeyA), cmp.Compare(commentIDB, commentIDA))
+	case model.CommentsOrderTop:
+		return cmp.Or(cmp.Compare(sortKeyB, sortKeyA), cmp.Compare(commentIDA, commentIDB))
+	default:
+		return cmp.Or(cmp.Compare(sortKeyA, sortKeyB), cmp.Compare(commentIDA, commentIDB))
+	}
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsByAuthor(ctx context.Context, authorID uuid.UUID, after *cursor.AuthorCursor, limit *int32) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	commentIDs, _ := inMemoryAccessor.storage.commentsByAuthor.Get(authorID)
+
+	comments := make([]*model.Comment, 0, len(commentIDs))
+	for _, commentID := range commentIDs {
+		comment, _ := inMemoryAccessor.storage.comments.Get(commentID)
+		if !inMemoryAccessor.isListedByAuthor(comment) {
+			continue
+		}
+
+		if after != nil && compareCommentPositions(model.CommentsOrderNewest, comment.CreateDate.UnixNano(), comment.ID, after.SortKey, after.CommentID) <= 0 {
+			continue
+		}
+
+		comments = append(comments, comment)
+	}
+
+	slices.SortFunc(comments, func(a, b *model.Comment) int {
+		return compareCommentPositions(model.CommentsOrderNewest, a.CreateDate.UnixNano(), a.ID, b.CreateDate.UnixNano(), b.ID)
+	})
+
+	if limit != nil && len(comments) > int(*limit) {
+		comments = comments[:*limit]
+	}
+
+	return comments, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsByAuthorCount(ctx context.Context, authorID uuid.UUID) (int32, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	commentIDs, _ := inMemoryAccessor.storage.commentsByAuthor.Get(authorID)
+
+	var count int32
+	for _, commentID := range commentIDs {
+		comment, _ := inMemoryAccessor.storage.comments.Get(commentID)
+		if inMemoryAccessor.isListedByAuthor(comment) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// isListedByAuthor reports whether the comment is shown in the list of comments of its author: it is approved
+// and neither the comment nor its post is deleted. Deleting a post keeps its comments.
+func (inMemoryAccessor *InMemoryAccessor) isListedByAuthor(comment *model.Comment) bool {
+	if comment.Status != model.CommentStatusApproved || comment.DeletedAt != nil {
+		return false
 	}
 
-	commentIDs, ok := inMemoryAccessor.storage.commentsByPath.Get(path)
+	_, ok := inMemoryAccessor.getPost(comment.PostID)
+	return ok
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPendingComments(ctx context.Context, postID int64, viewerID uuid.UUID, privileged bool) ([]*model.Comment, error) {
+	post, ok := inMemoryAccessor.getPost(postID)
 
 	if !ok {
-		return nil, errors.New("Path is incorrect. Path: " + path)
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
 	}
 
 	select {
@@ -209,14 +609,61 @@ func (inMemoryAccessor *InMemoryAccessor) GetCommentsLevel(ctx context.Context,
 	default:
 	}
 
-	comments := make([]*model.Comment, len(commentIDs))
-	for idx, commentID := range commentIDs {
-		comments[idx], _ = inMemoryAccessor.storage.comments.Get(commentID)
+	comments := make([]*model.Comment, 0)
+	for _, comment := range inMemoryAccessor.storage.comments.GetValues() {
+		if comment.PostID != postID || comment.Status != model.CommentStatusPending || comment.DeletedAt != nil {
+			continue
+		}
+
+		if privileged || post.AuthorID == viewerID || comment.AuthorID == viewerID {
+			comments = append(comments, comment)
+		}
 	}
 
+	slices.SortFunc(comments, func(a, b *model.Comment) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
 	return comments, nil
 }
 
+func (inMemoryAccessor *InMemoryAccessor) UpdateCommentStatus(ctx context.Context, commentID int64, authorID uuid.UUID, newStatus model.CommentStatus) (*model.Comment, error) {
+	comment, ok := inMemoryAccessor.getComment(commentID)
+
+	if !ok {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	post, ok := inMemoryAccessor.getPost(comment.PostID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " was not found")
+	}
+
+	if post.AuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(post.ID, 10) + ".")
+	}
+
+	if comment.Status != model.CommentStatusPending {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not pending moderation.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comment.Status = newStatus
+	if newStatus == model.CommentStatusApproved {
+		inMemoryAccessor.changeCommentCounters(comment, 1)
+		inMemoryAccessor.onCommentApproved(comment)
+	}
+
+	return comment, nil
+}
+
 func (inMemoryAccessor *InMemoryAccessor) CloseStorage() {
 
 }
@@ -2,9 +2,13 @@ package inmemory
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
@@ -36,28 +40,38 @@ func TestAddPost(t *testing.T) {
 			Text:            newPost.Text,
 			CommentsEnabled: newPost.CommentsEnabled,
 			CreateDate:      createdPost.CreateDate,
+			ModerationMode:  model.ModerationModeOpen,
+			Status:          model.PostStatusPublished,
+			PublishAt:       &createdPost.CreateDate,
 		}, createdPost)
 	})
 
 	t.Run("Successful Update CommentsEnabled", func(t *testing.T) {
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, authorID, false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, authorID, false, false)
 		assertions.Nil(err)
 		assertions.NotNil(updatedPost)
 		assertions.Equal(updatedPost.CommentsEnabled, false)
 	})
 
 	t.Run("Unsuccessful Update CommentsEnabled Incorrect PostID", func(t *testing.T) {
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, -1, authorID, false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, -1, authorID, false, false)
 		assertions.NotNil(err)
 		assertions.Nil(updatedPost)
 	})
 
 	t.Run("Unsuccessful Update CommentsEnabled Incorrect AuthorID", func(t *testing.T) {
-		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, uuid.New(), false)
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, uuid.New(), false, false)
 		assertions.NotNil(err)
 		assertions.Nil(updatedPost)
 	})
 
+	t.Run("Successful Update CommentsEnabled Privileged", func(t *testing.T) {
+		updatedPost, err := mockAccessor.UpdateCommentsEnabled(ctx, 0, uuid.New(), true, false)
+		assertions.Nil(err)
+		assertions.Equal(authorID, updatedPost.AuthorID)
+		assertions.False(updatedPost.CommentsEnabled)
+	})
+
 	t.Run("Successful Get Post", func(t *testing.T) {
 		existingPost := &model.PostInput{
 			AuthorID:        authorID,
@@ -66,7 +80,7 @@ func TestAddPost(t *testing.T) {
 			CommentsEnabled: false,
 		}
 
-		post, err := mockAccessor.GetPost(ctx, 0)
+		post, err := mockAccessor.GetPost(ctx, 0, uuid.Nil)
 		assertions.Nil(err)
 		assertions.Equal(&model.Post{
 			ID:              0,
@@ -75,11 +89,14 @@ func TestAddPost(t *testing.T) {
 			Text:            existingPost.Text,
 			CommentsEnabled: existingPost.CommentsEnabled,
 			CreateDate:      post.CreateDate,
+			ModerationMode:  model.ModerationModeClosed,
+			Status:          model.PostStatusPublished,
+			PublishAt:       &post.CreateDate,
 		}, post)
 	})
 
 	t.Run("Unsuccessful Get Post Incorrect PostID", func(t *testing.T) {
-		post, err := mockAccessor.GetPost(ctx, -1)
+		post, err := mockAccessor.GetPost(ctx, -1, uuid.Nil)
 		assertions.NotNil(err)
 		assertions.Nil(post)
 	})
@@ -102,11 +119,14 @@ func TestAddPost(t *testing.T) {
 			Text:            newPost.Text,
 			CommentsEnabled: newPost.CommentsEnabled,
 			CreateDate:      createdPost.CreateDate,
+			ModerationMode:  model.ModerationModeOpen,
+			Status:          model.PostStatusPublished,
+			PublishAt:       &createdPost.CreateDate,
 		}, createdPost)
 	})
 
 	t.Run("Successful Get All Posts", func(t *testing.T) {
-		posts, err := mockAccessor.GetAllPosts(ctx)
+		posts, err := mockAccessor.GetAllPosts(ctx, uuid.Nil)
 		assertions.Nil(err)
 		assertions.Equal([]*model.Post{
 			{
@@ -116,6 +136,9 @@ func TestAddPost(t *testing.T) {
 				Text:            "Test Text",
 				CommentsEnabled: false,
 				CreateDate:      posts[0].CreateDate,
+				ModerationMode:  model.ModerationModeClosed,
+				Status:          model.PostStatusPublished,
+				PublishAt:       posts[0].PublishAt,
 			},
 			{
 				ID:              1,
@@ -124,6 +147,9 @@ func TestAddPost(t *testing.T) {
 				Text:            "Test Text",
 				CommentsEnabled: true,
 				CreateDate:      posts[1].CreateDate,
+				ModerationMode:  model.ModerationModeOpen,
+				Status:          model.PostStatusPublished,
+				PublishAt:       posts[1].PublishAt,
 			},
 		}, posts)
 	})
@@ -146,6 +172,7 @@ func TestAddPost(t *testing.T) {
 			ParentID:   nil,
 			Text:       newComment.Text,
 			CreateDate: createdComment.CreateDate,
+			Status:     model.CommentStatusApproved,
 		}, createdComment)
 	})
 
@@ -189,6 +216,19 @@ func TestAddPost(t *testing.T) {
 		assertions.Nil(createdComment)
 	})
 
+	t.Run("Unsuccessful Add Comment Too Long", func(t *testing.T) {
+		newComment := &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   1,
+			Text:     strings.Repeat("я", 2001),
+			ParentID: nil,
+		}
+
+		createdComment, err := mockAccessor.AddComment(ctx, newComment)
+		assertions.NotNil(err)
+		assertions.Nil(createdComment)
+	})
+
 	t.Run("Successful Add Another Comment", func(t *testing.T) {
 		newComment := &model.CommentInput{
 			AuthorID: authorID,
@@ -207,6 +247,7 @@ func TestAddPost(t *testing.T) {
 			ParentID:   nil,
 			Text:       newComment.Text,
 			CreateDate: createdComment.CreateDate,
+			Status:     model.CommentStatusApproved,
 		}, createdComment)
 	})
 
@@ -229,6 +270,7 @@ func TestAddPost(t *testing.T) {
 			ParentID:   &parentID,
 			Text:       newComment.Text,
 			CreateDate: createdComment.CreateDate,
+			Status:     model.CommentStatusApproved,
 		}, createdComment)
 	})
 
@@ -263,7 +305,7 @@ func TestAddPost(t *testing.T) {
 	})
 
 	t.Run("Successful Get Root Comments", func(t *testing.T) {
-		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1")
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1", model.CommentsOrderOldest, nil, nil)
 
 		assertions.Nil(err)
 		assertions.Equal([]*model.Comment{
@@ -274,6 +316,8 @@ func TestAddPost(t *testing.T) {
 				ParentID:   nil,
 				Text:       "Test Text",
 				CreateDate: comments[0].CreateDate,
+				Status:     model.CommentStatusApproved,
+				ReplyCount: 1,
 			},
 			{
 				ID:         1,
@@ -282,12 +326,13 @@ func TestAddPost(t *testing.T) {
 				ParentID:   nil,
 				Text:       "Test Text",
 				CreateDate: comments[1].CreateDate,
+				Status:     model.CommentStatusApproved,
 			},
 		}, comments)
 	})
 
 	t.Run("Successful Get Child Comments", func(t *testing.T) {
-		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1.0")
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 1, "1.0", model.CommentsOrderOldest, nil, nil)
 
 		parentID := int64(0)
 		assertions.Nil(err)
@@ -299,7 +344,464 @@ func TestAddPost(t *testing.T) {
 				ParentID:   &parentID,
 				Text:       "Test Text",
 				CreateDate: comments[0].CreateDate,
+				Status:     model.CommentStatusApproved,
 			},
 		}, comments)
 	})
 }
+
+func TestCommentModeration(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	commenterID := uuid.New()
+	ctx := context.Background()
+
+	premoderated := model.ModerationModePremoderated
+	_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+		AuthorID:        authorID,
+		Title:           "Test Title",
+		Text:            "Test Text",
+		CommentsEnabled: true,
+		ModerationMode:  &premoderated,
+	})
+	assertions.Nil(err)
+
+	t.Run("Successful Add Pending Comment", func(t *testing.T) {
+		newComment := &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Test Text",
+		}
+
+		createdComment, err := mockAccessor.AddComment(ctx, newComment)
+		assertions.Nil(err)
+		assertions.Equal(model.CommentStatusPending, createdComment.Status)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(comments)
+	})
+
+	t.Run("Successful Get Pending Comments", func(t *testing.T) {
+		comments, err := mockAccessor.GetPendingComments(ctx, 0, authorID, false)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+
+		comments, err = mockAccessor.GetPendingComments(ctx, 0, commenterID, false)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+
+		comments, err = mockAccessor.GetPendingComments(ctx, 0, uuid.New(), false)
+		assertions.Nil(err)
+		assertions.Empty(comments)
+
+		comments, err = mockAccessor.GetPendingComments(ctx, 0, uuid.New(), true)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+	})
+
+	t.Run("Unsuccessful Approve Comment Incorrect AuthorID", func(t *testing.T) {
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, commenterID, model.CommentStatusApproved)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Successful Approve Comment", func(t *testing.T) {
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, authorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+		assertions.Equal(model.CommentStatusApproved, comment.Status)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+	})
+
+	t.Run("Unsuccessful Reject Comment Not Pending", func(t *testing.T) {
+		comment, err := mockAccessor.UpdateCommentStatus(ctx, 0, authorID, model.CommentStatusRejected)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Successful Update Moderation Mode", func(t *testing.T) {
+		post, err := mockAccessor.UpdateModerationMode(ctx, 0, authorID, model.ModerationModeClosed)
+		assertions.Nil(err)
+		assertions.Equal(model.ModerationModeClosed, post.ModerationMode)
+		assertions.False(post.CommentsEnabled)
+
+		post, err = mockAccessor.UpdateCommentsEnabled(ctx, 0, authorID, false, true)
+		assertions.Nil(err)
+		assertions.Equal(model.ModerationModeOpen, post.ModerationMode)
+	})
+}
+
+func TestCommentsOrder(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+		AuthorID:        authorID,
+		Title:           "Test Title",
+		Text:            "Test Text",
+		CommentsEnabled: true,
+	})
+	assertions.Nil(err)
+
+	for range 3 {
+		_, err = mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   0,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+	}
+
+	for _, userID := range []uuid.UUID{uuid.New(), uuid.New()} {
+		err = mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetComment,
+			TargetID:   1,
+			UserID:     userID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.Nil(err)
+	}
+
+	getIDs := func(comments []*model.Comment) []int64 {
+		commentIDs := make([]int64, len(comments))
+		for idx, comment := range comments {
+			commentIDs[idx] = comment.ID
+		}
+		return commentIDs
+	}
+
+	t.Run("Successful Get Comments Oldest", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{0, 1, 2}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments Newest", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderNewest, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{2, 1, 0}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments Top", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderTop, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{1, 0, 2}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments Page After Cursor", func(t *testing.T) {
+		limit := int32(1)
+		after := &cursor.Cursor{
+			Order:     model.CommentsOrderTop.String(),
+			SortKey:   2,
+			Path:      "0",
+			CommentID: 1,
+		}
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0", model.CommentsOrderTop, after, &limit)
+		assertions.Nil(err)
+		assertions.Equal([]int64{0}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments Empty Level", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsLevel(ctx, 0, "0.2", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(comments)
+	})
+}
+
+func TestGetPosts(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	firstAuthorID := uuid.New()
+	secondAuthorID := uuid.New()
+	ctx := context.Background()
+
+	for idx, authorID := range []uuid.UUID{firstAuthorID, secondAuthorID, firstAuthorID} {
+		_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Test Title",
+			Text:            "Test Text",
+			CommentsEnabled: idx != 2,
+		})
+		assertions.Nil(err)
+	}
+
+	getIDs := func(posts []*model.Post) []int64 {
+		postIDs := make([]int64, len(posts))
+		for idx, post := range posts {
+			postIDs[idx] = post.ID
+		}
+		return postIDs
+	}
+
+	t.Run("Successful Get Posts Without Filter", func(t *testing.T) {
+		posts, err := mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{2, 1, 0}, getIDs(posts))
+	})
+
+	t.Run("Successful Get Posts By Author", func(t *testing.T) {
+		posts, err := mockAccessor.GetPosts(ctx, &model.PostsFilter{AuthorID: &firstAuthorID}, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{2, 0}, getIDs(posts))
+	})
+
+	t.Run("Successful Get Posts By Comments Enabled", func(t *testing.T) {
+		commentsEnabled := true
+		posts, err := mockAccessor.GetPosts(ctx, &model.PostsFilter{CommentsEnabled: &commentsEnabled}, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{1, 0}, getIDs(posts))
+	})
+
+	t.Run("Successful Get Posts By Create Date", func(t *testing.T) {
+		createdBefore := time.Now().Add(-time.Hour)
+		posts, err := mockAccessor.GetPosts(ctx, &model.PostsFilter{CreatedBefore: &createdBefore}, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(posts)
+	})
+
+	t.Run("Successful Get Posts Page After Cursor", func(t *testing.T) {
+		post, err := mockAccessor.GetPost(ctx, 2, uuid.Nil)
+		assertions.Nil(err)
+
+		limit := int32(1)
+		after := &cursor.PostCursor{
+			SortKey: post.CreateDate.UnixNano(),
+			PostID:  post.ID,
+		}
+
+		posts, err := mockAccessor.GetPosts(ctx, nil, uuid.Nil, after, &limit)
+		assertions.Nil(err)
+		assertions.Equal([]int64{1}, getIDs(posts))
+	})
+}
+
+func TestCommentCounters(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	premoderated := model.ModerationModePremoderated
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{
+		AuthorID:        authorID,
+		Title:           "Test Title",
+		Text:            "Test Text",
+		CommentsEnabled: true,
+		ModerationMode:  &premoderated,
+	})
+	assertions.Nil(err)
+
+	t.Run("Successful Count Approved Comments", func(t *testing.T) {
+		root, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   post.ID,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		_, err = mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   post.ID,
+			ParentID: &root.ID,
+			Text:     "Test Text",
+		})
+		assertions.ErrorIs(err, storage.ErrNotFound)
+
+		assertions.Equal(int32(0), post.CommentCount)
+
+		_, err = mockAccessor.UpdateCommentStatus(ctx, root.ID, authorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+
+		reply, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   post.ID,
+			ParentID: &root.ID,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		_, err = mockAccessor.UpdateCommentStatus(ctx, reply.ID, authorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+
+		assertions.Equal(int32(2), post.CommentCount)
+		assertions.Equal(int32(1), root.ReplyCount)
+		assertions.Equal(int32(0), reply.ReplyCount)
+	})
+
+	t.Run("Successful Get Comments Count", func(t *testing.T) {
+		count, err := mockAccessor.GetCommentsCount(ctx, post.ID, "0")
+		assertions.Nil(err)
+		assertions.Equal(int32(1), count)
+
+		count, err = mockAccessor.GetCommentsCount(ctx, post.ID, "0.0")
+		assertions.Nil(err)
+		assertions.Equal(int32(1), count)
+
+		count, err = mockAccessor.GetCommentsCount(ctx, post.ID, "0.1")
+		assertions.Nil(err)
+		assertions.Equal(int32(0), count)
+	})
+
+	t.Run("Successful Rejected Comment Is Not Counted", func(t *testing.T) {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   post.ID,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		_, err = mockAccessor.UpdateCommentStatus(ctx, comment.ID, authorID, model.CommentStatusRejected)
+		assertions.Nil(err)
+
+		assertions.Equal(int32(2), post.CommentCount)
+	})
+
+	t.Run("Unsuccessful Get Comments Count Post Does Not Exist", func(t *testing.T) {
+		count, err := mockAccessor.GetCommentsCount(ctx, -1, "-1")
+		assertions.NotNil(err)
+		assertions.Zero(count)
+	})
+}
+
+func TestCommentsByAuthor(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	otherAuthorID := uuid.New()
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Test Title",
+			Text:            "Test Text",
+			CommentsEnabled: true,
+		})
+		assertions.Nil(err)
+	}
+
+	for idx, commentAuthorID := range []uuid.UUID{authorID, otherAuthorID, authorID, authorID} {
+		_, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: commentAuthorID,
+			PostID:   int64(idx % 2),
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+	}
+
+	getIDs := func(comments []*model.Comment) []int64 {
+		commentIDs := make([]int64, len(comments))
+		for idx, comment := range comments {
+			commentIDs[idx] = comment.ID
+		}
+		return commentIDs
+	}
+
+	t.Run("Successful Get Comments By Author", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsByAuthor(ctx, authorID, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{3, 2, 0}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments By Author Page After Cursor", func(t *testing.T) {
+		comment, _ := mockStorage.comments.Get(3)
+		limit := int32(1)
+		after := &cursor.AuthorCursor{
+			SortKey:   comment.CreateDate.UnixNano(),
+			CommentID: comment.ID,
+		}
+
+		comments, err := mockAccessor.GetCommentsByAuthor(ctx, authorID, after, &limit)
+		assertions.Nil(err)
+		assertions.Equal([]int64{2}, getIDs(comments))
+	})
+
+	t.Run("Successful Get Comments By Author Count", func(t *testing.T) {
+		count, err := mockAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int32(3), count)
+	})
+
+	t.Run("Successful Get Comments By Unknown Author", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsByAuthor(ctx, uuid.New(), nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(comments)
+	})
+
+	t.Run("Successful Get Comments By Author Without Deleted Post", func(t *testing.T) {
+		_, err := mockAccessor.DeletePost(ctx, 1, authorID, false)
+		assertions.Nil(err)
+
+		comments, err := mockAccessor.GetCommentsByAuthor(ctx, authorID, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal([]int64{2, 0}, getIDs(comments))
+
+		count, err := mockAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int32(2), count)
+	})
+}
+
+func TestMaxReplyDepth(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Unsuccessful Add Post Negative Max Reply Depth", func(t *testing.T) {
+		maxReplyDepth := int32(-1)
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", MaxReplyDepth: &maxReplyDepth})
+		assertions.NotNil(err)
+		assertions.Nil(post)
+	})
+
+	t.Run("Successful Get Post Max Reply Depth", func(t *testing.T) {
+		maxReplyDepth := int32(2)
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", MaxReplyDepth: &maxReplyDepth})
+		assertions.Nil(err)
+
+		storedDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Equal(maxReplyDepth, *storedDepth)
+	})
+
+	t.Run("Successful Get Post Max Reply Depth Not Set", func(t *testing.T) {
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text"})
+		assertions.Nil(err)
+
+		storedDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Nil(storedDepth)
+	})
+
+	t.Run("Unsuccessful Get Post Max Reply Depth Post Not Found", func(t *testing.T) {
+		storedDepth, err := mockAccessor.GetPostMaxReplyDepth(ctx, 100)
+		assertions.NotNil(err)
+		assertions.Nil(storedDepth)
+	})
+}
@@ -0,0 +1,153 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// globalBanPostID marks global bans in banKey, post IDs are never negative.
+const globalBanPostID = -1
+
+func getBanKey(userID uuid.UUID, postID *int64) banKey {
+	if postID == nil {
+		return banKey{userID: userID, postID: globalBanPostID}
+	}
+
+	return banKey{userID: userID, postID: *postID}
+}
+
+// AddBan bans the user globally or on one post. A repeated ban of the same scope replaces the previous one.
+func (inMemoryAccessor *InMemoryAccessor) AddBan(ctx context.Context, newBan *model.BanInput, moderatorID uuid.UUID) (*model.Ban, error) {
+	if err := helpers.CheckBan(newBan); err != nil {
+		return nil, err
+	}
+
+	if newBan.PostID != nil {
+		if _, ok := inMemoryAccessor.getPost(*newBan.PostID); !ok {
+			return nil, storage.NotFound("Post with ID " + strconv.FormatInt(*newBan.PostID, 10) + " was not found")
+		}
+	}
+
+	ban := &model.Ban{
+		UserID:      newBan.UserID,
+		PostID:      newBan.PostID,
+		ModeratorID: moderatorID,
+		Reason:      newBan.Reason,
+		CreateDate:  time.Now(),
+		ExpiresAt:   newBan.ExpiresAt,
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.bansMutex.Unlock()
+	inMemoryAccessor.bansMutex.Lock()
+
+	key := getBanKey(ban.UserID, ban.PostID)
+	if previousBan, ok := inMemoryAccessor.storage.bans.Get(key); ok {
+		ban.ID = previousBan.ID
+	} else {
+		inMemoryAccessor.lastBanID++
+		ban.ID = inMemoryAccessor.lastBanID
+	}
+
+	inMemoryAccessor.storage.bans.Set(key, ban)
+
+	return ban, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) DeleteBan(ctx context.Context, userID uuid.UUID, postID *int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	key := getBanKey(userID, postID)
+	if _, ok := inMemoryAccessor.storage.bans.Get(key); !ok {
+		return storage.NotFound("Ban of the user with ID " + userID.String() + " was not found")
+	}
+
+	inMemoryAccessor.storage.bans.Delete(key)
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetBans(ctx context.Context, userID *uuid.UUID, postID *int64, activeOnly bool) ([]*model.Ban, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	now := time.Now()
+	bans := make([]*model.Ban, 0)
+	for _, ban := range inMemoryAccessor.storage.bans.GetValues() {
+		if userID != nil && ban.UserID != *userID {
+			continue
+		}
+
+		if postID != nil && (ban.PostID == nil || *ban.PostID != *postID) {
+			continue
+		}
+
+		if activeOnly && !helpers.IsBanActive(ban, now) {
+			continue
+		}
+
+		bans = append(bans, ban)
+	}
+
+	slices.SortFunc(bans, func(a, b *model.Ban) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return bans, nil
+}
+
+// IsBanned reports whether the user has an active global ban or, if postID is set, an active ban on the post.
+// Both scopes are found by their keys, so the check is cheap enough for every insert.
+func (inMemoryAccessor *InMemoryAccessor) IsBanned(ctx context.Context, userID uuid.UUID, postID *int64) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+
+	default:
+	}
+
+	now := time.Now()
+	if ban, ok := inMemoryAccessor.storage.bans.Get(getBanKey(userID, nil)); ok && helpers.IsBanActive(ban, now) {
+		return true, nil
+	}
+
+	if postID != nil {
+		if ban, ok := inMemoryAccessor.storage.bans.Get(getBanKey(userID, postID)); ok && helpers.IsBanActive(ban, now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// deletePostBans removes bans on the post, like the cascade on bans.post_id does in Postgres.
+func (inMemoryAccessor *InMemoryAccessor) deletePostBans(postID int64) {
+	for _, key := range inMemoryAccessor.storage.bans.GetKeys() {
+		if key.postID == postID {
+			inMemoryAccessor.storage.bans.Delete(key)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBans(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	userID := uuid.New()
+	moderatorID := uuid.New()
+	ctx := context.Background()
+
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: uuid.New(), Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	otherPostID := post.ID + 1
+
+	t.Run("Successful Add Post Ban", func(t *testing.T) {
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, PostID: &post.ID}, moderatorID)
+		assertions.Nil(err)
+		assertions.Equal(moderatorID, ban.ModeratorID)
+
+		banned, err := mockAccessor.IsBanned(ctx, userID, &post.ID)
+		assertions.Nil(err)
+		assertions.True(banned)
+
+		banned, err = mockAccessor.IsBanned(ctx, userID, &otherPostID)
+		assertions.Nil(err)
+		assertions.False(banned)
+
+		banned, err = mockAccessor.IsBanned(ctx, userID, nil)
+		assertions.Nil(err)
+		assertions.False(banned)
+	})
+
+	t.Run("Successful Replace Post Ban", func(t *testing.T) {
+		reason := "Spam"
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, PostID: &post.ID, Reason: &reason}, moderatorID)
+		assertions.Nil(err)
+		assertions.Equal(int64(0), ban.ID)
+
+		bans, err := mockAccessor.GetBans(ctx, &userID, nil, true)
+		assertions.Nil(err)
+		assertions.Len(bans, 1)
+		assertions.Equal(&reason, bans[0].Reason)
+	})
+
+	t.Run("Unsuccessful Add Ban Post Not Found", func(t *testing.T) {
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, PostID: &otherPostID}, moderatorID)
+		assertions.NotNil(err)
+		assertions.Nil(ban)
+	})
+
+	t.Run("Unsuccessful Add Ban Expired", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Minute)
+		ban, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, ExpiresAt: &expiresAt}, moderatorID)
+		assertions.NotNil(err)
+		assertions.Nil(ban)
+	})
+
+	t.Run("Successful Add Global Ban With Expiry", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		_, err := mockAccessor.AddBan(ctx, &model.BanInput{UserID: userID, ExpiresAt: &expiresAt}, moderatorID)
+		assertions.Nil(err)
+
+		banned, err := mockAccessor.IsBanned(ctx, userID, &otherPostID)
+		assertions.Nil(err)
+		assertions.True(banned)
+	})
+
+	t.Run("Successful Expired Ban Is Not Active", func(t *testing.T) {
+		globalBan, _ := mockStorage.bans.Get(getBanKey(userID, nil))
+		expiresAt := time.Now().Add(-time.Second)
+		globalBan.ExpiresAt = &expiresAt
+
+		banned, err := mockAccessor.IsBanned(ctx, userID, nil)
+		assertions.Nil(err)
+		assertions.False(banned)
+
+		bans, err := mockAccessor.GetBans(ctx, &userID, nil, true)
+		assertions.Nil(err)
+		assertions.Len(bans, 1)
+
+		bans, err = mockAccessor.GetBans(ctx, &userID, nil, false)
+		assertions.Nil(err)
+		assertions.Len(bans, 2)
+	})
+
+	t.Run("Successful Delete Ban", func(t *testing.T) {
+		err := mockAccessor.DeleteBan(ctx, userID, &post.ID)
+		assertions.Nil(err)
+
+		banned, err := mockAccessor.IsBanned(ctx, userID, &post.ID)
+		assertions.Nil(err)
+		assertions.False(banned)
+	})
+
+	t.Run("Unsuccessful Delete Ban Not Found", func(t *testing.T) {
+		err := mockAccessor.DeleteBan(ctx, userID, &post.ID)
+		assertions.NotNil(err)
+	})
+}
@@ -0,0 +1,299 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// getPost returns the post unless it does not exist or is deleted.
+func (inMemoryAccessor *InMemoryAccessor) getPost(postID int64) (*model.Post, bool) {
+	post, ok := inMemoryAccessor.storage.posts.Get(postID)
+	if !ok || post.DeletedAt != nil {
+		return nil, false
+	}
+
+	return post, true
+}
+
+// getComment returns the comment unless it does not exist or is deleted.
+func (inMemoryAccessor *InMemoryAccessor) getComment(commentID int64) (*model.Comment, bool) {
+	comment, ok := inMemoryAccessor.storage.comments.Get(commentID)
+	if !ok || comment.DeletedAt != nil {
+		return nil, false
+	}
+
+	return comment, true
+}
+
+// getRepliesPath returns the path of the level with direct replies to the comment.
+func (inMemoryAccessor *InMemoryAccessor) getRepliesPath(comment *model.Comment) string {
+	path, _ := inMemoryAccessor.storage.commentPaths.Get(comment.ID)
+	return path + "." + strconv.FormatInt(comment.ID, 10)
+}
+
+// hasVisibleReplies reports whether any reply to the comment, direct or nested, is approved and not deleted.
+func (inMemoryAccessor *InMemoryAccessor) hasVisibleReplies(comment *model.Comment) bool {
+	replyIDs, _ := inMemoryAccessor.storage.commentsByPath.Get(inMemoryAccessor.getRepliesPath(comment))
+	for _, replyID := range replyIDs {
+		reply, _ := inMemoryAccessor.storage.comments.Get(replyID)
+		if reply.Status == model.CommentStatusApproved && reply.DeletedAt == nil {
+			return true
+		}
+
+		if inMemoryAccessor.hasVisibleReplies(reply) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (inMemoryAccessor *InMemoryAccessor) DeletePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool) (*model.Post, error) {
+	post, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if post.AuthorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	deletedAt := time.Now()
+	post.DeletedAt = &deletedAt
+
+	return post, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) RestorePost(ctx context.Context, postID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Post, error) {
+	post, ok := inMemoryAccessor.storage.posts.Get(postID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if post.AuthorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	if post.DeletedAt == nil {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " is not deleted.")
+	}
+
+	if post.DeletedAt.Before(deletedAfter) {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " can no longer be restored.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	post.DeletedAt = nil
+
+	return post, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) DeleteComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool) (*model.Comment, error) {
+	comment, ok := inMemoryAccessor.getComment(commentID)
+
+	if !ok {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	if _, ok = inMemoryAccessor.getPost(comment.PostID); !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " was not found")
+	}
+
+	if comment.AuthorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	deletedAt := time.Now()
+	comment.DeletedAt = &deletedAt
+	if comment.Status == model.CommentStatusApproved {
+		inMemoryAccessor.changeCommentCounters(comment, -1)
+	}
+
+	inMemoryAccessor.pinsMutex.Lock()
+	inMemoryAccessor.removePin(comment)
+	inMemoryAccessor.pinsMutex.Unlock()
+
+	return comment, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) RestoreComment(ctx context.Context, commentID int64, userID uuid.UUID, privileged bool, deletedAfter time.Time) (*model.Comment, error) {
+	comment, ok := inMemoryAccessor.storage.comments.Get(commentID)
+
+	if !ok {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	if _, ok = inMemoryAccessor.getPost(comment.PostID); !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " was not found")
+	}
+
+	if comment.AuthorID != userID && !privileged {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(userID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	if comment.DeletedAt == nil {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not deleted.")
+	}
+
+	if comment.DeletedAt.Before(deletedAfter) {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " can no longer be restored.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comment.DeletedAt = nil
+	if comment.Status == model.CommentStatusApproved {
+		inMemoryAccessor.changeCommentCounters(comment, 1)
+	}
+
+	return comment, nil
+}
+
+// PurgeDeleted permanently removes posts and comments deleted before deletedBefore and returns their number.
+// A deleted comment stays while any of its replies is not purged, so its placeholder keeps the thread navigable.
+func (inMemoryAccessor *InMemoryAccessor) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	var purged int64
+	for _, post := range inMemoryAccessor.storage.posts.GetValues() {
+		if post.DeletedAt != nil && post.DeletedAt.Before(deletedBefore) {
+			inMemoryAccessor.purgePost(post)
+			purged++
+		}
+	}
+
+	for _, comment := range inMemoryAccessor.storage.comments.GetValues() {
+		if _, ok := inMemoryAccessor.storage.comments.Get(comment.ID); !ok {
+			continue
+		}
+
+		if inMemoryAccessor.isPurgeable(comment, deletedBefore) {
+			purged += inMemoryAccessor.purgeComment(comment)
+		}
+	}
+
+	return purged, nil
+}
+
+// isPurgeable reports whether the comment and all its replies were deleted before deletedBefore.
+func (inMemoryAccessor *InMemoryAccessor) isPurgeable(comment *model.Comment, deletedBefore time.Time) bool {
+	if comment.DeletedAt == nil || !comment.DeletedAt.Before(deletedBefore) {
+		return false
+	}
+
+	replyIDs, _ := inMemoryAccessor.storage.commentsByPath.Get(inMemoryAccessor.getRepliesPath(comment))
+	for _, replyID := range replyIDs {
+		reply, _ := inMemoryAccessor.storage.comments.Get(replyID)
+		if !inMemoryAccessor.isPurgeable(reply, deletedBefore) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// purgePost removes the post with all its comments.
+func (inMemoryAccessor *InMemoryAccessor) purgePost(post *model.Post) {
+	for _, comment := range inMemoryAccessor.storage.comments.GetValues() {
+		if comment.PostID == post.ID && comment.ParentID == nil {
+			inMemoryAccessor.purgeComment(comment)
+		}
+	}
+
+	rootPath := strconv.FormatInt(post.ID, 10)
+	for _, path := range inMemoryAccessor.storage.levelCounts.GetKeys() {
+		if path == rootPath || strings.HasPrefix(path, rootPath+".") {
+			inMemoryAccessor.storage.levelCounts.Delete(path)
+		}
+	}
+
+	inMemoryAccessor.storage.posts.Delete(post.ID)
+	inMemoryAccessor.storage.postsIndex.Remove(post.ID)
+	inMemoryAccessor.storage.postTags.Delete(post.ID)
+	inMemoryAccessor.storage.postReplyDepths.Delete(post.ID)
+	inMemoryAccessor.storage.pinnedComments.Delete(post.ID)
+	inMemoryAccessor.storage.postMentions.Delete(post.ID)
+	inMemoryAccessor.storage.postRevisions.Delete(post.ID)
+	inMemoryAccessor.storage.reactions.Delete(reactionTarget{targetType: model.ReactionTargetPost, targetID: post.ID})
+	inMemoryAccessor.deletePostBans(post.ID)
+	inMemoryAccessor.deleteNotifications(func(notification *model.Notification) bool {
+		return notification.PostID == post.ID
+	})
+}
+
+// purgeComment removes the comment with all its replies and returns the number of removed comments.
+func (inMemoryAccessor *InMemoryAccessor) purgeComment(comment *model.Comment) int64 {
+	purged := int64(1)
+
+	repliesPath := inMemoryAccessor.getRepliesPath(comment)
+	replyIDs, _ := inMemoryAccessor.storage.commentsByPath.Get(repliesPath)
+	for _, replyID := range replyIDs {
+		reply, _ := inMemoryAccessor.storage.comments.Get(replyID)
+		purged += inMemoryAccessor.purgeComment(reply)
+	}
+	inMemoryAccessor.storage.commentsByPath.Delete(repliesPath)
+
+	path, _ := inMemoryAccessor.storage.commentPaths.Get(comment.ID)
+	levelCommentIDs, _ := inMemoryAccessor.storage.commentsByPath.Get(path)
+	inMemoryAccessor.storage.commentsByPath.Set(path, slices.DeleteFunc(slices.Clone(levelCommentIDs), func(commentID int64) bool {
+		return commentID == comment.ID
+	}))
+
+	authorComments, _ := inMemoryAccessor.storage.commentsByAuthor.Get(comment.AuthorID)
+	inMemoryAccessor.storage.commentsByAuthor.Set(comment.AuthorID, slices.DeleteFunc(slices.Clone(authorComments), func(commentID int64) bool {
+		return commentID == comment.ID
+	}))
+
+	inMemoryAccessor.storage.comments.Delete(comment.ID)
+	inMemoryAccessor.storage.commentPaths.Delete(comment.ID)
+	inMemoryAccessor.storage.commentPinDates.Delete(comment.ID)
+	inMemoryAccessor.storage.commentsIndex.Remove(comment.ID)
+	inMemoryAccessor.storage.commentMentions.Delete(comment.ID)
+	inMemoryAccessor.storage.commentRevisions.Delete(comment.ID)
+	inMemoryAccessor.storage.reactions.Delete(reactionTarget{targetType: model.ReactionTargetComment, targetID: comment.ID})
+	inMemoryAccessor.deleteNotifications(func(notification *model.Notification) bool {
+		return notification.CommentID != nil && *notification.CommentID == comment.ID
+	})
+
+	return purged
+}
@@ -0,0 +1,157 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeletion(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	adminID := uuid.New()
+	ctx := context.Background()
+	restoreDeadline := time.Now().Add(-time.Hour)
+
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	root, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Root"})
+	assertions.Nil(err)
+
+	reply, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+	assertions.Nil(err)
+
+	t.Run("Unsuccessful Delete Post Not Author", func(t *testing.T) {
+		deletedPost, err := mockAccessor.DeletePost(ctx, post.ID, uuid.New(), false)
+		assertions.NotNil(err)
+		assertions.Nil(deletedPost)
+	})
+
+	t.Run("Successful Delete Post", func(t *testing.T) {
+		deletedPost, err := mockAccessor.DeletePost(ctx, post.ID, authorID, false)
+		assertions.Nil(err)
+		assertions.NotNil(deletedPost.DeletedAt)
+
+		_, err = mockAccessor.GetPost(ctx, post.ID, uuid.Nil)
+		assertions.NotNil(err)
+
+		posts, err := mockAccessor.GetAllPosts(ctx, uuid.Nil)
+		assertions.Nil(err)
+		assertions.Empty(posts)
+
+		posts, err = mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(posts)
+
+		_, err = mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Comment"})
+		assertions.NotNil(err)
+	})
+
+	t.Run("Unsuccessful Restore Post After Window", func(t *testing.T) {
+		restoredPost, err := mockAccessor.RestorePost(ctx, post.ID, authorID, false, time.Now().Add(time.Hour))
+		assertions.NotNil(err)
+		assertions.Nil(restoredPost)
+	})
+
+	t.Run("Successful Restore Post By Admin", func(t *testing.T) {
+		restoredPost, err := mockAccessor.RestorePost(ctx, post.ID, adminID, true, restoreDeadline)
+		assertions.Nil(err)
+		assertions.Nil(restoredPost.DeletedAt)
+
+		_, err = mockAccessor.GetPost(ctx, post.ID, uuid.Nil)
+		assertions.Nil(err)
+	})
+
+	t.Run("Unsuccessful Restore Post Not Deleted", func(t *testing.T) {
+		restoredPost, err := mockAccessor.RestorePost(ctx, post.ID, authorID, false, restoreDeadline)
+		assertions.NotNil(err)
+		assertions.Nil(restoredPost)
+	})
+
+	t.Run("Successful Delete Comment With Replies", func(t *testing.T) {
+		deletedComment, err := mockAccessor.DeleteComment(ctx, root.ID, authorID, false)
+		assertions.Nil(err)
+		assertions.NotNil(deletedComment.DeletedAt)
+		assertions.Equal(int32(1), post.CommentCount)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, post.ID, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+		assertions.Equal(root.ID, comments[0].ID)
+		assertions.Equal("", comments[0].Text)
+		assertions.Equal(uuid.Nil, comments[0].AuthorID)
+		assertions.Equal("Root", root.Text)
+
+		count, err := mockAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int32(1), count)
+	})
+
+	t.Run("Unsuccessful Reply To Deleted Comment", func(t *testing.T) {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Successful Delete Last Reply Hides Placeholder", func(t *testing.T) {
+		_, err := mockAccessor.DeleteComment(ctx, reply.ID, authorID, false)
+		assertions.Nil(err)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, post.ID, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(comments)
+	})
+
+	t.Run("Successful Restore Comment", func(t *testing.T) {
+		restoredComment, err := mockAccessor.RestoreComment(ctx, reply.ID, authorID, false, restoreDeadline)
+		assertions.Nil(err)
+		assertions.Nil(restoredComment.DeletedAt)
+		assertions.Equal(int32(1), post.CommentCount)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, post.ID, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+	})
+
+	t.Run("Successful Purge Deleted Keeps Comments With Replies", func(t *testing.T) {
+		purged, err := mockAccessor.PurgeDeleted(ctx, time.Now().Add(time.Second))
+		assertions.Nil(err)
+		assertions.Equal(int64(0), purged)
+
+		_, err = mockAccessor.DeleteComment(ctx, reply.ID, authorID, false)
+		assertions.Nil(err)
+
+		purged, err = mockAccessor.PurgeDeleted(ctx, time.Now().Add(time.Second))
+		assertions.Nil(err)
+		assertions.Equal(int64(2), purged)
+
+		restoredComment, err := mockAccessor.RestoreComment(ctx, root.ID, authorID, false, restoreDeadline)
+		assertions.NotNil(err)
+		assertions.Nil(restoredComment)
+	})
+
+	t.Run("Successful Purge Deleted Post", func(t *testing.T) {
+		_, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Comment"})
+		assertions.Nil(err)
+
+		_, err = mockAccessor.DeletePost(ctx, post.ID, adminID, true)
+		assertions.Nil(err)
+
+		purged, err := mockAccessor.PurgeDeleted(ctx, time.Now().Add(time.Second))
+		assertions.Nil(err)
+		assertions.Equal(int64(1), purged)
+
+		count, err := mockAccessor.GetCommentsByAuthorCount(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Equal(int32(0), count)
+	})
+}
@@ -0,0 +1,126 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"iter"
+	"slices"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+// IteratePosts yields all posts selected by the filter in the order of their IDs, including drafts and deleted posts.
+func (inMemoryAccessor *InMemoryAccessor) IteratePosts(ctx context.Context, filter *storage.ExportFilter) iter.Seq2[*storage.ExportPost, error] {
+	return func(yield func(*storage.ExportPost, error) bool) {
+		posts := slices.DeleteFunc(inMemoryAccessor.storage.posts.GetValues(), func(post *model.Post) bool {
+			return !matchesExportFilter(post, filter)
+		})
+		slices.SortFunc(posts, func(a, b *model.Post) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+
+		for _, post := range posts {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+
+			default:
+			}
+
+			exportPost := &storage.ExportPost{Post: post}
+			if maxReplyDepth, ok := inMemoryAccessor.storage.postReplyDepths.Get(post.ID); ok {
+				exportPost.MaxReplyDepth = &maxReplyDepth
+			}
+
+			if !yield(exportPost, nil) {
+				return
+			}
+		}
+	}
+}
+
+func matchesExportFilter(post *model.Post, filter *storage.ExportFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.PostID != nil && post.ID != *filter.PostID {
+		return false
+	}
+
+	if filter.CreatedAfter != nil && !post.CreateDate.After(*filter.CreatedAfter) {
+		return false
+	}
+
+	if filter.CreatedBefore != nil && !post.CreateDate.Before(*filter.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// IteratePostComments yields all comments of the post with their paths in the order of their IDs,
+// so every comment follows its parent. Deleted comments are included.
+func (inMemoryAccessor *InMemoryAccessor) IteratePostComments(ctx context.Context, postID int64) iter.Seq2[*storage.TreeComment, error] {
+	return func(yield func(*storage.TreeComment, error) bool) {
+		comments := slices.DeleteFunc(inMemoryAccessor.storage.comments.GetValues(), func(comment *model.Comment) bool {
+			return comment.PostID != postID
+		})
+		slices.SortFunc(comments, func(a, b *model.Comment) int {
+			return cmp.Compare(a.ID, b.ID)
+		})
+
+		for _, comment := range comments {
+			select {
+			case <-ctx.Done():
+				yield(nil, ctx.Err())
+				return
+
+			default:
+			}
+
+			path, _ := inMemoryAccessor.storage.commentPaths.Get(comment.ID)
+			treeComment := &storage.TreeComment{Comment: comment, Path: path}
+			if pinnedAt, ok := inMemoryAccessor.storage.commentPinDates.Get(comment.ID); ok {
+				treeComment.PinnedAt = &pinnedAt
+			}
+
+			if !yield(treeComment, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CountOtherRecords counts records other than posts and comments, which archives and migrations do not carry.
+func (inMemoryAccessor *InMemoryAccessor) CountOtherRecords(ctx context.Context) (*storage.OtherRecords, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	records := &storage.OtherRecords{
+		Users:                int64(len(inMemoryAccessor.storage.users.GetKeys())),
+		Bans:                 int64(len(inMemoryAccessor.storage.bans.GetKeys())),
+		Notifications:        int64(len(inMemoryAccessor.storage.notifications.GetKeys())),
+		WebhookSubscriptions: int64(len(inMemoryAccessor.storage.webhooks.GetKeys())),
+	}
+
+	for _, revisions := range inMemoryAccessor.storage.postRevisions.GetValues() {
+		records.Revisions += int64(len(revisions) - 1)
+	}
+
+	for _, revisions := range inMemoryAccessor.storage.commentRevisions.GetValues() {
+		records.Revisions += int64(len(revisions) - 1)
+	}
+
+	for _, userReactions := range inMemoryAccessor.storage.reactions.GetValues() {
+		records.Reactions += int64(len(userReactions))
+	}
+
+	return records, nil
+}
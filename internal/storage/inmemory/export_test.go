@@ -0,0 +1,98 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	first, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "First", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+	second, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Second", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	root, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: second.ID, Text: "Root"})
+	assertions.Nil(err)
+	reply, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: second.ID, ParentID: &root.ID, Text: "Reply"})
+	assertions.Nil(err)
+	_, err = mockAccessor.DeleteComment(ctx, reply.ID, authorID, false)
+	assertions.Nil(err)
+
+	t.Run("Successful Iterate Posts", func(t *testing.T) {
+		postIDs := make([]int64, 0)
+		for post, err := range mockAccessor.IteratePosts(ctx, nil) {
+			assertions.Nil(err)
+			postIDs = append(postIDs, post.Post.ID)
+		}
+		assertions.Equal([]int64{first.ID, second.ID}, postIDs)
+	})
+
+	t.Run("Successful Iterate Posts With Filter", func(t *testing.T) {
+		createdBefore := time.Now().Add(-time.Hour)
+		for range mockAccessor.IteratePosts(ctx, &storage.ExportFilter{CreatedBefore: &createdBefore}) {
+			assertions.Fail("No posts were expected")
+		}
+
+		postIDs := make([]int64, 0)
+		for post, err := range mockAccessor.IteratePosts(ctx, &storage.ExportFilter{PostID: &second.ID}) {
+			assertions.Nil(err)
+			postIDs = append(postIDs, post.Post.ID)
+		}
+		assertions.Equal([]int64{second.ID}, postIDs)
+	})
+
+	t.Run("Successful Iterate Post Comments", func(t *testing.T) {
+		comments := make([]*storage.TreeComment, 0)
+		for comment, err := range mockAccessor.IteratePostComments(ctx, second.ID) {
+			assertions.Nil(err)
+			comments = append(comments, comment)
+		}
+
+		assertions.Len(comments, 2)
+		assertions.Equal(root.ID, comments[0].Comment.ID)
+		assertions.Equal("1", comments[0].Path)
+		assertions.Equal(reply.ID, comments[1].Comment.ID)
+		assertions.Equal("1.0", comments[1].Path)
+		assertions.NotNil(comments[1].Comment.DeletedAt)
+	})
+
+	t.Run("Unsuccessful Iterate Posts Cancelled", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		for post, err := range mockAccessor.IteratePosts(cancelledCtx, nil) {
+			assertions.Nil(post)
+			assertions.ErrorIs(err, context.Canceled)
+		}
+	})
+
+	t.Run("Successful Count Other Records", func(t *testing.T) {
+		records, err := mockAccessor.CountOtherRecords(ctx)
+		assertions.Nil(err)
+		assertions.True(records.IsEmpty())
+
+		text := "Edited"
+		_, _, err = mockAccessor.EditPost(ctx, first.ID, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+		_, err = mockAccessor.AddUser(ctx, &model.UserInput{ID: &authorID, DisplayName: "Author"})
+		assertions.Nil(err)
+
+		records, err = mockAccessor.CountOtherRecords(ctx)
+		assertions.Nil(err)
+		assertions.Equal(&storage.OtherRecords{Users: 1, Revisions: 1}, records)
+	})
+}
@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+)
+
+// GetRecentComments returns the newest approved comments of the post at any level of the tree.
+func (inMemoryAccessor *InMemoryAccessor) GetRecentComments(ctx context.Context, postID int64, limit int32) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comments := slices.DeleteFunc(inMemoryAccessor.storage.comments.GetValues(), func(comment *model.Comment) bool {
+		return comment.PostID != postID || comment.Status != model.CommentStatusApproved || comment.DeletedAt != nil
+	})
+
+	slices.SortFunc(comments, func(a, b *model.Comment) int {
+		return compareCommentPositions(model.CommentsOrderNewest, a.CreateDate.UnixNano(), a.ID, b.CreateDate.UnixNano(), b.ID)
+	})
+
+	if len(comments) > int(limit) {
+		comments = comments[:limit]
+	}
+
+	return comments, nil
+}
+
+// GetPostsEditDates returns the dates of the latest revisions of the posts, which are their create dates
+// for posts that were never edited.
+func (inMemoryAccessor *InMemoryAccessor) GetPostsEditDates(ctx context.Context, postIDs []int64) (map[int64]time.Time, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	return getEditDates(inMemoryAccessor.storage.postRevisions, postIDs), nil
+}
+
+// GetCommentsEditDates returns the dates of the latest revisions of the comments, which are their create dates
+// for comments that were never edited.
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsEditDates(ctx context.Context, commentIDs []int64) (map[int64]time.Time, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	return getEditDates(inMemoryAccessor.storage.commentRevisions, commentIDs), nil
+}
+
+func getEditDates(revisions *helpers.SafeMap[int64, []*model.Revision], ids []int64) map[int64]time.Time {
+	editDates := make(map[int64]time.Time, len(ids))
+	for _, id := range ids {
+		if history, ok := revisions.Get(id); ok && len(history) > 0 {
+			editDates[id] = history[len(history)-1].CreateDate
+		}
+	}
+
+	return editDates
+}
@@ -0,0 +1,58 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeds(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+	root, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Root"})
+	assertions.Nil(err)
+	reply, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, ParentID: &root.ID, Text: "Reply"})
+	assertions.Nil(err)
+	deleted, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Deleted"})
+	assertions.Nil(err)
+	_, err = mockAccessor.DeleteComment(ctx, deleted.ID, authorID, false)
+	assertions.Nil(err)
+
+	t.Run("Successful Get Recent Comments", func(t *testing.T) {
+		comments, err := mockAccessor.GetRecentComments(ctx, post.ID, 10)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(reply.ID, comments[0].ID)
+		assertions.Equal(root.ID, comments[1].ID)
+
+		comments, err = mockAccessor.GetRecentComments(ctx, post.ID, 1)
+		assertions.Nil(err)
+		assertions.Len(comments, 1)
+	})
+
+	t.Run("Successful Get Edit Dates", func(t *testing.T) {
+		text := "Edited"
+		_, revision, err := mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+
+		postDates, err := mockAccessor.GetPostsEditDates(ctx, []int64{post.ID, post.ID + 1})
+		assertions.Nil(err)
+		assertions.Len(postDates, 1)
+		assertions.Equal(revision.CreateDate, postDates[post.ID])
+
+		commentDates, err := mockAccessor.GetCommentsEditDates(ctx, []int64{root.ID})
+		assertions.Nil(err)
+		assertions.Equal(root.CreateDate, commentDates[root.ID])
+	})
+}
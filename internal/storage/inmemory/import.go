@@ -0,0 +1,196 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+)
+
+// KeepImportedIDs makes imports keep IDs of the source when they are not taken. A memory snapshot restored
+// into an empty storage then has the same IDs as the storage it was taken from, on every restore.
+func (inMemoryAccessor *InMemoryAccessor) KeepImportedIDs(keep bool) {
+	defer inMemoryAccessor.importMutex.Unlock()
+	inMemoryAccessor.importMutex.Lock()
+
+	inMemoryAccessor.keepImportedIDs = keep
+}
+
+// GetImportedIDs returns IDs of the records imported from the source, keyed by their IDs in the source.
+// Records that were not imported are absent from the result.
+func (inMemoryAccessor *InMemoryAccessor) GetImportedIDs(ctx context.Context, source string, kind storage.ImportKind, sourceIDs []int64) (map[int64]int64, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	return inMemoryAccessor.getImportedIDs(source, kind, sourceIDs), nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) getImportedIDs(source string, kind storage.ImportKind, sourceIDs []int64) map[int64]int64 {
+	mappings := inMemoryAccessor.storage.importedPosts
+	if kind == storage.ImportKindComment {
+		mappings = inMemoryAccessor.storage.importedComments
+	}
+
+	importedIDs := make(map[int64]int64, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		if targetID, ok := mappings.Get(importKey{source: source, sourceID: sourceID}); ok {
+			importedIDs[sourceID] = targetID
+		}
+	}
+
+	return importedIDs
+}
+
+// ImportPosts adds posts of another storage with their IDs in that storage, keeping their create dates and reply depth limits.
+// Posts already imported from the source are skipped, so the import can be repeated. It returns the number of added posts.
+func (inMemoryAccessor *InMemoryAccessor) ImportPosts(ctx context.Context, source string, posts []*storage.ExportPost) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.importMutex.Unlock()
+	inMemoryAccessor.importMutex.Lock()
+
+	var imported int64
+	for _, exportPost := range posts {
+		sourcePost := exportPost.Post
+		key := importKey{source: source, sourceID: sourcePost.ID}
+		if _, ok := inMemoryAccessor.storage.importedPosts.Get(key); ok {
+			continue
+		}
+
+		post := &model.Post{
+			AuthorID:        sourcePost.AuthorID,
+			Title:           sourcePost.Title,
+			Text:            sourcePost.Text,
+			CreateDate:      sourcePost.CreateDate,
+			CommentsEnabled: sourcePost.CommentsEnabled,
+			ModerationMode:  helpers.GetModerationMode(sourcePost.CommentsEnabled, &sourcePost.ModerationMode),
+			Status:          sourcePost.Status,
+			PublishAt:       sourcePost.PublishAt,
+			DeletedAt:       sourcePost.DeletedAt,
+		}
+
+		if _, taken := inMemoryAccessor.storage.posts.Get(sourcePost.ID); inMemoryAccessor.keepImportedIDs && sourcePost.ID >= 0 && !taken {
+			post.ID = sourcePost.ID
+			inMemoryAccessor.lastPostID = max(inMemoryAccessor.lastPostID, post.ID)
+		} else {
+			inMemoryAccessor.lastPostID++
+			post.ID = inMemoryAccessor.lastPostID
+		}
+
+		refs := textrefs.Extract(post.Title, post.Text)
+		inMemoryAccessor.storage.postTags.Set(post.ID, slices.Sorted(slices.Values(refs.Tags)))
+		inMemoryAccessor.storage.postMentions.Set(post.ID, inMemoryAccessor.getMentionedUsers(refs.Mentions))
+		if exportPost.MaxReplyDepth != nil {
+			inMemoryAccessor.storage.postReplyDepths.Set(post.ID, *exportPost.MaxReplyDepth)
+		}
+
+		inMemoryAccessor.storage.posts.Set(post.ID, post)
+		inMemoryAccessor.storage.postsIndex.Add(post.ID, post.Title+" "+post.Text)
+		inMemoryAccessor.addPostRevision(post, post.AuthorID, post.CreateDate)
+		inMemoryAccessor.storage.importedPosts.Set(key, post.ID)
+		imported++
+	}
+
+	return imported, nil
+}
+
+// ImportComments adds comments of another storage. IDs of the comments, of their posts and of their parents are IDs
+// in that storage, and the posts and the parents must be imported before the comments or earlier in the same batch.
+// Paths are rebuilt from the new IDs and pinned comments stay pinned. Comments already imported from the source are skipped.
+// It returns the number of added comments.
+func (inMemoryAccessor *InMemoryAccessor) ImportComments(ctx context.Context, source string, comments []*storage.TreeComment) (int64, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.importMutex.Unlock()
+	inMemoryAccessor.importMutex.Lock()
+
+	var imported int64
+	for _, treeComment := range comments {
+		sourceComment := treeComment.Comment
+		key := importKey{source: source, sourceID: sourceComment.ID}
+		if _, ok := inMemoryAccessor.storage.importedComments.Get(key); ok {
+			continue
+		}
+
+		postID, ok := inMemoryAccessor.storage.importedPosts.Get(importKey{source: source, sourceID: sourceComment.PostID})
+		if !ok {
+			return imported, errors.New("Post with ID " + strconv.FormatInt(sourceComment.PostID, 10) + " was not imported")
+		}
+
+		path := strconv.FormatInt(postID, 10)
+		var parentID *int64
+		if sourceComment.ParentID != nil {
+			importedParentID, ok := inMemoryAccessor.storage.importedComments.Get(importKey{source: source, sourceID: *sourceComment.ParentID})
+			if !ok {
+				return imported, errors.New("Parent comment with ID " + strconv.FormatInt(*sourceComment.ParentID, 10) + " was not imported")
+			}
+
+			parentPath, _ := inMemoryAccessor.storage.commentPaths.Get(importedParentID)
+			path = parentPath + "." + strconv.FormatInt(importedParentID, 10)
+			parentID = &importedParentID
+		}
+
+		comment := &model.Comment{
+			AuthorID:   sourceComment.AuthorID,
+			PostID:     postID,
+			ParentID:   parentID,
+			Text:       sourceComment.Text,
+			CreateDate: sourceComment.CreateDate,
+			Status:     sourceComment.Status,
+			DeletedAt:  sourceComment.DeletedAt,
+		}
+
+		if _, taken := inMemoryAccessor.storage.comments.Get(sourceComment.ID); inMemoryAccessor.keepImportedIDs && sourceComment.ID >= 0 && !taken {
+			comment.ID = sourceComment.ID
+			inMemoryAccessor.lastCommentID = max(inMemoryAccessor.lastCommentID, comment.ID)
+		} else {
+			inMemoryAccessor.lastCommentID++
+			comment.ID = inMemoryAccessor.lastCommentID
+		}
+
+		commentsByPath, _ := inMemoryAccessor.storage.commentsByPath.Get(path)
+		inMemoryAccessor.storage.commentsByPath.Set(path, append(slices.Clone(commentsByPath), comment.ID))
+		inMemoryAccessor.storage.commentPaths.Set(comment.ID, path)
+		authorComments, _ := inMemoryAccessor.storage.commentsByAuthor.Get(comment.AuthorID)
+		inMemoryAccessor.storage.commentsByAuthor.Set(comment.AuthorID, append(slices.Clone(authorComments), comment.ID))
+		inMemoryAccessor.storage.comments.Set(comment.ID, comment)
+		inMemoryAccessor.storage.commentsIndex.Add(comment.ID, comment.Text)
+		inMemoryAccessor.addCommentRevision(comment, comment.AuthorID, comment.CreateDate)
+		inMemoryAccessor.storage.commentMentions.Set(comment.ID, inMemoryAccessor.getMentionedUsers(textrefs.Extract(comment.Text).Mentions))
+
+		// Imported comments are not new, so nobody is notified about them.
+		if comment.Status == model.CommentStatusApproved && comment.DeletedAt == nil {
+			inMemoryAccessor.changeCommentCounters(comment, 1)
+		}
+
+		if treeComment.PinnedAt != nil {
+			inMemoryAccessor.pinsMutex.Lock()
+			inMemoryAccessor.addPin(comment, *treeComment.PinnedAt)
+			inMemoryAccessor.pinsMutex.Unlock()
+		}
+
+		inMemoryAccessor.storage.importedComments.Set(key, comment.ID)
+		imported++
+	}
+
+	return imported, nil
+}
@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImport(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	createDate := time.Now().Add(-24 * time.Hour)
+
+	existing, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Existing", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	maxReplyDepth := int32(2)
+	posts := []*storage.ExportPost{
+		{Post: &model.Post{ID: 10, AuthorID: authorID, Title: "Imported", Text: "Text #go", CreateDate: createDate, CommentsEnabled: true,
+			ModerationMode: model.ModerationModeOpen, Status: model.PostStatusPublished}, MaxReplyDepth: &maxReplyDepth},
+	}
+	parentID := int64(20)
+	comments := []*storage.TreeComment{
+		{Comment: &model.Comment{ID: 20, AuthorID: authorID, PostID: 10, Text: "Root", CreateDate: createDate, Status: model.CommentStatusApproved},
+			PinnedAt: &createDate},
+		{Comment: &model.Comment{ID: 21, AuthorID: authorID, PostID: 10, ParentID: &parentID, Text: "Reply", CreateDate: createDate, Status: model.CommentStatusApproved}},
+	}
+
+	t.Run("Successful Import Posts", func(t *testing.T) {
+		imported, err := mockAccessor.ImportPosts(ctx, "test", posts)
+		assertions.Nil(err)
+		assertions.Equal(int64(1), imported)
+
+		importedIDs, err := mockAccessor.GetImportedIDs(ctx, "test", storage.ImportKindPost, []int64{10, 11})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]int64{10: existing.ID + 1}, importedIDs)
+
+		post, err := mockAccessor.GetPost(ctx, existing.ID+1, authorID)
+		assertions.Nil(err)
+		assertions.Equal("Imported", post.Title)
+		assertions.True(post.CreateDate.Equal(createDate))
+
+		importedDepth, ok := mockStorage.postReplyDepths.Get(existing.ID + 1)
+		assertions.True(ok)
+		assertions.Equal(maxReplyDepth, importedDepth)
+	})
+
+	t.Run("Successful Import Comments", func(t *testing.T) {
+		imported, err := mockAccessor.ImportComments(ctx, "test", comments)
+		assertions.Nil(err)
+		assertions.Equal(int64(2), imported)
+
+		importedIDs, err := mockAccessor.GetImportedIDs(ctx, "test", storage.ImportKindComment, []int64{20, 21})
+		assertions.Nil(err)
+		assertions.Len(importedIDs, 2)
+
+		rootPath, _ := mockStorage.commentPaths.Get(importedIDs[20])
+		assertions.Equal("1", rootPath)
+		replyPath, _ := mockStorage.commentPaths.Get(importedIDs[21])
+		assertions.Equal("1.0", replyPath)
+
+		reply, _ := mockStorage.comments.Get(importedIDs[21])
+		assertions.Equal(importedIDs[20], *reply.ParentID)
+
+		post, _ := mockStorage.posts.Get(existing.ID + 1)
+		assertions.Equal(int32(2), post.CommentCount)
+
+		pinned, err := mockAccessor.GetPinnedComments(ctx, existing.ID+1)
+		assertions.Nil(err)
+		assertions.Len(pinned, 1)
+		assertions.Equal(importedIDs[20], pinned[0].ID)
+	})
+
+	t.Run("Successful Repeated Import", func(t *testing.T) {
+		imported, err := mockAccessor.ImportPosts(ctx, "test", posts)
+		assertions.Nil(err)
+		assertions.Equal(int64(0), imported)
+
+		imported, err = mockAccessor.ImportComments(ctx, "test", comments)
+		assertions.Nil(err)
+		assertions.Equal(int64(0), imported)
+
+		assertions.Len(mockStorage.posts.GetValues(), 2)
+		assertions.Len(mockStorage.comments.GetValues(), 2)
+	})
+
+	t.Run("Unsuccessful Import Comments Post Not Imported", func(t *testing.T) {
+		imported, err := mockAccessor.ImportComments(ctx, "other", comments)
+		assertions.NotNil(err)
+		assertions.Equal(int64(0), imported)
+	})
+}
+
+func TestImportKeepingIDs(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	mockAccessor.KeepImportedIDs(true)
+
+	t.Run("Successful Import Keeping IDs", func(t *testing.T) {
+		imported, err := mockAccessor.ImportPosts(ctx, "snapshot", []*storage.ExportPost{
+			{Post: &model.Post{ID: 7, AuthorID: authorID, Title: "First", Text: "Text", CommentsEnabled: true, Status: model.PostStatusPublished}},
+			{Post: &model.Post{ID: 3, AuthorID: authorID, Title: "Second", Text: "Text", CommentsEnabled: true, Status: model.PostStatusPublished}},
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(2), imported)
+
+		imported, err = mockAccessor.ImportComments(ctx, "snapshot", []*storage.TreeComment{
+			{Comment: &model.Comment{ID: 12, AuthorID: authorID, PostID: 7, Text: "Root", Status: model.CommentStatusApproved}},
+			{Comment: &model.Comment{ID: 5, AuthorID: authorID, PostID: 3, Text: "Root", Status: model.CommentStatusApproved}},
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(2), imported)
+
+		importedIDs, err := mockAccessor.GetImportedIDs(ctx, "snapshot", storage.ImportKindPost, []int64{7, 3})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]int64{7: 7, 3: 3}, importedIDs)
+
+		importedIDs, err = mockAccessor.GetImportedIDs(ctx, "snapshot", storage.ImportKindComment, []int64{12, 5})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]int64{12: 12, 5: 5}, importedIDs)
+	})
+
+	t.Run("Successful Import Keeping IDs taken ID", func(t *testing.T) {
+		imported, err := mockAccessor.ImportPosts(ctx, "other", []*storage.ExportPost{
+			{Post: &model.Post{ID: 3, AuthorID: authorID, Title: "Third", Text: "Text", CommentsEnabled: true, Status: model.PostStatusPublished}},
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(1), imported)
+
+		importedIDs, err := mockAccessor.GetImportedIDs(ctx, "other", storage.ImportKindPost, []int64{3})
+		assertions.Nil(err)
+		assertions.Equal(map[int64]int64{3: 8}, importedIDs)
+
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "New", Text: "Text", CommentsEnabled: true})
+		assertions.Nil(err)
+		assertions.Equal(int64(9), post.ID)
+	})
+}
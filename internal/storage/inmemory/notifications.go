@@ -0,0 +1,205 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/google/uuid"
+)
+
+// addReplyNotification notifies the author of the parent comment, or the author of the post for root comments.
+func (inMemoryAccessor *InMemoryAccessor) addReplyNotification(comment *model.Comment) {
+	var recipientID uuid.UUID
+	if comment.ParentID != nil {
+		parent, ok := inMemoryAccessor.storage.comments.Get(*comment.ParentID)
+		if !ok {
+			return
+		}
+		recipientID = parent.AuthorID
+	} else {
+		post, ok := inMemoryAccessor.storage.posts.Get(comment.PostID)
+		if !ok {
+			return
+		}
+		recipientID = post.AuthorID
+	}
+
+	inMemoryAccessor.addNotification(helpers.NewReplyNotification(comment, recipientID))
+}
+
+// addNotification stores the notification and adds it to the list of the recipient. Nil notifications are skipped.
+func (inMemoryAccessor *InMemoryAccessor) addNotification(notification *model.Notification) {
+	if notification == nil {
+		return
+	}
+
+	inMemoryAccessor.lastNotificationID++
+	notification.ID = inMemoryAccessor.lastNotificationID
+
+	inMemoryAccessor.storage.notifications.Set(notification.ID, notification)
+
+	userNotifications, _ := inMemoryAccessor.storage.userNotifications.Get(notification.UserID)
+	inMemoryAccessor.storage.userNotifications.Set(notification.UserID, append(slices.Clone(userNotifications), notification.ID))
+}
+
+// addRevisionNotification stores the notification together with the number of the revision that caused it.
+func (inMemoryAccessor *InMemoryAccessor) addRevisionNotification(notification *model.Notification, revisionNumber int32) {
+	if notification == nil {
+		return
+	}
+
+	inMemoryAccessor.addNotification(notification)
+	inMemoryAccessor.storage.notificationRevisions.Set(notification.ID, revisionNumber)
+}
+
+// deleteNotifications removes notifications that match, together with their references in the lists of users.
+func (inMemoryAccessor *InMemoryAccessor) deleteNotifications(matches func(notification *model.Notification) bool) {
+	for _, notification := range inMemoryAccessor.storage.notifications.GetValues() {
+		if !matches(notification) {
+			continue
+		}
+
+		inMemoryAccessor.storage.notifications.Delete(notification.ID)
+		inMemoryAccessor.storage.notificationRevisions.Delete(notification.ID)
+
+		userNotifications, _ := inMemoryAccessor.storage.userNotifications.Get(notification.UserID)
+		inMemoryAccessor.storage.userNotifications.Set(notification.UserID, slices.DeleteFunc(slices.Clone(userNotifications), func(notificationID int64) bool {
+			return notificationID == notification.ID
+		}))
+	}
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetNotifications(ctx context.Context, userID uuid.UUID, unreadOnly bool, after *cursor.NotificationCursor, limit *int32) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	notificationIDs, _ := inMemoryAccessor.storage.userNotifications.Get(userID)
+
+	notifications := make([]*model.Notification, 0)
+	for _, notificationID := range slices.Backward(notificationIDs) {
+		if limit != nil && len(notifications) == int(*limit) {
+			break
+		}
+
+		if after != nil && notificationID >= after.NotificationID {
+			continue
+		}
+
+		notification, _ := inMemoryAccessor.storage.notifications.Get(notificationID)
+		if unreadOnly && notification.Read {
+			continue
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentNotifications(ctx context.Context, commentID int64) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	notifications := make([]*model.Notification, 0)
+	for _, notification := range inMemoryAccessor.storage.notifications.GetValues() {
+		if notification.CommentID != nil && *notification.CommentID == commentID {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	slices.SortFunc(notifications, func(a, b *model.Notification) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return notifications, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPostNotifications(ctx context.Context, postID int64) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	notifications := make([]*model.Notification, 0)
+	for _, notification := range inMemoryAccessor.storage.notifications.GetValues() {
+		if notification.PostID == postID && notification.CommentID == nil {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	slices.SortFunc(notifications, func(a, b *model.Notification) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return notifications, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetRevisionNotifications(ctx context.Context, postID int64, commentID *int64, revisionNumber int32) ([]*model.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	notifications := make([]*model.Notification, 0)
+	for _, notification := range inMemoryAccessor.storage.notifications.GetValues() {
+		if notification.PostID != postID || (notification.CommentID == nil) != (commentID == nil) {
+			continue
+		}
+
+		if commentID != nil && *notification.CommentID != *commentID {
+			continue
+		}
+
+		if number, ok := inMemoryAccessor.storage.notificationRevisions.Get(notification.ID); ok && number == revisionNumber {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	slices.SortFunc(notifications, func(a, b *model.Notification) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return notifications, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) MarkNotificationsRead(ctx context.Context, userID uuid.UUID, notificationIDs []int64) (int32, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	userNotificationIDs, _ := inMemoryAccessor.storage.userNotifications.Get(userID)
+
+	var marked int32
+	for _, notificationID := range userNotificationIDs {
+		if notificationIDs != nil && !slices.Contains(notificationIDs, notificationID) {
+			continue
+		}
+
+		notification, _ := inMemoryAccessor.storage.notifications.Get(notificationID)
+		if !notification.Read {
+			notification.Read = true
+			marked++
+		}
+	}
+
+	return marked, nil
+}
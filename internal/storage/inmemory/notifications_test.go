@@ -0,0 +1,143 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifications(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	postAuthorID := uuid.New()
+	commenterID := uuid.New()
+	ctx := context.Background()
+
+	_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+		AuthorID:        postAuthorID,
+		Title:           "Test Title",
+		Text:            "Test Text",
+		CommentsEnabled: true,
+	})
+	assertions.Nil(err)
+
+	t.Run("Successful Notify Post Author About Root Comment", func(t *testing.T) {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetNotifications(ctx, postAuthorID, false, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(&model.Notification{
+			ID:         0,
+			UserID:     postAuthorID,
+			Kind:       model.NotificationKindReply,
+			PostID:     0,
+			CommentID:  &comment.ID,
+			ActorID:    commenterID,
+			CreateDate: notifications[0].CreateDate,
+		}, notifications[0])
+	})
+
+	t.Run("Successful Notify Parent Comment Author About Reply", func(t *testing.T) {
+		parentID := int64(0)
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: postAuthorID,
+			PostID:   0,
+			ParentID: &parentID,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(commenterID, notifications[0].UserID)
+	})
+
+	t.Run("Successful Skip Notification For Own Comment", func(t *testing.T) {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: postAuthorID,
+			PostID:   0,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+	})
+
+	t.Run("Successful Get Notifications Page After Cursor", func(t *testing.T) {
+		_, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		limit := int32(1)
+		notifications, err := mockAccessor.GetNotifications(ctx, postAuthorID, false, nil, &limit)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(int64(2), notifications[0].ID)
+
+		notifications, err = mockAccessor.GetNotifications(ctx, postAuthorID, false, &cursor.NotificationCursor{NotificationID: 2}, &limit)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(int64(0), notifications[0].ID)
+	})
+
+	t.Run("Successful Mark Notifications Read", func(t *testing.T) {
+		marked, err := mockAccessor.MarkNotificationsRead(ctx, postAuthorID, []int64{0, 1})
+		assertions.Nil(err)
+		assertions.Equal(int32(1), marked)
+
+		notifications, err := mockAccessor.GetNotifications(ctx, postAuthorID, true, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(int64(2), notifications[0].ID)
+
+		marked, err = mockAccessor.MarkNotificationsRead(ctx, postAuthorID, nil)
+		assertions.Nil(err)
+		assertions.Equal(int32(1), marked)
+
+		notifications, err = mockAccessor.GetNotifications(ctx, postAuthorID, true, nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+	})
+
+	t.Run("Successful Notify After Approval", func(t *testing.T) {
+		_, err := mockAccessor.UpdateModerationMode(ctx, 0, postAuthorID, model.ModerationModePremoderated)
+		assertions.Nil(err)
+
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: commenterID,
+			PostID:   0,
+			Text:     "Test Text",
+		})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+
+		_, err = mockAccessor.UpdateCommentStatus(ctx, comment.ID, postAuthorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+
+		notifications, err = mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+	})
+}
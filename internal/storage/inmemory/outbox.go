@@ -0,0 +1,127 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// eventLease marks an event claimed by a dispatcher until the time.
+type eventLease struct {
+	ownerID uuid.UUID
+	until   time.Time
+}
+
+// WithTransaction runs fn with the same accessor: the in-memory storage applies every change immediately
+// and has no rollback.
+func (inMemoryAccessor *InMemoryAccessor) WithTransaction(ctx context.Context, fn func(accessor storage.Accessor) error) error {
+	return fn(inMemoryAccessor)
+}
+
+func (inMemoryAccessor *InMemoryAccessor) AddEvent(ctx context.Context, envelope *events.Envelope) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	// IDs are assigned and stored under one lock, so the dispatcher never sees a gap that is filled later.
+	defer inMemoryAccessor.eventsMutex.Unlock()
+	inMemoryAccessor.eventsMutex.Lock()
+
+	inMemoryAccessor.lastEventID++
+	envelope.ID = inMemoryAccessor.lastEventID
+	envelope.CreateDate = time.Now()
+
+	inMemoryAccessor.storage.outbox.Set(envelope.ID, envelope)
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPendingEvents(ctx context.Context, limit int32) ([]*events.Envelope, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	eventIDs := inMemoryAccessor.storage.outbox.GetKeys()
+	slices.Sort(eventIDs)
+
+	envelopes := make([]*events.Envelope, 0)
+	for _, eventID := range eventIDs {
+		if len(envelopes) == int(limit) {
+			break
+		}
+
+		if envelope, ok := inMemoryAccessor.storage.outbox.Get(eventID); ok {
+			envelopes = append(envelopes, envelope)
+		}
+	}
+
+	return envelopes, nil
+}
+
+// ClaimPendingEvents leases the first pending events to the owner until leaseUntil. While another owner holds
+// a lease, nothing is claimed, so only one dispatcher delivers at a time and events keep their order.
+func (inMemoryAccessor *InMemoryAccessor) ClaimPendingEvents(ctx context.Context, ownerID uuid.UUID, now time.Time, leaseUntil time.Time, limit int32) ([]*events.Envelope, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.eventsMutex.Unlock()
+	inMemoryAccessor.eventsMutex.Lock()
+
+	eventIDs := inMemoryAccessor.storage.outbox.GetKeys()
+	slices.Sort(eventIDs)
+
+	for _, eventID := range eventIDs {
+		lease, ok := inMemoryAccessor.storage.outboxLeases.Get(eventID)
+		if ok && lease.ownerID != ownerID && lease.until.After(now) {
+			return make([]*events.Envelope, 0), nil
+		}
+	}
+
+	envelopes := make([]*events.Envelope, 0)
+	for _, eventID := range eventIDs {
+		if len(envelopes) == int(limit) {
+			break
+		}
+
+		if envelope, ok := inMemoryAccessor.storage.outbox.Get(eventID); ok {
+			inMemoryAccessor.storage.outboxLeases.Set(eventID, eventLease{ownerID: ownerID, until: leaseUntil})
+			envelopes = append(envelopes, envelope)
+		}
+	}
+
+	return envelopes, nil
+}
+
+// MarkEventsDispatched removes events from the outbox, the in-memory storage does not keep the history.
+func (inMemoryAccessor *InMemoryAccessor) MarkEventsDispatched(ctx context.Context, eventIDs []int64) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.eventsMutex.Unlock()
+	inMemoryAccessor.eventsMutex.Lock()
+
+	for _, eventID := range eventIDs {
+		inMemoryAccessor.storage.outbox.Delete(eventID)
+		inMemoryAccessor.storage.outboxLeases.Delete(eventID)
+	}
+
+	return nil
+}
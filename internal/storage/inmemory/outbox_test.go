@@ -0,0 +1,121 @@
+package inmemory
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingSink counts deliveries of every event and may be shared by dispatchers running concurrently.
+type countingSink struct {
+	mutex    sync.Mutex
+	received map[int64]int
+	order    []int64
+}
+
+func (sink *countingSink) Deliver(ctx context.Context, envelope *events.Envelope) error {
+	defer sink.mutex.Unlock()
+	sink.mutex.Lock()
+
+	sink.received[envelope.ID]++
+	sink.order = append(sink.order, envelope.ID)
+	return nil
+}
+
+func TestOutbox(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	inMemoryStorage := NewInMemoryStorage()
+	inMemoryAccessor := NewInMemoryAccessor(inMemoryStorage)
+
+	t.Run("Successful Add Event", func(t *testing.T) {
+		for _, kind := range []events.Kind{events.KindPostCreated, events.KindCommentAdded, events.KindCommentsToggled} {
+			envelope := &events.Envelope{Kind: kind, Payload: []byte(`{}`)}
+			assertions.Nil(inMemoryAccessor.AddEvent(ctx, envelope))
+			assertions.False(envelope.CreateDate.IsZero())
+		}
+	})
+
+	t.Run("Successful Get Pending Events", func(t *testing.T) {
+		envelopes, err := inMemoryAccessor.GetPendingEvents(ctx, 2)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 2)
+		assertions.Equal(int64(0), envelopes[0].ID)
+		assertions.Equal(events.KindCommentAdded, envelopes[1].Kind)
+	})
+
+	t.Run("Successful Mark Events Dispatched", func(t *testing.T) {
+		assertions.Nil(inMemoryAccessor.MarkEventsDispatched(ctx, []int64{0, 1}))
+
+		envelopes, err := inMemoryAccessor.GetPendingEvents(ctx, 10)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 1)
+		assertions.Equal(int64(2), envelopes[0].ID)
+	})
+
+	t.Run("Successful Claim Pending Events leased by another owner", func(t *testing.T) {
+		firstOwnerID := uuid.New()
+		now := time.Now()
+
+		envelopes, err := inMemoryAccessor.ClaimPendingEvents(ctx, firstOwnerID, now, now.Add(time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 1)
+
+		envelopes, err = inMemoryAccessor.ClaimPendingEvents(ctx, uuid.New(), now, now.Add(time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Empty(envelopes)
+
+		envelopes, err = inMemoryAccessor.ClaimPendingEvents(ctx, uuid.New(), now.Add(2*time.Minute), now.Add(3*time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Len(envelopes, 1)
+	})
+}
+
+func TestConcurrentDispatchers(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	inMemoryAccessor := NewInMemoryAccessor(NewInMemoryStorage())
+
+	const eventsCount = 500
+	for idx := range eventsCount {
+		envelope := &events.Envelope{Kind: events.KindPostCreated, Payload: []byte(`{"postID":` + strconv.Itoa(idx) + `}`)}
+		assertions.Nil(inMemoryAccessor.AddEvent(ctx, envelope))
+	}
+
+	sink := &countingSink{received: make(map[int64]int)}
+	dispatchers := []*events.Dispatcher{
+		events.NewDispatcher(inMemoryAccessor, time.Second, sink),
+		events.NewDispatcher(inMemoryAccessor, time.Second, sink),
+	}
+
+	var waitGroup sync.WaitGroup
+	for _, dispatcher := range dispatchers {
+		waitGroup.Go(func() {
+			for {
+				pending, err := inMemoryAccessor.GetPendingEvents(ctx, 1)
+				assertions.Nil(err)
+				if len(pending) == 0 {
+					return
+				}
+
+				_, err = dispatcher.DispatchPending(ctx)
+				assertions.Nil(err)
+			}
+		})
+	}
+	waitGroup.Wait()
+
+	assertions.Len(sink.received, eventsCount)
+	for eventID, count := range sink.received {
+		assertions.Equal(1, count, "event %d", eventID)
+	}
+	assertions.IsIncreasing(sink.order)
+}
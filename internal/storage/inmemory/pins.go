@@ -0,0 +1,174 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// PinComment pins the root comment to the top of its post. Only the author of the post may pin comments,
+// and the post may have no more than maxPinned pinned comments.
+func (inMemoryAccessor *InMemoryAccessor) PinComment(ctx context.Context, commentID int64, authorID uuid.UUID, maxPinned int32) (*model.Comment, error) {
+	comment, err := inMemoryAccessor.getPinnableComment(commentID, authorID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.ParentID != nil {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is a reply, only root comments can be pinned.")
+	}
+
+	if comment.Status != model.CommentStatusApproved {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not approved.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.pinsMutex.Unlock()
+	inMemoryAccessor.pinsMutex.Lock()
+
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(comment.PostID)
+	if slices.Contains(pinnedIDs, commentID) {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is already pinned.")
+	}
+
+	if len(pinnedIDs) >= int(maxPinned) {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " already has " + strconv.Itoa(int(maxPinned)) + " pinned comments.")
+	}
+
+	inMemoryAccessor.addPin(comment, time.Now())
+
+	return comment, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) UnpinComment(ctx context.Context, commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	comment, err := inMemoryAccessor.getPinnableComment(commentID, authorID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.pinsMutex.Unlock()
+	inMemoryAccessor.pinsMutex.Lock()
+
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(comment.PostID)
+	if !slices.Contains(pinnedIDs, commentID) {
+		return nil, errors.New("Comment with ID " + strconv.FormatInt(commentID, 10) + " is not pinned.")
+	}
+
+	inMemoryAccessor.removePin(comment)
+
+	return comment, nil
+}
+
+// GetPinnedComments returns pinned comments of the post in the order they were pinned.
+func (inMemoryAccessor *InMemoryAccessor) GetPinnedComments(ctx context.Context, postID int64) ([]*model.Comment, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	comments := make([]*model.Comment, 0)
+	if _, ok := inMemoryAccessor.getPost(postID); !ok {
+		return comments, nil
+	}
+
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(postID)
+	for _, commentID := range pinnedIDs {
+		if comment, ok := inMemoryAccessor.getComment(commentID); ok {
+			comments = append(comments, comment)
+		}
+	}
+
+	return comments, nil
+}
+
+// GetCommentsPinned reports which of the comments are pinned. Comments which are not pinned may be missing from the map.
+func (inMemoryAccessor *InMemoryAccessor) GetCommentsPinned(ctx context.Context, commentIDs []int64) (map[int64]bool, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	pinned := make(map[int64]bool, len(commentIDs))
+	for _, commentID := range commentIDs {
+		comment, ok := inMemoryAccessor.storage.comments.Get(commentID)
+		if !ok {
+			continue
+		}
+
+		pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(comment.PostID)
+		pinned[commentID] = slices.Contains(pinnedIDs, commentID)
+	}
+
+	return pinned, nil
+}
+
+// getPinnableComment returns the comment if it exists and the user is the author of its post.
+func (inMemoryAccessor *InMemoryAccessor) getPinnableComment(commentID int64, authorID uuid.UUID) (*model.Comment, error) {
+	comment, ok := inMemoryAccessor.getComment(commentID)
+
+	if !ok {
+		return nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	post, ok := inMemoryAccessor.getPost(comment.PostID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(comment.PostID, 10) + " was not found")
+	}
+
+	if post.AuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(post.ID, 10) + ".")
+	}
+
+	return comment, nil
+}
+
+// removePin unpins the comment, like setting pinned_at to NULL does in Postgres.
+func (inMemoryAccessor *InMemoryAccessor) removePin(comment *model.Comment) {
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(comment.PostID)
+	inMemoryAccessor.storage.pinnedComments.Set(comment.PostID, slices.DeleteFunc(slices.Clone(pinnedIDs), func(commentID int64) bool {
+		return commentID == comment.ID
+	}))
+	inMemoryAccessor.storage.commentPinDates.Delete(comment.ID)
+}
+
+// addPin pins the comment at the date, keeping pinned comments of the post in the order they were pinned.
+// Comments pinned at the same date keep the order they were added in.
+func (inMemoryAccessor *InMemoryAccessor) addPin(comment *model.Comment, pinnedAt time.Time) {
+	pinnedIDs, _ := inMemoryAccessor.storage.pinnedComments.Get(comment.PostID)
+	position := len(pinnedIDs)
+	for position > 0 {
+		previousPinnedAt, _ := inMemoryAccessor.storage.commentPinDates.Get(pinnedIDs[position-1])
+		if !previousPinnedAt.After(pinnedAt) {
+			break
+		}
+		position--
+	}
+	inMemoryAccessor.storage.pinnedComments.Set(comment.PostID, slices.Insert(slices.Clone(pinnedIDs), position, comment.ID))
+	inMemoryAccessor.storage.commentPinDates.Set(comment.ID, pinnedAt)
+}
@@ -0,0 +1,114 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPins(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	rootIDs := make([]int64, 0, 3)
+	for range 3 {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: uuid.New(), PostID: post.ID, Text: "Comment"})
+		assertions.Nil(err)
+		rootIDs = append(rootIDs, comment.ID)
+	}
+
+	reply, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: uuid.New(), PostID: post.ID, ParentID: &rootIDs[0], Text: "Reply"})
+	assertions.Nil(err)
+
+	t.Run("Successful Pin Comment", func(t *testing.T) {
+		comment, err := mockAccessor.PinComment(ctx, rootIDs[1], authorID, 2)
+		assertions.Nil(err)
+		assertions.Equal(rootIDs[1], comment.ID)
+
+		pinned, err := mockAccessor.GetPinnedComments(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Len(pinned, 1)
+		assertions.Equal(rootIDs[1], pinned[0].ID)
+
+		commentsPinned, err := mockAccessor.GetCommentsPinned(ctx, []int64{rootIDs[0], rootIDs[1]})
+		assertions.Nil(err)
+		assertions.False(commentsPinned[rootIDs[0]])
+		assertions.True(commentsPinned[rootIDs[1]])
+	})
+
+	t.Run("Successful Get Comments Level Without Pinned", func(t *testing.T) {
+		comments, err := mockAccessor.GetCommentsLevel(ctx, post.ID, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(rootIDs[0], comments[0].ID)
+		assertions.Equal(rootIDs[2], comments[1].ID)
+	})
+
+	t.Run("Unsuccessful Pin Comment Not Author", func(t *testing.T) {
+		comment, err := mockAccessor.PinComment(ctx, rootIDs[0], uuid.New(), 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Unsuccessful Pin Reply", func(t *testing.T) {
+		comment, err := mockAccessor.PinComment(ctx, reply.ID, authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Unsuccessful Pin Comment Already Pinned", func(t *testing.T) {
+		comment, err := mockAccessor.PinComment(ctx, rootIDs[1], authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Unsuccessful Pin Comment Limit Reached", func(t *testing.T) {
+		_, err := mockAccessor.PinComment(ctx, rootIDs[0], authorID, 2)
+		assertions.Nil(err)
+
+		comment, err := mockAccessor.PinComment(ctx, rootIDs[2], authorID, 2)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+
+		pinned, err := mockAccessor.GetPinnedComments(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Equal([]int64{rootIDs[1], rootIDs[0]}, []int64{pinned[0].ID, pinned[1].ID})
+	})
+
+	t.Run("Successful Unpin Comment", func(t *testing.T) {
+		comment, err := mockAccessor.UnpinComment(ctx, rootIDs[1], authorID)
+		assertions.Nil(err)
+		assertions.Equal(rootIDs[1], comment.ID)
+
+		comments, err := mockAccessor.GetCommentsLevel(ctx, post.ID, "0", model.CommentsOrderOldest, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(comments, 2)
+		assertions.Equal(rootIDs[1], comments[0].ID)
+	})
+
+	t.Run("Unsuccessful Unpin Comment Not Pinned", func(t *testing.T) {
+		comment, err := mockAccessor.UnpinComment(ctx, rootIDs[1], authorID)
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Successful Delete Pinned Comment", func(t *testing.T) {
+		_, err := mockAccessor.DeleteComment(ctx, rootIDs[0], authorID, true)
+		assertions.Nil(err)
+
+		pinned, err := mockAccessor.GetPinnedComments(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Empty(pinned)
+	})
+}
@@ -0,0 +1,85 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+// PublishPost publishes the draft right away or, if publishAt is in the future, schedules its publication.
+func (inMemoryAccessor *InMemoryAccessor) PublishPost(ctx context.Context, postID int64, authorID uuid.UUID, publishAt *time.Time) (*model.Post, error) {
+	post, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if post.AuthorID != authorID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(authorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.publicationMutex.Unlock()
+	inMemoryAccessor.publicationMutex.Lock()
+
+	if post.Status == model.PostStatusPublished {
+		return nil, errors.New("Post with ID " + strconv.FormatInt(postID, 10) + " is already published.")
+	}
+
+	now := time.Now()
+	status := model.PostStatusPublished
+	post.Status, post.PublishAt = helpers.GetPostPublication(&status, publishAt, now)
+	if post.Status == model.PostStatusPublished {
+		// Posts are ordered by the create date, so a published draft gets the date of its publication.
+		post.CreateDate = now
+		inMemoryAccessor.addPostMentionNotifications(post)
+	}
+
+	return post, nil
+}
+
+// PublishDuePosts publishes up to limit drafts scheduled no later than now, the earliest first.
+func (inMemoryAccessor *InMemoryAccessor) PublishDuePosts(ctx context.Context, now time.Time, limit int32) ([]*model.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.publicationMutex.Unlock()
+	inMemoryAccessor.publicationMutex.Lock()
+
+	posts := slices.DeleteFunc(inMemoryAccessor.storage.posts.GetValues(), func(post *model.Post) bool {
+		return post.DeletedAt != nil || post.Status != model.PostStatusDraft || post.PublishAt == nil || post.PublishAt.After(now)
+	})
+	slices.SortFunc(posts, func(a, b *model.Post) int {
+		return cmp.Or(a.PublishAt.Compare(*b.PublishAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	if len(posts) > int(limit) {
+		posts = posts[:limit]
+	}
+
+	for _, post := range posts {
+		post.Status = model.PostStatusPublished
+		post.CreateDate = now
+		inMemoryAccessor.addPostMentionNotifications(post)
+	}
+
+	return posts, nil
+}
@@ -0,0 +1,119 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublication(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+	draftStatus := model.PostStatusDraft
+	publishAt := time.Now().Add(time.Hour)
+
+	draft, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Draft", Text: "Text", CommentsEnabled: true, Status: &draftStatus})
+	assertions.Nil(err)
+	scheduled, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Scheduled", Text: "Text", CommentsEnabled: true, PublishAt: &publishAt})
+	assertions.Nil(err)
+	published, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Published", Text: "Text", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	t.Run("Successful Add Post Statuses", func(t *testing.T) {
+		assertions.Equal(model.PostStatusDraft, draft.Status)
+		assertions.Nil(draft.PublishAt)
+		assertions.Equal(model.PostStatusDraft, scheduled.Status)
+		assertions.Equal(publishAt, *scheduled.PublishAt)
+		assertions.Equal(model.PostStatusPublished, published.Status)
+	})
+
+	t.Run("Successful Get Drafts As Author", func(t *testing.T) {
+		post, err := mockAccessor.GetPost(ctx, draft.ID, authorID)
+		assertions.Nil(err)
+		assertions.Equal(draft.ID, post.ID)
+
+		posts, err := mockAccessor.GetAllPosts(ctx, authorID)
+		assertions.Nil(err)
+		assertions.Len(posts, 3)
+	})
+
+	t.Run("Unsuccessful Get Drafts As Other User", func(t *testing.T) {
+		post, err := mockAccessor.GetPost(ctx, draft.ID, uuid.New())
+		assertions.NotNil(err)
+		assertions.Nil(post)
+
+		posts, err := mockAccessor.GetAllPosts(ctx, uuid.New())
+		assertions.Nil(err)
+		assertions.Len(posts, 1)
+		assertions.Equal(published.ID, posts[0].ID)
+
+		posts, err = mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(posts, 1)
+	})
+
+	t.Run("Unsuccessful Add Comment To Draft", func(t *testing.T) {
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: uuid.New(), PostID: draft.ID, Text: "Comment"})
+		assertions.NotNil(err)
+		assertions.Nil(comment)
+	})
+
+	t.Run("Unsuccessful Publish Post Not Author", func(t *testing.T) {
+		post, err := mockAccessor.PublishPost(ctx, draft.ID, uuid.New(), nil)
+		assertions.NotNil(err)
+		assertions.Nil(post)
+	})
+
+	t.Run("Successful Publish Post", func(t *testing.T) {
+		post, err := mockAccessor.PublishPost(ctx, draft.ID, authorID, nil)
+		assertions.Nil(err)
+		assertions.Equal(model.PostStatusPublished, post.Status)
+		assertions.NotNil(post.PublishAt)
+
+		post, err = mockAccessor.GetPost(ctx, draft.ID, uuid.New())
+		assertions.Nil(err)
+		assertions.Equal(draft.ID, post.ID)
+
+		posts, err := mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(posts, 2)
+		assertions.Equal(draft.ID, posts[0].ID)
+		assertions.True(posts[0].CreateDate.After(published.CreateDate))
+	})
+
+	t.Run("Unsuccessful Publish Post Already Published", func(t *testing.T) {
+		post, err := mockAccessor.PublishPost(ctx, published.ID, authorID, nil)
+		assertions.NotNil(err)
+		assertions.Nil(post)
+	})
+
+	t.Run("Successful Publish Due Posts", func(t *testing.T) {
+		posts, err := mockAccessor.PublishDuePosts(ctx, time.Now(), 10)
+		assertions.Nil(err)
+		assertions.Len(posts, 0)
+
+		posts, err = mockAccessor.PublishDuePosts(ctx, publishAt, 10)
+		assertions.Nil(err)
+		assertions.Len(posts, 1)
+		assertions.Equal(scheduled.ID, posts[0].ID)
+		assertions.Equal(model.PostStatusPublished, posts[0].Status)
+		assertions.Equal(publishAt, posts[0].CreateDate)
+
+		posts, err = mockAccessor.GetPosts(ctx, nil, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Equal(scheduled.ID, posts[0].ID)
+
+		posts, err = mockAccessor.PublishDuePosts(ctx, publishAt, 10)
+		assertions.Nil(err)
+		assertions.Len(posts, 0)
+	})
+}
@@ -0,0 +1,134 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+func (inMemoryAccessor *InMemoryAccessor) AddReaction(ctx context.Context, reaction *model.ReactionInput) error {
+	err := inMemoryAccessor.checkReactionTarget(reaction.TargetType, reaction.TargetID, reaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	target := reactionTarget{targetType: reaction.TargetType, targetID: reaction.TargetID}
+	newReaction := userReaction{userID: reaction.UserID, kind: reaction.Kind}
+
+	reactions, _ := inMemoryAccessor.storage.reactions.Get(target)
+	if slices.Contains(reactions, newReaction) {
+		return nil
+	}
+
+	inMemoryAccessor.storage.reactions.Set(target, append(slices.Clone(reactions), newReaction))
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) DeleteReaction(ctx context.Context, reaction *model.ReactionInput) error {
+	err := inMemoryAccessor.checkReactionTarget(reaction.TargetType, reaction.TargetID, reaction.UserID)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	target := reactionTarget{targetType: reaction.TargetType, targetID: reaction.TargetID}
+	oldReaction := userReaction{userID: reaction.UserID, kind: reaction.Kind}
+
+	reactions, ok := inMemoryAccessor.storage.reactions.Get(target)
+	if !ok || !slices.Contains(reactions, oldReaction) {
+		return nil
+	}
+
+	inMemoryAccessor.storage.reactions.Set(target, slices.DeleteFunc(slices.Clone(reactions), func(existing userReaction) bool {
+		return existing == oldReaction
+	}))
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64) (map[int64][]*model.ReactionCount, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	reactionCounts := make(map[int64][]*model.ReactionCount, len(targetIDs))
+	for _, targetID := range targetIDs {
+		reactions, _ := inMemoryAccessor.storage.reactions.Get(reactionTarget{targetType: targetType, targetID: targetID})
+
+		counts := make(map[model.ReactionKind]int32)
+		for _, reaction := range reactions {
+			counts[reaction.kind]++
+		}
+
+		reactionCounts[targetID] = helpers.GetReactionCounts(counts)
+	}
+
+	return reactionCounts, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetViewerReactions(ctx context.Context, targetType model.ReactionTarget, targetIDs []int64, viewerID uuid.UUID) (map[int64][]model.ReactionKind, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	viewerReactions := make(map[int64][]model.ReactionKind, len(targetIDs))
+	for _, targetID := range targetIDs {
+		reactions, _ := inMemoryAccessor.storage.reactions.Get(reactionTarget{targetType: targetType, targetID: targetID})
+
+		kinds := make([]model.ReactionKind, 0)
+		for _, kind := range model.AllReactionKind {
+			if slices.Contains(reactions, userReaction{userID: viewerID, kind: kind}) {
+				kinds = append(kinds, kind)
+			}
+		}
+
+		viewerReactions[targetID] = kinds
+	}
+
+	return viewerReactions, nil
+}
+
+// checkReactionTarget reports whether the user may see the target: drafts are visible only to their authors
+// and comments only when they are approved.
+func (inMemoryAccessor *InMemoryAccessor) checkReactionTarget(targetType model.ReactionTarget, targetID int64, userID uuid.UUID) error {
+	switch targetType {
+	case model.ReactionTargetPost:
+		if post, ok := inMemoryAccessor.getPost(targetID); !ok || !helpers.IsPostVisible(post, userID) {
+			return storage.NotFound("Post with ID " + strconv.FormatInt(targetID, 10) + " was not found")
+		}
+	case model.ReactionTargetComment:
+		if comment, ok := inMemoryAccessor.getComment(targetID); !ok || comment.Status != model.CommentStatusApproved {
+			return storage.NotFound("Comment with ID " + strconv.FormatInt(targetID, 10) + " was not found")
+		}
+	default:
+		return errors.New("Unsupported reaction target: " + targetType.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReactions(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	viewerID := uuid.New()
+	ctx := context.Background()
+
+	_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+		AuthorID:        authorID,
+		Title:           "Test Title",
+		Text:            "Test Text",
+		CommentsEnabled: true,
+	})
+	assertions.Nil(err)
+
+	_, err = mockAccessor.AddComment(ctx, &model.CommentInput{
+		AuthorID: authorID,
+		PostID:   0,
+		Text:     "Test Text",
+	})
+	assertions.Nil(err)
+
+	t.Run("Successful Add Reaction", func(t *testing.T) {
+		reaction := &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   0,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		}
+
+		assertions.Nil(mockAccessor.AddReaction(ctx, reaction))
+		assertions.Nil(mockAccessor.AddReaction(ctx, reaction))
+		assertions.Nil(mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   0,
+			UserID:     authorID,
+			Kind:       model.ReactionKindHeart,
+		}))
+		assertions.Nil(mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   0,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindThumbsUp,
+		}))
+	})
+
+	t.Run("Unsuccessful Add Reaction Target Does Not Exist", func(t *testing.T) {
+		err := mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetComment,
+			TargetID:   123,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.NotNil(err)
+	})
+
+	t.Run("Successful Get Reaction Counts", func(t *testing.T) {
+		reactionCounts, err := mockAccessor.GetReactionCounts(ctx, model.ReactionTargetPost, []int64{0, 1})
+		assertions.Nil(err)
+		assertions.Equal(map[int64][]*model.ReactionCount{
+			0: {
+				{Kind: model.ReactionKindThumbsUp, Count: 1},
+				{Kind: model.ReactionKindHeart, Count: 2},
+			},
+			1: {},
+		}, reactionCounts)
+	})
+
+	t.Run("Successful Get Viewer Reactions", func(t *testing.T) {
+		viewerReactions, err := mockAccessor.GetViewerReactions(ctx, model.ReactionTargetPost, []int64{0}, viewerID)
+		assertions.Nil(err)
+		assertions.Equal([]model.ReactionKind{model.ReactionKindThumbsUp, model.ReactionKindHeart}, viewerReactions[0])
+	})
+
+	t.Run("Successful Delete Reaction", func(t *testing.T) {
+		reaction := &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   0,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		}
+
+		assertions.Nil(mockAccessor.DeleteReaction(ctx, reaction))
+		assertions.Nil(mockAccessor.DeleteReaction(ctx, reaction))
+
+		reactionCounts, err := mockAccessor.GetReactionCounts(ctx, model.ReactionTargetPost, []int64{0})
+		assertions.Nil(err)
+		assertions.Equal([]*model.ReactionCount{
+			{Kind: model.ReactionKindThumbsUp, Count: 1},
+			{Kind: model.ReactionKindHeart, Count: 1},
+		}, reactionCounts[0])
+	})
+
+	t.Run("Unsuccessful Add Reaction Target Not Visible", func(t *testing.T) {
+		draft, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Draft",
+			Text:            "Text",
+			CommentsEnabled: true,
+			Status:          &[]model.PostStatus{model.PostStatusDraft}[0],
+		})
+		assertions.Nil(err)
+
+		err = mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   draft.ID,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.NotNil(err)
+
+		err = mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetPost,
+			TargetID:   draft.ID,
+			UserID:     authorID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.Nil(err)
+
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Premoderated",
+			Text:            "Text",
+			CommentsEnabled: true,
+			ModerationMode:  &[]model.ModerationMode{model.ModerationModePremoderated}[0],
+		})
+		assertions.Nil(err)
+
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: viewerID, PostID: post.ID, Text: "Pending"})
+		assertions.Nil(err)
+
+		err = mockAccessor.AddReaction(ctx, &model.ReactionInput{
+			TargetType: model.ReactionTargetComment,
+			TargetID:   comment.ID,
+			UserID:     viewerID,
+			Kind:       model.ReactionKindHeart,
+		})
+		assertions.NotNil(err)
+	})
+}
@@ -0,0 +1,66 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/google/uuid"
+)
+
+// getMentionedUsers resolves mentioned usernames to user IDs. Mentions of unknown usernames are ignored.
+func (inMemoryAccessor *InMemoryAccessor) getMentionedUsers(mentions []string) []uuid.UUID {
+	userIDs := make([]uuid.UUID, 0, len(mentions))
+	for _, mention := range mentions {
+		if userID, ok := inMemoryAccessor.storage.usernames.Get(mention); ok && !slices.Contains(userIDs, userID) {
+			userIDs = append(userIDs, userID)
+		}
+	}
+
+	return userIDs
+}
+
+// replaceMentions stores the users mentioned in the new text of the post or the comment with the ID
+// and returns the users who were not mentioned in it before.
+func (inMemoryAccessor *InMemoryAccessor) replaceMentions(mentions *helpers.SafeMap[int64, []uuid.UUID], id int64, userIDs []uuid.UUID) []uuid.UUID {
+	previousUserIDs, _ := mentions.Get(id)
+	mentions.Set(id, userIDs)
+
+	return slices.DeleteFunc(slices.Clone(userIDs), func(userID uuid.UUID) bool {
+		return slices.Contains(previousUserIDs, userID)
+	})
+}
+
+// addPostMentionNotifications notifies users mentioned in the post. Posts are visible right after they are added.
+func (inMemoryAccessor *InMemoryAccessor) addPostMentionNotifications(post *model.Post) {
+	userIDs, _ := inMemoryAccessor.storage.postMentions.Get(post.ID)
+	for _, userID := range userIDs {
+		inMemoryAccessor.addNotification(helpers.NewMentionNotification(post.ID, nil, post.AuthorID, userID))
+	}
+}
+
+// addCommentMentionNotifications notifies users mentioned in the comment once it becomes visible.
+func (inMemoryAccessor *InMemoryAccessor) addCommentMentionNotifications(comment *model.Comment) {
+	userIDs, _ := inMemoryAccessor.storage.commentMentions.Get(comment.ID)
+	for _, userID := range userIDs {
+		inMemoryAccessor.addNotification(helpers.NewMentionNotification(comment.PostID, &comment.ID, comment.AuthorID, userID))
+	}
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPostsTags(ctx context.Context, postIDs []int64) (map[int64][]string, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	tags := make(map[int64][]string, len(postIDs))
+	for _, postID := range postIDs {
+		postTags, _ := inMemoryAccessor.storage.postTags.Get(postID)
+		tags[postID] = append(make([]string, 0, len(postTags)), postTags...)
+	}
+
+	return tags, nil
+}
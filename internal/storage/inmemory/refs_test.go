@@ -0,0 +1,147 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefs(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	mentionedID := uuid.New()
+	ctx := context.Background()
+
+	username := "Alice"
+	_, err := mockAccessor.AddUser(ctx, &model.UserInput{ID: &mentionedID, Username: &username, DisplayName: "Alice"})
+	assertions.Nil(err)
+
+	t.Run("Unsuccessful Add User Username Taken", func(t *testing.T) {
+		takenUsername := "alice"
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{Username: &takenUsername, DisplayName: "Other Alice"})
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Successful Add Post With Tags And Mentions", func(t *testing.T) {
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Release #Go",
+			Text:            "Thanks @alice and @unknown, see #release and `#code`",
+			CommentsEnabled: true,
+		})
+		assertions.Nil(err)
+
+		tags, err := mockAccessor.GetPostsTags(ctx, []int64{post.ID, 100})
+		assertions.Nil(err)
+		assertions.Equal(map[int64][]string{post.ID: {"go", "release"}, 100: {}}, tags)
+
+		notifications, err := mockAccessor.GetPostNotifications(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(mentionedID, notifications[0].UserID)
+		assertions.Equal(model.NotificationKindMention, notifications[0].Kind)
+		assertions.Nil(notifications[0].CommentID)
+	})
+
+	t.Run("Successful Get Posts By Tag", func(t *testing.T) {
+		_, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Other", Text: "#rust", CommentsEnabled: true})
+		assertions.Nil(err)
+
+		tag := "#GO"
+		posts, err := mockAccessor.GetPosts(ctx, &model.PostsFilter{Tag: &tag}, uuid.Nil, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(posts, 1)
+		assertions.Equal(int64(0), posts[0].ID)
+	})
+
+	t.Run("Successful Notify Mentioned User After Approval", func(t *testing.T) {
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Premoderated",
+			Text:            "Text",
+			CommentsEnabled: true,
+			ModerationMode:  &[]model.ModerationMode{model.ModerationModePremoderated}[0],
+		})
+		assertions.Nil(err)
+
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Hi @ALICE"})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+
+		_, err = mockAccessor.UpdateCommentStatus(ctx, comment.ID, authorID, model.CommentStatusApproved)
+		assertions.Nil(err)
+
+		notifications, err = mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(mentionedID, notifications[0].UserID)
+		assertions.Equal(&comment.ID, notifications[0].CommentID)
+	})
+
+	t.Run("Successful Skip Self Mention", func(t *testing.T) {
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: mentionedID, Title: "Me", Text: "I am @alice", CommentsEnabled: true})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetPostNotifications(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+	})
+
+	t.Run("Successful Notify Users Mentioned By Edit", func(t *testing.T) {
+		post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Edited", Text: "Text", CommentsEnabled: true})
+		assertions.Nil(err)
+
+		text := "Thanks @alice"
+		_, _, err = mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+
+		text = "Thanks again @alice"
+		_, _, err = mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+
+		notifications, err := mockAccessor.GetPostNotifications(ctx, post.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(mentionedID, notifications[0].UserID)
+
+		notifications, err = mockAccessor.GetRevisionNotifications(ctx, post.ID, nil, 2)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(mentionedID, notifications[0].UserID)
+
+		notifications, err = mockAccessor.GetRevisionNotifications(ctx, post.ID, nil, 3)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+
+		comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Hi"})
+		assertions.Nil(err)
+
+		_, revision, err := mockAccessor.EditComment(ctx, comment.ID, authorID, "Hi @alice")
+		assertions.Nil(err)
+
+		notifications, err = mockAccessor.GetCommentNotifications(ctx, comment.ID)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(mentionedID, notifications[0].UserID)
+
+		notifications, err = mockAccessor.GetRevisionNotifications(ctx, post.ID, &comment.ID, revision.Number)
+		assertions.Nil(err)
+		assertions.Len(notifications, 1)
+		assertions.Equal(&comment.ID, notifications[0].CommentID)
+
+		notifications, err = mockAccessor.GetRevisionNotifications(ctx, post.ID, &comment.ID, 1)
+		assertions.Nil(err)
+		assertions.Empty(notifications)
+	})
+}
@@ -0,0 +1,214 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/textrefs"
+	"github.com/google/uuid"
+)
+
+// addPostRevision appends the current title and text of the post to its history.
+// The first revision is the post as it was created.
+func (inMemoryAccessor *InMemoryAccessor) addPostRevision(post *model.Post, editorID uuid.UUID, editDate time.Time) *model.Revision {
+	defer inMemoryAccessor.revisionsMutex.Unlock()
+	inMemoryAccessor.revisionsMutex.Lock()
+
+	title := post.Title
+	revisions, _ := inMemoryAccessor.storage.postRevisions.Get(post.ID)
+	revision := inMemoryAccessor.newRevision(int32(len(revisions)+1), editorID, &title, post.Text, editDate)
+	inMemoryAccessor.storage.postRevisions.Set(post.ID, append(slices.Clone(revisions), revision))
+
+	return revision
+}
+
+// addCommentRevision appends the current text of the comment to its history.
+// The first revision is the comment as it was added.
+func (inMemoryAccessor *InMemoryAccessor) addCommentRevision(comment *model.Comment, editorID uuid.UUID, editDate time.Time) *model.Revision {
+	defer inMemoryAccessor.revisionsMutex.Unlock()
+	inMemoryAccessor.revisionsMutex.Lock()
+
+	revisions, _ := inMemoryAccessor.storage.commentRevisions.Get(comment.ID)
+	revision := inMemoryAccessor.newRevision(int32(len(revisions)+1), editorID, nil, comment.Text, editDate)
+	inMemoryAccessor.storage.commentRevisions.Set(comment.ID, append(slices.Clone(revisions), revision))
+
+	return revision
+}
+
+func (inMemoryAccessor *InMemoryAccessor) newRevision(number int32, editorID uuid.UUID, title *string, text string, editDate time.Time) *model.Revision {
+	inMemoryAccessor.lastRevisionID++
+
+	return &model.Revision{
+		ID:         inMemoryAccessor.lastRevisionID,
+		Number:     number,
+		EditorID:   editorID,
+		Title:      title,
+		Text:       text,
+		CreateDate: editDate,
+	}
+}
+
+func (inMemoryAccessor *InMemoryAccessor) EditPost(ctx context.Context, postID int64, editorID uuid.UUID, changes *model.PostEditInput) (*model.Post, *model.Revision, error) {
+	if err := helpers.CheckPostChanges(changes); err != nil {
+		return nil, nil, err
+	}
+
+	post, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return nil, nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	if post.AuthorID != editorID {
+		return nil, nil, errors.New("User with ID " + strconv.FormatUint(uint64(editorID.ID()), 10) + " is not the author of the post with ID " + strconv.FormatInt(postID, 10) + ".")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+
+	default:
+	}
+
+	if changes.Title != nil {
+		post.Title = *changes.Title
+	}
+
+	if changes.Text != nil {
+		post.Text = *changes.Text
+	}
+
+	refs := textrefs.Extract(post.Title, post.Text)
+	inMemoryAccessor.storage.postsIndex.Add(post.ID, post.Title+" "+post.Text)
+	inMemoryAccessor.storage.postTags.Set(post.ID, slices.Sorted(slices.Values(refs.Tags)))
+
+	// Only users mentioned by the edit are notified, and only if the post is visible. Drafts notify on publication.
+	revision := inMemoryAccessor.addPostRevision(post, editorID, time.Now())
+	newUserIDs := inMemoryAccessor.replaceMentions(inMemoryAccessor.storage.postMentions, post.ID, inMemoryAccessor.getMentionedUsers(refs.Mentions))
+	if post.Status == model.PostStatusPublished {
+		for _, userID := range newUserIDs {
+			inMemoryAccessor.addRevisionNotification(helpers.NewMentionNotification(post.ID, nil, post.AuthorID, userID), revision.Number)
+		}
+	}
+
+	return post, revision, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) EditComment(ctx context.Context, commentID int64, editorID uuid.UUID, text string) (*model.Comment, *model.Revision, error) {
+	comment, ok := inMemoryAccessor.getComment(commentID)
+
+	if !ok {
+		return nil, nil, storage.NotFound("Comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	if comment.AuthorID != editorID {
+		return nil, nil, errors.New("User with ID " + strconv.FormatUint(uint64(editorID.ID()), 10) + " is not the author of the comment with ID " + strconv.FormatInt(commentID, 10) + ".")
+	}
+
+	if err := helpers.CheckCommentText(text); err != nil {
+		return nil, nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+
+	default:
+	}
+
+	comment.Text = text
+	inMemoryAccessor.storage.commentsIndex.Add(comment.ID, comment.Text)
+
+	// Comments on moderation notify mentioned users when they are approved.
+	revision := inMemoryAccessor.addCommentRevision(comment, editorID, time.Now())
+	newUserIDs := inMemoryAccessor.replaceMentions(inMemoryAccessor.storage.commentMentions, comment.ID, inMemoryAccessor.getMentionedUsers(textrefs.Extract(text).Mentions))
+	if comment.Status == model.CommentStatusApproved {
+		for _, userID := range newUserIDs {
+			inMemoryAccessor.addRevisionNotification(helpers.NewMentionNotification(comment.PostID, &comment.ID, comment.AuthorID, userID), revision.Number)
+		}
+	}
+
+	return comment, revision, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPostRevisions(ctx context.Context, postID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	revisions, _ := inMemoryAccessor.storage.postRevisions.Get(postID)
+	return getRevisionsPage(revisions, after, limit), nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentRevisions(ctx context.Context, commentID int64, after *cursor.RevisionCursor, limit *int32) ([]*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	revisions, _ := inMemoryAccessor.storage.commentRevisions.Get(commentID)
+	return getRevisionsPage(revisions, after, limit), nil
+}
+
+// getRevisionsPage returns revisions from the newest to the oldest. Revision numbers start with 1
+// and follow the order of the list.
+func getRevisionsPage(revisions []*model.Revision, after *cursor.RevisionCursor, limit *int32) []*model.Revision {
+	page := make([]*model.Revision, 0)
+	for _, revision := range slices.Backward(revisions) {
+		if limit != nil && len(page) == int(*limit) {
+			break
+		}
+
+		if after != nil && revision.Number >= after.Number {
+			continue
+		}
+
+		page = append(page, revision)
+	}
+
+	return page
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetPostRevision(ctx context.Context, postID int64, number int32) (*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	revisions, _ := inMemoryAccessor.storage.postRevisions.Get(postID)
+	if number < 1 || int(number) > len(revisions) {
+		return nil, storage.NotFound("Revision " + strconv.FormatInt(int64(number), 10) + " of the post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	return revisions[number-1], nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetCommentRevision(ctx context.Context, commentID int64, number int32) (*model.Revision, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	revisions, _ := inMemoryAccessor.storage.commentRevisions.Get(commentID)
+	if number < 1 || int(number) > len(revisions) {
+		return nil, storage.NotFound("Revision " + strconv.FormatInt(int64(number), 10) + " of the comment with ID " + strconv.FormatInt(commentID, 10) + " was not found")
+	}
+
+	return revisions[number-1], nil
+}
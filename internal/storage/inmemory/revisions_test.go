@@ -0,0 +1,114 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevisions(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	post, err := mockAccessor.AddPost(ctx, &model.PostInput{AuthorID: authorID, Title: "Title", Text: "Text #go", CommentsEnabled: true})
+	assertions.Nil(err)
+
+	comment, err := mockAccessor.AddComment(ctx, &model.CommentInput{AuthorID: authorID, PostID: post.ID, Text: "Comment"})
+	assertions.Nil(err)
+
+	t.Run("Successful Edit Post", func(t *testing.T) {
+		text := "New text #rust"
+		editedPost, revision, err := mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Text: &text})
+		assertions.Nil(err)
+		assertions.Equal("Title", editedPost.Title)
+		assertions.Equal(text, editedPost.Text)
+		assertions.Equal(int32(2), revision.Number)
+		assertions.Equal(authorID, revision.EditorID)
+
+		tags, err := mockAccessor.GetPostsTags(ctx, []int64{post.ID})
+		assertions.Nil(err)
+		assertions.Equal([]string{"rust"}, tags[post.ID])
+	})
+
+	t.Run("Unsuccessful Edit Post Not Author", func(t *testing.T) {
+		title := "Other title"
+		editedPost, revision, err := mockAccessor.EditPost(ctx, post.ID, uuid.New(), &model.PostEditInput{Title: &title})
+		assertions.NotNil(err)
+		assertions.Nil(editedPost)
+		assertions.Nil(revision)
+	})
+
+	t.Run("Unsuccessful Edit Post No Changes", func(t *testing.T) {
+		editedPost, revision, err := mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{})
+		assertions.NotNil(err)
+		assertions.Nil(editedPost)
+		assertions.Nil(revision)
+	})
+
+	t.Run("Successful Get Post Revisions", func(t *testing.T) {
+		title := "Third title"
+		_, _, err := mockAccessor.EditPost(ctx, post.ID, authorID, &model.PostEditInput{Title: &title})
+		assertions.Nil(err)
+
+		limit := int32(2)
+		revisions, err := mockAccessor.GetPostRevisions(ctx, post.ID, nil, &limit)
+		assertions.Nil(err)
+		assertions.Len(revisions, 2)
+		assertions.Equal(int32(3), revisions[0].Number)
+		assertions.Equal(int32(2), revisions[1].Number)
+
+		revisions, err = mockAccessor.GetPostRevisions(ctx, post.ID, &cursor.RevisionCursor{Number: 2}, &limit)
+		assertions.Nil(err)
+		assertions.Len(revisions, 1)
+		assertions.Equal("Title", *revisions[0].Title)
+		assertions.Equal("Text #go", revisions[0].Text)
+	})
+
+	t.Run("Successful Get Post Revision", func(t *testing.T) {
+		revision, err := mockAccessor.GetPostRevision(ctx, post.ID, 3)
+		assertions.Nil(err)
+		assertions.Equal("Third title", *revision.Title)
+	})
+
+	t.Run("Unsuccessful Get Post Revision Does Not Exist", func(t *testing.T) {
+		revision, err := mockAccessor.GetPostRevision(ctx, post.ID, 4)
+		assertions.NotNil(err)
+		assertions.Nil(revision)
+	})
+
+	t.Run("Successful Edit Comment", func(t *testing.T) {
+		editedComment, revision, err := mockAccessor.EditComment(ctx, comment.ID, authorID, "Edited comment")
+		assertions.Nil(err)
+		assertions.Equal("Edited comment", editedComment.Text)
+		assertions.Equal(int32(2), revision.Number)
+		assertions.Nil(revision.Title)
+
+		revisions, err := mockAccessor.GetCommentRevisions(ctx, comment.ID, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(revisions, 2)
+		assertions.Equal("Comment", revisions[1].Text)
+	})
+
+	t.Run("Unsuccessful Edit Comment Not Author", func(t *testing.T) {
+		editedComment, revision, err := mockAccessor.EditComment(ctx, comment.ID, uuid.New(), "Other text")
+		assertions.NotNil(err)
+		assertions.Nil(editedComment)
+		assertions.Nil(revision)
+	})
+
+	t.Run("Unsuccessful Edit Comment Does Not Exist", func(t *testing.T) {
+		editedComment, revision, err := mockAccessor.EditComment(ctx, 100, authorID, "Other text")
+		assertions.NotNil(err)
+		assertions.Nil(editedComment)
+		assertions.Nil(revision)
+	})
+}
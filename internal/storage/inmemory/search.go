@@ -0,0 +1,88 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"strconv"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/search"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+)
+
+func (inMemoryAccessor *InMemoryAccessor) SearchPosts(ctx context.Context, query string, after *cursor.SearchCursor, limit *int32) ([]*model.PostSearchEdge, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	edges := make([]*model.PostSearchEdge, 0)
+	for _, match := range inMemoryAccessor.storage.postsIndex.Search(query) {
+		if limit != nil && len(edges) == int(*limit) {
+			break
+		}
+
+		if after != nil && compareSearchPositions(match.Rank, match.ID, after.Rank, after.ID) <= 0 {
+			continue
+		}
+
+		post, ok := inMemoryAccessor.getPost(match.ID)
+		if !ok || post.Status != model.PostStatusPublished {
+			continue
+		}
+
+		edges = append(edges, &model.PostSearchEdge{
+			Node:    post,
+			Rank:    match.Rank,
+			Snippet: search.Snippet(post.Text, query),
+		})
+	}
+
+	return edges, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) SearchComments(ctx context.Context, postID int64, query string, after *cursor.SearchCursor, limit *int32) ([]*model.CommentSearchEdge, error) {
+	_, ok := inMemoryAccessor.getPost(postID)
+
+	if !ok {
+		return nil, storage.NotFound("Post with ID " + strconv.FormatInt(postID, 10) + " was not found")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	edges := make([]*model.CommentSearchEdge, 0)
+	for _, match := range inMemoryAccessor.storage.commentsIndex.Search(query) {
+		if limit != nil && len(edges) == int(*limit) {
+			break
+		}
+
+		if after != nil && compareSearchPositions(match.Rank, match.ID, after.Rank, after.ID) <= 0 {
+			continue
+		}
+
+		comment, ok := inMemoryAccessor.getComment(match.ID)
+		if !ok || comment.PostID != postID || comment.Status != model.CommentStatusApproved {
+			continue
+		}
+
+		edges = append(edges, &model.CommentSearchEdge{
+			Node:    comment,
+			Rank:    match.Rank,
+			Snippet: search.Snippet(comment.Text, query),
+		})
+	}
+
+	return edges, nil
+}
+
+func compareSearchPositions(rankA float64, idA int64, rankB float64, idB int64) int {
+	return cmp.Or(cmp.Compare(rankB, rankA), cmp.Compare(idA, idB))
+}
@@ -0,0 +1,78 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	authorID := uuid.New()
+	ctx := context.Background()
+
+	for _, text := range []string{"Go channels explained", "Channels, channels and more channels", "Nothing related"} {
+		_, err := mockAccessor.AddPost(ctx, &model.PostInput{
+			AuthorID:        authorID,
+			Title:           "Test Title",
+			Text:            text,
+			CommentsEnabled: true,
+		})
+		assertions.Nil(err)
+	}
+
+	for _, text := range []string{"I like channels", "Mutexes are better"} {
+		_, err := mockAccessor.AddComment(ctx, &model.CommentInput{
+			AuthorID: authorID,
+			PostID:   0,
+			Text:     text,
+		})
+		assertions.Nil(err)
+	}
+
+	t.Run("Successful Search Posts", func(t *testing.T) {
+		edges, err := mockAccessor.SearchPosts(ctx, "channels", nil, nil)
+		assertions.Nil(err)
+		assertions.Len(edges, 2)
+		assertions.Equal(int64(1), edges[0].Node.ID)
+		assertions.Equal(int64(0), edges[1].Node.ID)
+		assertions.Equal("Go <b>channels</b> explained", edges[1].Snippet)
+	})
+
+	t.Run("Successful Search Posts Page After Cursor", func(t *testing.T) {
+		edges, err := mockAccessor.SearchPosts(ctx, "channels", nil, nil)
+		assertions.Nil(err)
+
+		limit := int32(1)
+		after := &cursor.SearchCursor{Rank: edges[0].Rank, ID: edges[0].Node.ID}
+		page, err := mockAccessor.SearchPosts(ctx, "channels", after, &limit)
+		assertions.Nil(err)
+		assertions.Len(page, 1)
+		assertions.Equal(int64(0), page[0].Node.ID)
+	})
+
+	t.Run("Successful Search Comments", func(t *testing.T) {
+		edges, err := mockAccessor.SearchComments(ctx, 0, "channels", nil, nil)
+		assertions.Nil(err)
+		assertions.Len(edges, 1)
+		assertions.Equal(int64(0), edges[0].Node.ID)
+
+		edges, err = mockAccessor.SearchComments(ctx, 1, "channels", nil, nil)
+		assertions.Nil(err)
+		assertions.Empty(edges)
+	})
+
+	t.Run("Unsuccessful Search Comments Post Does Not Exist", func(t *testing.T) {
+		edges, err := mockAccessor.SearchComments(ctx, -1, "channels", nil, nil)
+		assertions.NotNil(err)
+		assertions.Nil(edges)
+	})
+}
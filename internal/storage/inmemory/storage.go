@@ -1,22 +1,118 @@
 package inmemory
 
 import (
+	"time"
+
 	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
 	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/search"
+	"github.com/google/uuid"
 )
 
+type reactionTarget struct {
+	targetType model.ReactionTarget
+	targetID   int64
+}
+
+type userReaction struct {
+	userID uuid.UUID
+	kind   model.ReactionKind
+}
+
+type webhookSubscription struct {
+	subscription *model.WebhookSubscription
+	secret       string
+}
+
+type webhookDelivery struct {
+	delivery *model.WebhookDelivery
+	envelope *events.Envelope
+}
+
+// banKey identifies the scope of a ban: the post ID or globalBanPostID for global bans.
+type banKey struct {
+	userID uuid.UUID
+	postID int64
+}
+
+type webhookEvent struct {
+	subscriptionID int64
+	eventID        int64
+}
+
+// importKey identifies an imported record by its source and its ID in the source.
+type importKey struct {
+	source   string
+	sourceID int64
+}
+
 type InMemoryStorage struct {
-	posts          *helpers.SafeMap[int64, *model.Post]
-	comments       *helpers.SafeMap[int64, *model.Comment]
-	commentsByPath *helpers.SafeMap[string, []int64]
-	commentPaths   *helpers.SafeMap[int64, string]
+	posts                 *helpers.SafeMap[int64, *model.Post]
+	comments              *helpers.SafeMap[int64, *model.Comment]
+	commentsByPath        *helpers.SafeMap[string, []int64]
+	commentPaths          *helpers.SafeMap[int64, string]
+	commentsByAuthor      *helpers.SafeMap[uuid.UUID, []int64]
+	levelCounts           *helpers.SafeMap[string, int32]
+	reactions             *helpers.SafeMap[reactionTarget, []userReaction]
+	users                 *helpers.SafeMap[uuid.UUID, *model.User]
+	usernames             *helpers.SafeMap[string, uuid.UUID]
+	postTags              *helpers.SafeMap[int64, []string]
+	postReplyDepths       *helpers.SafeMap[int64, int32]
+	postMentions          *helpers.SafeMap[int64, []uuid.UUID]
+	commentMentions       *helpers.SafeMap[int64, []uuid.UUID]
+	postRevisions         *helpers.SafeMap[int64, []*model.Revision]
+	commentRevisions      *helpers.SafeMap[int64, []*model.Revision]
+	notifications         *helpers.SafeMap[int64, *model.Notification]
+	userNotifications     *helpers.SafeMap[uuid.UUID, []int64]
+	notificationRevisions *helpers.SafeMap[int64, int32]
+	outbox                *helpers.SafeMap[int64, *events.Envelope]
+	outboxLeases          *helpers.SafeMap[int64, eventLease]
+	webhooks              *helpers.SafeMap[int64, *webhookSubscription]
+	webhookDeliveries     *helpers.SafeMap[int64, *webhookDelivery]
+	webhookEvents         *helpers.SafeMap[webhookEvent, int64]
+	webhookHistory        *helpers.SafeMap[int64, []int64]
+	bans                  *helpers.SafeMap[banKey, *model.Ban]
+	pinnedComments        *helpers.SafeMap[int64, []int64]
+	commentPinDates       *helpers.SafeMap[int64, time.Time]
+	importedPosts         *helpers.SafeMap[importKey, int64]
+	importedComments      *helpers.SafeMap[importKey, int64]
+	postsIndex            *search.Index
+	commentsIndex         *search.Index
 }
 
 func NewInMemoryStorage() *InMemoryStorage {
 	return &InMemoryStorage{
-		posts:          helpers.NewSafeMap(make(map[int64]*model.Post)),
-		comments:       helpers.NewSafeMap(make(map[int64]*model.Comment)),
-		commentsByPath: helpers.NewSafeMap(make(map[string][]int64)),
-		commentPaths:   helpers.NewSafeMap(make(map[int64]string)),
+		posts:                 helpers.NewSafeMap(make(map[int64]*model.Post)),
+		comments:              helpers.NewSafeMap(make(map[int64]*model.Comment)),
+		commentsByPath:        helpers.NewSafeMap(make(map[string][]int64)),
+		commentPaths:          helpers.NewSafeMap(make(map[int64]string)),
+		commentsByAuthor:      helpers.NewSafeMap(make(map[uuid.UUID][]int64)),
+		levelCounts:           helpers.NewSafeMap(make(map[string]int32)),
+		reactions:             helpers.NewSafeMap(make(map[reactionTarget][]userReaction)),
+		users:                 helpers.NewSafeMap(make(map[uuid.UUID]*model.User)),
+		usernames:             helpers.NewSafeMap(make(map[string]uuid.UUID)),
+		postTags:              helpers.NewSafeMap(make(map[int64][]string)),
+		postReplyDepths:       helpers.NewSafeMap(make(map[int64]int32)),
+		postMentions:          helpers.NewSafeMap(make(map[int64][]uuid.UUID)),
+		commentMentions:       helpers.NewSafeMap(make(map[int64][]uuid.UUID)),
+		postRevisions:         helpers.NewSafeMap(make(map[int64][]*model.Revision)),
+		commentRevisions:      helpers.NewSafeMap(make(map[int64][]*model.Revision)),
+		notifications:         helpers.NewSafeMap(make(map[int64]*model.Notification)),
+		userNotifications:     helpers.NewSafeMap(make(map[uuid.UUID][]int64)),
+		notificationRevisions: helpers.NewSafeMap(make(map[int64]int32)),
+		outbox:                helpers.NewSafeMap(make(map[int64]*events.Envelope)),
+		outboxLeases:          helpers.NewSafeMap(make(map[int64]eventLease)),
+		webhooks:              helpers.NewSafeMap(make(map[int64]*webhookSubscription)),
+		webhookDeliveries:     helpers.NewSafeMap(make(map[int64]*webhookDelivery)),
+		webhookEvents:         helpers.NewSafeMap(make(map[webhookEvent]int64)),
+		webhookHistory:        helpers.NewSafeMap(make(map[int64][]int64)),
+		bans:                  helpers.NewSafeMap(make(map[banKey]*model.Ban)),
+		pinnedComments:        helpers.NewSafeMap(make(map[int64][]int64)),
+		commentPinDates:       helpers.NewSafeMap(make(map[int64]time.Time)),
+		importedPosts:         helpers.NewSafeMap(make(map[importKey]int64)),
+		importedComments:      helpers.NewSafeMap(make(map[importKey]int64)),
+		postsIndex:            search.NewIndex(),
+		commentsIndex:         search.NewIndex(),
 	}
 }
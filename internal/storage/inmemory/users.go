@@ -0,0 +1,174 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/google/uuid"
+)
+
+func (inMemoryAccessor *InMemoryAccessor) AddUser(ctx context.Context, newUser *model.UserInput) (*model.User, error) {
+	if err := helpers.CheckDisplayName(newUser.DisplayName); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckAvatarURL(newUser.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckUsername(newUser.Username); err != nil {
+		return nil, err
+	}
+
+	user := &model.User{
+		ID:          uuid.New(),
+		Username:    newUser.Username,
+		DisplayName: newUser.DisplayName,
+		AvatarURL:   newUser.AvatarURL,
+		Role:        model.RoleUser,
+		CreateDate:  time.Now(),
+	}
+
+	if newUser.ID != nil {
+		user.ID = *newUser.ID
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	if _, ok := inMemoryAccessor.storage.users.Get(user.ID); ok {
+		return nil, errors.New("User with ID " + user.ID.String() + " already exists.")
+	}
+
+	if user.Username != nil {
+		if _, ok := inMemoryAccessor.storage.usernames.Get(strings.ToLower(*user.Username)); ok {
+			return nil, errors.New("Username " + *user.Username + " is already taken.")
+		}
+
+		inMemoryAccessor.storage.usernames.Set(strings.ToLower(*user.Username), user.ID)
+	}
+
+	inMemoryAccessor.storage.users.Set(user.ID, user)
+
+	return user, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetUser(ctx context.Context, userID uuid.UUID) (*model.User, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	user, ok := inMemoryAccessor.storage.users.Get(userID)
+
+	if !ok {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	}
+
+	return user, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetUsers(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]*model.User, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	users := make(map[uuid.UUID]*model.User, len(userIDs))
+	for _, userID := range userIDs {
+		if user, ok := inMemoryAccessor.storage.users.Get(userID); ok {
+			users[userID] = user
+		}
+	}
+
+	return users, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) UpdateUser(ctx context.Context, userID uuid.UUID, changes *model.UserUpdateInput) (*model.User, error) {
+	user, ok := inMemoryAccessor.storage.users.Get(userID)
+
+	if !ok {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	}
+
+	if changes.DisplayName != nil {
+		if err := helpers.CheckDisplayName(*changes.DisplayName); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := helpers.CheckAvatarURL(changes.AvatarURL); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.CheckUsername(changes.Username); err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	if changes.DisplayName != nil {
+		user.DisplayName = *changes.DisplayName
+	}
+
+	if changes.AvatarURL != nil {
+		user.AvatarURL = changes.AvatarURL
+	}
+
+	if changes.Username != nil {
+		username := strings.ToLower(*changes.Username)
+		if ownerID, ok := inMemoryAccessor.storage.usernames.Get(username); ok && ownerID != userID {
+			return nil, errors.New("Username " + *changes.Username + " is already taken.")
+		}
+
+		if user.Username != nil {
+			inMemoryAccessor.storage.usernames.Delete(strings.ToLower(*user.Username))
+		}
+
+		inMemoryAccessor.storage.usernames.Set(username, userID)
+		user.Username = changes.Username
+	}
+
+	return user, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) UpdateUserRole(ctx context.Context, userID uuid.UUID, role model.Role) (*model.User, error) {
+	user, ok := inMemoryAccessor.storage.users.Get(userID)
+
+	if !ok {
+		return nil, storage.NotFound("User with ID " + userID.String() + " was not found")
+	}
+
+	if !role.IsValid() {
+		return nil, errors.New("Role " + role.String() + " is not valid.")
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	user.Role = role
+
+	return user, nil
+}
@@ -0,0 +1,136 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsers(t *testing.T) {
+	mockStorage := NewInMemoryStorage()
+	mockAccessor := NewInMemoryAccessor(mockStorage)
+	defer mockAccessor.CloseStorage()
+
+	assertions := assert.New(t)
+	userID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Successful Add User", func(t *testing.T) {
+		avatarURL := "https://example.com/avatar.png"
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			ID:          &userID,
+			DisplayName: "Test User",
+			AvatarURL:   &avatarURL,
+		})
+
+		assertions.Nil(err)
+		assertions.Equal(&model.User{
+			ID:          userID,
+			DisplayName: "Test User",
+			AvatarURL:   &avatarURL,
+			Role:        model.RoleUser,
+			CreateDate:  user.CreateDate,
+		}, user)
+	})
+
+	t.Run("Successful Add User Generated ID", func(t *testing.T) {
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			DisplayName: "Another User",
+		})
+
+		assertions.Nil(err)
+		assertions.NotEqual(uuid.Nil, user.ID)
+		assertions.Nil(user.AvatarURL)
+	})
+
+	t.Run("Unsuccessful Add User Already Exists", func(t *testing.T) {
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			ID:          &userID,
+			DisplayName: "Test User",
+		})
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Unsuccessful Add User Empty Display Name", func(t *testing.T) {
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			DisplayName: " ",
+		})
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Unsuccessful Add User Incorrect Avatar URL", func(t *testing.T) {
+		avatarURL := "javascript:alert(1)"
+		user, err := mockAccessor.AddUser(ctx, &model.UserInput{
+			DisplayName: "Test User",
+			AvatarURL:   &avatarURL,
+		})
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Successful Update User", func(t *testing.T) {
+		displayName := "Renamed User"
+		user, err := mockAccessor.UpdateUser(ctx, userID, &model.UserUpdateInput{
+			DisplayName: &displayName,
+		})
+
+		assertions.Nil(err)
+		assertions.Equal(displayName, user.DisplayName)
+		assertions.NotNil(user.AvatarURL)
+	})
+
+	t.Run("Unsuccessful Update User Does Not Exist", func(t *testing.T) {
+		displayName := "Renamed User"
+		user, err := mockAccessor.UpdateUser(ctx, uuid.New(), &model.UserUpdateInput{
+			DisplayName: &displayName,
+		})
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Successful Get Users", func(t *testing.T) {
+		unknownID := uuid.New()
+		users, err := mockAccessor.GetUsers(ctx, []uuid.UUID{userID, unknownID})
+
+		assertions.Nil(err)
+		assertions.Len(users, 1)
+		assertions.Equal("Renamed User", users[userID].DisplayName)
+	})
+
+	t.Run("Unsuccessful Get User Does Not Exist", func(t *testing.T) {
+		user, err := mockAccessor.GetUser(ctx, uuid.New())
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Successful Update User Role", func(t *testing.T) {
+		user, err := mockAccessor.UpdateUserRole(ctx, userID, model.RoleModerator)
+
+		assertions.Nil(err)
+		assertions.Equal(model.RoleModerator, user.Role)
+	})
+
+	t.Run("Unsuccessful Update User Role Invalid Role", func(t *testing.T) {
+		user, err := mockAccessor.UpdateUserRole(ctx, userID, model.Role("OWNER"))
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+
+	t.Run("Unsuccessful Update User Role Does Not Exist", func(t *testing.T) {
+		user, err := mockAccessor.UpdateUserRole(ctx, uuid.New(), model.RoleAdmin)
+
+		assertions.NotNil(err)
+		assertions.Nil(user)
+	})
+}
@@ -0,0 +1,267 @@
+package inmemory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/cursor"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/helpers"
+	"github.com/C-4KE/simple-posts-service/internal/storage"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
+	"github.com/google/uuid"
+)
+
+func (inMemoryAccessor *InMemoryAccessor) AddWebhookSubscription(ctx context.Context, newSubscription *model.WebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	if err := helpers.CheckWebhookSubscription(newSubscription); err != nil {
+		return nil, err
+	}
+
+	subscription := &model.WebhookSubscription{
+		OwnerID:    newSubscription.OwnerID,
+		URL:        newSubscription.URL,
+		EventKinds: newSubscription.EventKinds,
+		CreateDate: time.Now(),
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.webhooksMutex.Unlock()
+	inMemoryAccessor.webhooksMutex.Lock()
+
+	inMemoryAccessor.lastWebhookID++
+	subscription.ID = inMemoryAccessor.lastWebhookID
+
+	inMemoryAccessor.storage.webhooks.Set(subscription.ID, &webhookSubscription{
+		subscription: subscription,
+		secret:       newSubscription.Secret,
+	})
+
+	return subscription, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) DeleteWebhookSubscription(ctx context.Context, subscriptionID int64, ownerID uuid.UUID) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	if _, err := inMemoryAccessor.getOwnWebhook(subscriptionID, ownerID); err != nil {
+		return err
+	}
+
+	inMemoryAccessor.storage.webhooks.Delete(subscriptionID)
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetWebhookSubscriptions(ctx context.Context, ownerID uuid.UUID) ([]*model.WebhookSubscription, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	subscriptions := make([]*model.WebhookSubscription, 0)
+	for _, webhook := range inMemoryAccessor.storage.webhooks.GetValues() {
+		if webhook.subscription.OwnerID == ownerID {
+			subscriptions = append(subscriptions, webhook.subscription)
+		}
+	}
+
+	slices.SortFunc(subscriptions, func(first, second *model.WebhookSubscription) int {
+		return cmp.Compare(first.ID, second.ID)
+	})
+
+	return subscriptions, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) GetWebhookDeliveries(ctx context.Context, subscriptionID int64, ownerID uuid.UUID, status *model.WebhookDeliveryStatus, after *cursor.DeliveryCursor, limit *int32) ([]*model.WebhookDelivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	if _, err := inMemoryAccessor.getOwnWebhook(subscriptionID, ownerID); err != nil {
+		return nil, err
+	}
+
+	deliveryIDs, _ := inMemoryAccessor.storage.webhookHistory.Get(subscriptionID)
+
+	deliveries := make([]*model.WebhookDelivery, 0)
+	for _, deliveryID := range slices.Backward(deliveryIDs) {
+		if limit != nil && len(deliveries) == int(*limit) {
+			break
+		}
+
+		if after != nil && deliveryID >= after.DeliveryID {
+			continue
+		}
+
+		stored, ok := inMemoryAccessor.storage.webhookDeliveries.Get(deliveryID)
+		if !ok || (status != nil && stored.delivery.Status != *status) {
+			continue
+		}
+
+		deliveries = append(deliveries, stored.delivery)
+	}
+
+	return deliveries, nil
+}
+
+// AddWebhookDeliveries creates a pending delivery of the event for every subscription to its kind.
+func (inMemoryAccessor *InMemoryAccessor) AddWebhookDeliveries(ctx context.Context, envelope *events.Envelope) (int32, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.webhooksMutex.Unlock()
+	inMemoryAccessor.webhooksMutex.Lock()
+
+	var added int32
+	for _, webhook := range inMemoryAccessor.storage.webhooks.GetValues() {
+		if !slices.Contains(webhook.subscription.EventKinds, model.EventKind(envelope.Kind)) {
+			continue
+		}
+
+		key := webhookEvent{subscriptionID: webhook.subscription.ID, eventID: envelope.ID}
+		if _, ok := inMemoryAccessor.storage.webhookEvents.Get(key); ok {
+			continue
+		}
+
+		now := time.Now()
+		inMemoryAccessor.lastDeliveryID++
+		delivery := &model.WebhookDelivery{
+			ID:              inMemoryAccessor.lastDeliveryID,
+			SubscriptionID:  webhook.subscription.ID,
+			EventID:         envelope.ID,
+			EventKind:       model.EventKind(envelope.Kind),
+			Status:          model.WebhookDeliveryStatusPending,
+			NextAttemptDate: now,
+			CreateDate:      now,
+		}
+
+		inMemoryAccessor.storage.webhookDeliveries.Set(delivery.ID, &webhookDelivery{
+			delivery: delivery,
+			envelope: envelope,
+		})
+		inMemoryAccessor.storage.webhookEvents.Set(key, delivery.ID)
+
+		history, _ := inMemoryAccessor.storage.webhookHistory.Get(delivery.SubscriptionID)
+		inMemoryAccessor.storage.webhookHistory.Set(delivery.SubscriptionID, append(slices.Clone(history), delivery.ID))
+
+		added++
+	}
+
+	return added, nil
+}
+
+// ClaimDueWebhookDeliveries returns deliveries due at now and moves their next attempt to leaseUntil,
+// so they are not returned again until the worker updates them or the lease expires.
+func (inMemoryAccessor *InMemoryAccessor) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int32) ([]*webhooks.PendingDelivery, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+
+	default:
+	}
+
+	defer inMemoryAccessor.webhooksMutex.Unlock()
+	inMemoryAccessor.webhooksMutex.Lock()
+
+	due := make([]*webhookDelivery, 0)
+	for _, stored := range inMemoryAccessor.storage.webhookDeliveries.GetValues() {
+		if stored.delivery.Status != model.WebhookDeliveryStatusPending || stored.delivery.NextAttemptDate.After(now) {
+			continue
+		}
+
+		if _, ok := inMemoryAccessor.storage.webhooks.Get(stored.delivery.SubscriptionID); ok {
+			due = append(due, stored)
+		}
+	}
+
+	slices.SortFunc(due, func(first, second *webhookDelivery) int {
+		return cmp.Or(first.delivery.NextAttemptDate.Compare(second.delivery.NextAttemptDate),
+			cmp.Compare(first.delivery.ID, second.delivery.ID))
+	})
+
+	if len(due) > int(limit) {
+		due = due[:limit]
+	}
+
+	pendingDeliveries := make([]*webhooks.PendingDelivery, 0, len(due))
+	for _, stored := range due {
+		claimed := *stored.delivery
+		claimed.NextAttemptDate = leaseUntil
+		inMemoryAccessor.storage.webhookDeliveries.Set(claimed.ID, &webhookDelivery{
+			delivery: &claimed,
+			envelope: stored.envelope,
+		})
+
+		webhook, _ := inMemoryAccessor.storage.webhooks.Get(claimed.SubscriptionID)
+
+		// The worker changes the delivery, it must not touch the stored one until the update.
+		delivery := claimed
+		pendingDeliveries = append(pendingDeliveries, &webhooks.PendingDelivery{
+			Delivery: &delivery,
+			URL:      webhook.subscription.URL,
+			Secret:   webhook.secret,
+			Envelope: stored.envelope,
+		})
+	}
+
+	return pendingDeliveries, nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+
+	default:
+	}
+
+	stored, ok := inMemoryAccessor.storage.webhookDeliveries.Get(delivery.ID)
+	if !ok {
+		return storage.NotFound("Webhook delivery with ID " + strconv.FormatInt(delivery.ID, 10) + " was not found")
+	}
+
+	updated := *delivery
+	inMemoryAccessor.storage.webhookDeliveries.Set(delivery.ID, &webhookDelivery{
+		delivery: &updated,
+		envelope: stored.envelope,
+	})
+
+	return nil
+}
+
+func (inMemoryAccessor *InMemoryAccessor) getOwnWebhook(subscriptionID int64, ownerID uuid.UUID) (*webhookSubscription, error) {
+	webhook, ok := inMemoryAccessor.storage.webhooks.Get(subscriptionID)
+	if !ok {
+		return nil, storage.NotFound("Webhook subscription with ID " + strconv.FormatInt(subscriptionID, 10) + " was not found")
+	}
+
+	if webhook.subscription.OwnerID != ownerID {
+		return nil, errors.New("User with ID " + strconv.FormatUint(uint64(ownerID.ID()), 10) + " is not the owner of the webhook subscription with ID " + strconv.FormatInt(subscriptionID, 10) + ".")
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,171 @@
+package inmemory
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/C-4KE/simple-posts-service/internal/webhooks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooks(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+	ownerID := uuid.New()
+	secret := "0123456789abcdef"
+
+	inMemoryStorage := NewInMemoryStorage()
+	inMemoryAccessor := NewInMemoryAccessor(inMemoryStorage)
+
+	var received []string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhooks.HeaderTimestamp), 10, 64)
+		if r.Header.Get(webhooks.HeaderSignature) != webhooks.Sign(secret, timestamp, body) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		received = append(received, r.Header.Get(webhooks.HeaderEventKind))
+	}))
+	defer receiver.Close()
+
+	// Subscriptions to the loopback address of the receiver are rejected, so the public URL is dialed to the receiver.
+	receiverURL := "http://receiver.example/hook"
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, receiver.Listener.Addr().String())
+		},
+	}}
+
+	t.Run("Unsuccessful Add Webhook Subscription", func(t *testing.T) {
+		subscription, err := inMemoryAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+			OwnerID:    ownerID,
+			URL:        "ftp://example.com",
+			EventKinds: []model.EventKind{model.EventKindPostCreated},
+			Secret:     secret,
+		})
+		assertions.NotNil(err)
+		assertions.Nil(subscription)
+
+		subscription, err = inMemoryAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+			OwnerID:    ownerID,
+			URL:        receiverURL,
+			EventKinds: []model.EventKind{model.EventKindPostCreated},
+			Secret:     "short",
+		})
+		assertions.NotNil(err)
+		assertions.Nil(subscription)
+
+		for _, url := range []string{receiver.URL, "http://localhost:8080/hook", "http://10.0.0.1/hook", "http://169.254.169.254/latest", "http://[::1]/hook"} {
+			subscription, err = inMemoryAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+				OwnerID:    ownerID,
+				URL:        url,
+				EventKinds: []model.EventKind{model.EventKindPostCreated},
+				Secret:     secret,
+			})
+			assertions.NotNil(err, url)
+			assertions.Nil(subscription)
+		}
+	})
+
+	t.Run("Successful Add Webhook Subscription", func(t *testing.T) {
+		subscription, err := inMemoryAccessor.AddWebhookSubscription(ctx, &model.WebhookSubscriptionInput{
+			OwnerID:    ownerID,
+			URL:        receiverURL,
+			EventKinds: []model.EventKind{model.EventKindPostCreated, model.EventKindCommentAdded},
+			Secret:     secret,
+		})
+		assertions.Nil(err)
+		assertions.Equal(int64(0), subscription.ID)
+
+		subscriptions, err := inMemoryAccessor.GetWebhookSubscriptions(ctx, ownerID)
+		assertions.Nil(err)
+		assertions.Equal([]*model.WebhookSubscription{subscription}, subscriptions)
+	})
+
+	t.Run("Successful Add Webhook Deliveries", func(t *testing.T) {
+		added, err := inMemoryAccessor.AddWebhookDeliveries(ctx, &events.Envelope{ID: 1, Kind: events.KindPostCreated, Payload: []byte(`{}`)})
+		assertions.Nil(err)
+		assertions.Equal(int32(1), added)
+
+		added, err = inMemoryAccessor.AddWebhookDeliveries(ctx, &events.Envelope{ID: 1, Kind: events.KindPostCreated, Payload: []byte(`{}`)})
+		assertions.Nil(err)
+		assertions.Equal(int32(0), added)
+
+		added, err = inMemoryAccessor.AddWebhookDeliveries(ctx, &events.Envelope{ID: 2, Kind: events.KindCommentsToggled, Payload: []byte(`{}`)})
+		assertions.Nil(err)
+		assertions.Equal(int32(0), added)
+	})
+
+	t.Run("Successful Claim Due Webhook Deliveries", func(t *testing.T) {
+		now := time.Now()
+		pendingDeliveries, err := inMemoryAccessor.ClaimDueWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Len(pendingDeliveries, 1)
+		assertions.True(now.Add(time.Minute).Equal(pendingDeliveries[0].Delivery.NextAttemptDate))
+
+		pendingDeliveries, err = inMemoryAccessor.ClaimDueWebhookDeliveries(ctx, now, now.Add(time.Minute), 10)
+		assertions.Nil(err)
+		assertions.Len(pendingDeliveries, 0)
+
+		pendingDeliveries, err = inMemoryAccessor.ClaimDueWebhookDeliveries(ctx, now.Add(time.Minute), now, 10)
+		assertions.Nil(err)
+		assertions.Len(pendingDeliveries, 1)
+	})
+
+	t.Run("Successful Send Webhook Deliveries", func(t *testing.T) {
+		worker := webhooks.NewWorker(inMemoryAccessor, client, 3, time.Minute, time.Second)
+
+		processed, err := worker.ProcessDue(ctx, time.Now())
+		assertions.Nil(err)
+		assertions.Equal(1, processed)
+		assertions.Equal([]string{string(events.KindPostCreated)}, received)
+
+		processed, err = worker.ProcessDue(ctx, time.Now())
+		assertions.Nil(err)
+		assertions.Equal(0, processed)
+	})
+
+	t.Run("Successful Get Webhook Deliveries", func(t *testing.T) {
+		status := model.WebhookDeliveryStatusSucceeded
+		deliveries, err := inMemoryAccessor.GetWebhookDeliveries(ctx, 0, ownerID, &status, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(deliveries, 1)
+		assertions.Equal(int64(1), deliveries[0].EventID)
+		assertions.Equal(int32(1), deliveries[0].Attempts)
+
+		status = model.WebhookDeliveryStatusDead
+		deliveries, err = inMemoryAccessor.GetWebhookDeliveries(ctx, 0, ownerID, &status, nil, nil)
+		assertions.Nil(err)
+		assertions.Len(deliveries, 0)
+	})
+
+	t.Run("Unsuccessful Get Webhook Deliveries not owner", func(t *testing.T) {
+		deliveries, err := inMemoryAccessor.GetWebhookDeliveries(ctx, 0, uuid.New(), nil, nil, nil)
+		assertions.NotNil(err)
+		assertions.Nil(deliveries)
+	})
+
+	t.Run("Successful Delete Webhook Subscription", func(t *testing.T) {
+		assertions.NotNil(inMemoryAccessor.DeleteWebhookSubscription(ctx, 0, uuid.New()))
+		assertions.Nil(inMemoryAccessor.DeleteWebhookSubscription(ctx, 0, ownerID))
+
+		subscriptions, err := inMemoryAccessor.GetWebhookSubscriptions(ctx, ownerID)
+		assertions.Nil(err)
+		assertions.Len(subscriptions, 0)
+
+		added, err := inMemoryAccessor.AddWebhookDeliveries(ctx, &events.Envelope{ID: 3, Kind: events.KindPostCreated, Payload: []byte(`{}`)})
+		assertions.Nil(err)
+		assertions.Equal(int32(0), added)
+	})
+}
@@ -0,0 +1,156 @@
+package textrefs
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	MaxMentionLength = 64
+	MaxTagLength     = 50
+)
+
+// Refs holds @mentions and #tags found in a text. Both are lowercased, deduplicated
+// and kept in the order of their first appearance.
+type Refs struct {
+	Mentions []string
+	Tags     []string
+}
+
+// Extract finds references in texts. A reference starts with "@" or "#" at the beginning of a word
+// and continues with letters, digits and "_". Tags must contain at least one letter, so "#1" stays a number.
+// Text in `code spans` and fenced code blocks, e-mail addresses and URL fragments are skipped.
+func Extract(texts ...string) *Refs {
+	refs := &Refs{
+		Mentions: make([]string, 0),
+		Tags:     make([]string, 0),
+	}
+
+	for _, text := range texts {
+		refs.extract(stripCode(text))
+	}
+
+	return refs
+}
+
+// NormalizeTag converts a tag written by a user, with or without the leading "#", to the stored form.
+func NormalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+}
+
+// IsName reports whether name can be written after "@" as a whole, so a user with this name can be mentioned.
+func IsName(name string) bool {
+	return name != "" && utf8.RuneCountInString(name) <= MaxMentionLength && strings.IndexFunc(name, func(char rune) bool {
+		return !isRefChar(char)
+	}) < 0
+}
+
+func (refs *Refs) extract(text string) {
+	for idx := 0; idx < len(text); {
+		char, size := utf8.DecodeRuneInString(text[idx:])
+		if (char != '@' && char != '#') || !isRefStart(text, idx) {
+			idx += size
+			continue
+		}
+
+		end := idx + size
+		for end < len(text) {
+			next, nextSize := utf8.DecodeRuneInString(text[end:])
+			if !isRefChar(next) {
+				break
+			}
+			end += nextSize
+		}
+
+		name := strings.ToLower(text[idx+size : end])
+		idx = end
+
+		if !isRefEnd(text, end) {
+			continue
+		}
+
+		switch char {
+		case '@':
+			if name != "" && utf8.RuneCountInString(name) <= MaxMentionLength && !slices.Contains(refs.Mentions, name) {
+				refs.Mentions = append(refs.Mentions, name)
+			}
+		case '#':
+			if hasLetter(name) && utf8.RuneCountInString(name) <= MaxTagLength && !slices.Contains(refs.Tags, name) {
+				refs.Tags = append(refs.Tags, name)
+			}
+		}
+	}
+}
+
+// isRefStart reports whether the marker at idx begins a word that is not a part of an e-mail address or a URL.
+func isRefStart(text string, idx int) bool {
+	if idx == 0 {
+		return true
+	}
+
+	previous, _ := utf8.DecodeLastRuneInString(text[:idx])
+	if isRefChar(previous) || previous == '@' || previous == '#' || previous == '/' || previous == '&' {
+		return false
+	}
+
+	wordStart := strings.LastIndexFunc(text[:idx], unicode.IsSpace) + 1
+	return !strings.Contains(text[wordStart:idx], "://")
+}
+
+// isRefEnd rejects references glued to another marker, like "@user@host" or "#tag#more".
+func isRefEnd(text string, end int) bool {
+	if end >= len(text) {
+		return true
+	}
+
+	next, _ := utf8.DecodeRuneInString(text[end:])
+	return next != '@' && next != '#'
+}
+
+func isRefChar(char rune) bool {
+	return unicode.IsLetter(char) || unicode.IsDigit(char) || unicode.IsMark(char) || char == '_'
+}
+
+func hasLetter(name string) bool {
+	return strings.IndexFunc(name, unicode.IsLetter) >= 0
+}
+
+// stripCode replaces code spans and fenced code blocks with spaces, the same way Markdown renders them as code.
+func stripCode(text string) string {
+	var output strings.Builder
+
+	inBlock := false
+	for line := range strings.Lines(text) {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inBlock = !inBlock
+			output.WriteString("\n")
+			continue
+		}
+
+		if inBlock {
+			output.WriteString("\n")
+			continue
+		}
+
+		for {
+			start := strings.Index(line, "`")
+			if start < 0 {
+				break
+			}
+
+			end := strings.Index(line[start+1:], "`")
+			if end < 0 {
+				break
+			}
+
+			output.WriteString(line[:start] + " ")
+			line = line[start+end+2:]
+		}
+
+		output.WriteString(line)
+	}
+
+	return output.String()
+}
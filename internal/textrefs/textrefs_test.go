@@ -0,0 +1,70 @@
+package textrefs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+	assertions := assert.New(t)
+
+	testCases := []struct {
+		name     string
+		texts    []string
+		mentions []string
+		tags     []string
+	}{
+		{"Simple", []string{"Hi @alice, see #golang"}, []string{"alice"}, []string{"golang"}},
+		{"Several texts", []string{"#Title", "text @Bob #title"}, []string{"bob"}, []string{"title"}},
+		{"Duplicates keep first order", []string{"#b #a #B @x @y @X"}, []string{"x", "y"}, []string{"b", "a"}},
+		{"Unicode", []string{"Привет @Пётр_1, тема #Гоу и #日本語"}, []string{"пётр_1"}, []string{"гоу", "日本語"}},
+		{"Combining marks", []string{"#café"}, []string{}, []string{"café"}},
+		{"Trailing punctuation", []string{"(@alice). #go! #rust? @bob:"}, []string{"alice", "bob"}, []string{"go", "rust"}},
+		{"Leading punctuation", []string{"\"@alice\" [#go]"}, []string{"alice"}, []string{"go"}},
+		{"Numbers are not tags", []string{"issue #123 and #1st"}, []string{}, []string{"1st"}},
+		{"Bare markers", []string{"@ # @, #."}, []string{}, []string{}},
+		{"E-mail is not a mention", []string{"write to user@example.com"}, []string{}, []string{}},
+		{"Word with hash is not a tag", []string{"C# and F#"}, []string{}, []string{}},
+		{"URL fragment is not a tag", []string{"https://example.com/page#section and https://example.com/?a=1&#x"}, []string{}, []string{}},
+		{"Glued markers", []string{"@user@host #tag#more"}, []string{}, []string{}},
+		{"Code span", []string{"`@alice #go` but @bob #rust"}, []string{"bob"}, []string{"rust"}},
+		{"Unclosed code span", []string{"`@alice"}, []string{"alice"}, []string{}},
+		{"Code block", []string{"```\n@alice #go\n```\n@bob"}, []string{"bob"}, []string{}},
+		{"Too long", []string{"@" + strings.Repeat("a", MaxMentionLength+1) + " #" + strings.Repeat("b", MaxTagLength+1)}, []string{}, []string{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run("Successful Extract "+testCase.name, func(t *testing.T) {
+			refs := Extract(testCase.texts...)
+			assertions.Equal(testCase.mentions, refs.Mentions)
+			assertions.Equal(testCase.tags, refs.Tags)
+		})
+	}
+}
+
+func TestNormalizeTag(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful NormalizeTag", func(t *testing.T) {
+		assertions.Equal("golang", NormalizeTag(" #GoLang "))
+		assertions.Equal("гоу", NormalizeTag("Гоу"))
+	})
+}
+
+func TestIsName(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful IsName", func(t *testing.T) {
+		assertions.True(IsName("alice_1"))
+		assertions.True(IsName("Пётр"))
+	})
+
+	t.Run("Unsuccessful IsName", func(t *testing.T) {
+		assertions.False(IsName(""))
+		assertions.False(IsName("alice smith"))
+		assertions.False(IsName("alice.smith"))
+		assertions.False(IsName(strings.Repeat("a", MaxMentionLength+1)))
+	})
+}
@@ -0,0 +1,277 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+)
+
+const (
+	defaultBatchSize   = 100
+	defaultConcurrency = 10
+	maxRetryDelay      = time.Hour
+	deliveryLease      = 10 * time.Minute
+
+	HeaderSignature  = "X-Webhook-Signature"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderEventID    = "X-Event-ID"
+	HeaderEventKind  = "X-Event-Kind"
+)
+
+// PendingDelivery is a delivery that is due, together with everything needed to send it.
+type PendingDelivery struct {
+	Delivery *model.WebhookDelivery
+	URL      string
+	Secret   string
+	Envelope *events.Envelope
+}
+
+// Store keeps webhook subscriptions and the state of their deliveries.
+type Store interface {
+	AddWebhookDeliveries(ctx context.Context, envelope *events.Envelope) (int32, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int32) ([]*PendingDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
+
+// Sign returns the signature of the request body sent at the timestamp (Unix seconds).
+// Receivers compute the same HMAC-SHA256 with their secret and compare it with the X-Webhook-Signature header.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CheckAddr rejects addresses that are not reachable from the public internet (loopback, private, link-local and others),
+// so subscriptions cannot make the service send requests to itself or to its internal network.
+func CheckAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return errors.New("Webhook address " + addr.String() + " is not a public address.")
+	}
+
+	return nil
+}
+
+// NewClient returns the client for sending deliveries. Addresses are checked when the connection is dialed,
+// after the host is resolved, so host names and redirects pointing to internal addresses are rejected as well.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+
+			return CheckAddr(addrPort.Addr())
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the receiver and hide its address from the check.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// Sink receives events from the dispatcher and creates a delivery for every subscription to the event kind.
+// Deliveries are unique per subscription and event, so a repeated event does not create duplicates.
+type Sink struct {
+	store Store
+}
+
+func NewSink(store Store) *Sink {
+	return &Sink{
+		store: store,
+	}
+}
+
+func (sink *Sink) Deliver(ctx context.Context, envelope *events.Envelope) error {
+	_, err := sink.store.AddWebhookDeliveries(ctx, envelope)
+	return err
+}
+
+// Worker sends due deliveries, up to concurrency at once. A failed delivery is retried with exponential backoff
+// and is marked as dead after maxAttempts attempts.
+type Worker struct {
+	store        Store
+	client       *http.Client
+	maxAttempts  int32
+	retryDelay   time.Duration
+	pollInterval time.Duration
+	batchSize    int32
+	concurrency  int
+}
+
+func NewWorker(store Store, client *http.Client, maxAttempts int32, retryDelay time.Duration, pollInterval time.Duration) *Worker {
+	return &Worker{
+		store:        store,
+		client:       client,
+		maxAttempts:  maxAttempts,
+		retryDelay:   retryDelay,
+		pollInterval: pollInterval,
+		batchSize:    defaultBatchSize,
+		concurrency:  defaultConcurrency,
+	}
+}
+
+// Run polls due deliveries until the context is cancelled.
+func (worker *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(worker.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-ticker.C:
+		}
+
+		if _, err := worker.ProcessDue(ctx, time.Now()); err != nil {
+			log.Printf("Error while sending webhook deliveries: %s", err)
+		}
+	}
+}
+
+// ProcessDue claims deliveries due at now, makes one attempt for every one of them and returns the number of attempts.
+// Claimed deliveries are hidden from other workers for deliveryLease, which exceeds the time of sending a batch.
+// A slow receiver holds only one of the concurrent sends, so it does not delay deliveries to other receivers.
+func (worker *Worker) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	pendingDeliveries, err := worker.store.ClaimDueWebhookDeliveries(ctx, now, now.Add(deliveryLease), worker.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var waitGroup sync.WaitGroup
+	var mutex sync.Mutex
+	var updateErr error
+	processed := 0
+
+	semaphore := make(chan struct{}, worker.concurrency)
+	for _, pendingDelivery := range pendingDeliveries {
+		semaphore <- struct{}{}
+		waitGroup.Go(func() {
+			defer func() { <-semaphore }()
+
+			worker.attempt(ctx, pendingDelivery)
+			err := worker.store.UpdateWebhookDelivery(ctx, pendingDelivery.Delivery)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if err != nil {
+				if updateErr == nil {
+					updateErr = err
+				}
+				return
+			}
+
+			processed++
+		})
+	}
+
+	waitGroup.Wait()
+
+	return processed, updateErr
+}
+
+func (worker *Worker) attempt(ctx context.Context, pendingDelivery *PendingDelivery) {
+	delivery := pendingDelivery.Delivery
+	delivery.Attempts++
+
+	statusCode, err := worker.send(ctx, pendingDelivery)
+	now := time.Now()
+
+	delivery.LastStatusCode = nil
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	if err == nil {
+		delivery.Status = model.WebhookDeliveryStatusSucceeded
+		delivery.LastError = nil
+		delivery.DeliveryDate = &now
+		return
+	}
+
+	lastError := err.Error()
+	delivery.LastError = &lastError
+
+	if delivery.Attempts >= worker.maxAttempts {
+		delivery.Status = model.WebhookDeliveryStatusDead
+		return
+	}
+
+	delivery.NextAttemptDate = now.Add(worker.getRetryDelay(delivery.Attempts))
+}
+
+// getRetryDelay doubles the delay after every failed attempt.
+func (worker *Worker) getRetryDelay(attempts int32) time.Duration {
+	delay := worker.retryDelay
+	for range attempts - 1 {
+		delay *= 2
+		if delay >= maxRetryDelay {
+			return maxRetryDelay
+		}
+	}
+
+	return delay
+}
+
+func (worker *Worker) send(ctx context.Context, pendingDelivery *PendingDelivery) (int32, error) {
+	body, err := json.Marshal(pendingDelivery.Envelope)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, pendingDelivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(HeaderSignature, Sign(pendingDelivery.Secret, timestamp, body))
+	request.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(HeaderDeliveryID, strconv.FormatInt(pendingDelivery.Delivery.ID, 10))
+	request.Header.Set(HeaderEventID, strconv.FormatInt(pendingDelivery.Envelope.ID, 10))
+	request.Header.Set(HeaderEventKind, string(pendingDelivery.Envelope.Kind))
+
+	response, err := worker.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return int32(response.StatusCode), errors.New("Webhook responded with status " + strconv.Itoa(response.StatusCode) + ".")
+	}
+
+	return int32(response.StatusCode), nil
+}
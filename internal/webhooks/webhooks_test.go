@@ -0,0 +1,244 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/C-4KE/simple-posts-service/graph/model"
+	"github.com/C-4KE/simple-posts-service/internal/events"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "0123456789abcdef"
+
+type testStore struct {
+	mutex    sync.Mutex
+	pending  []*PendingDelivery
+	updated  []model.WebhookDelivery
+	envelope *events.Envelope
+}
+
+func (store *testStore) AddWebhookDeliveries(ctx context.Context, envelope *events.Envelope) (int32, error) {
+	store.envelope = envelope
+	return 1, nil
+}
+
+func (store *testStore) ClaimDueWebhookDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit int32) ([]*PendingDelivery, error) {
+	due := make([]*PendingDelivery, 0)
+	for _, pendingDelivery := range store.pending {
+		if pendingDelivery.Delivery.Status == model.WebhookDeliveryStatusPending && !pendingDelivery.Delivery.NextAttemptDate.After(now) {
+			pendingDelivery.Delivery.NextAttemptDate = leaseUntil
+			due = append(due, pendingDelivery)
+		}
+	}
+
+	return due, nil
+}
+
+func (store *testStore) UpdateWebhookDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.updated = append(store.updated, *delivery)
+	return nil
+}
+
+func newTestPendingDelivery(url string) *PendingDelivery {
+	return &PendingDelivery{
+		Delivery: &model.WebhookDelivery{
+			ID:              3,
+			SubscriptionID:  1,
+			EventID:         7,
+			EventKind:       model.EventKindCommentAdded,
+			Status:          model.WebhookDeliveryStatusPending,
+			NextAttemptDate: time.Now(),
+			CreateDate:      time.Now(),
+		},
+		URL:    url,
+		Secret: testSecret,
+		Envelope: &events.Envelope{
+			ID:      7,
+			Kind:    events.KindCommentAdded,
+			Payload: []byte(`{"commentID":4}`),
+		},
+	}
+}
+
+func TestSign(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Sign", func(t *testing.T) {
+		body := []byte(`{"id":1}`)
+
+		assertions.Equal(Sign(testSecret, 1700000000, body), Sign(testSecret, 1700000000, body))
+		assertions.Regexp(`^sha256=[0-9a-f]{64}$`, Sign(testSecret, 1700000000, body))
+	})
+
+	t.Run("Unsuccessful Sign with another secret or timestamp", func(t *testing.T) {
+		body := []byte(`{"id":1}`)
+
+		assertions.NotEqual(Sign(testSecret, 1700000000, body), Sign("fedcba9876543210", 1700000000, body))
+		assertions.NotEqual(Sign(testSecret, 1700000000, body), Sign(testSecret, 1700000001, body))
+	})
+}
+
+func TestCheckAddr(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Check Addr", func(t *testing.T) {
+		for _, addr := range []string{"93.184.216.34", "2606:2800:220:1::1"} {
+			assertions.Nil(CheckAddr(netip.MustParseAddr(addr)), addr)
+		}
+	})
+
+	t.Run("Unsuccessful Check Addr not public", func(t *testing.T) {
+		for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fc00::1", "::ffff:127.0.0.1"} {
+			assertions.NotNil(CheckAddr(netip.MustParseAddr(addr)), addr)
+		}
+	})
+
+	t.Run("Unsuccessful New Client dials loopback", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer receiver.Close()
+
+		_, err := NewClient(time.Second).Get(receiver.URL)
+		assertions.NotNil(err)
+	})
+}
+
+func TestSink(t *testing.T) {
+	assertions := assert.New(t)
+
+	t.Run("Successful Deliver", func(t *testing.T) {
+		store := &testStore{}
+		envelope := &events.Envelope{ID: 1, Kind: events.KindPostCreated}
+
+		assertions.Nil(NewSink(store).Deliver(context.Background(), envelope))
+		assertions.Equal(envelope, store.envelope)
+	})
+}
+
+func TestWorker(t *testing.T) {
+	assertions := assert.New(t)
+	ctx := context.Background()
+
+	t.Run("Successful Process Due", func(t *testing.T) {
+		var signatureValid bool
+		var headers http.Header
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			headers = r.Header
+			body, _ := io.ReadAll(r.Body)
+			timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+			signatureValid = r.Header.Get(HeaderSignature) == Sign(testSecret, timestamp, body)
+		}))
+		defer receiver.Close()
+
+		store := &testStore{pending: []*PendingDelivery{newTestPendingDelivery(receiver.URL)}}
+		worker := NewWorker(store, receiver.Client(), 3, time.Second, time.Second)
+
+		processed, err := worker.ProcessDue(ctx, time.Now())
+		assertions.Nil(err)
+		assertions.Equal(1, processed)
+		assertions.True(signatureValid)
+		assertions.Equal("3", headers.Get(HeaderDeliveryID))
+		assertions.Equal("7", headers.Get(HeaderEventID))
+		assertions.Equal(string(events.KindCommentAdded), headers.Get(HeaderEventKind))
+
+		assertions.Len(store.updated, 1)
+		assertions.Equal(model.WebhookDeliveryStatusSucceeded, store.updated[0].Status)
+		assertions.Equal(int32(1), store.updated[0].Attempts)
+		assertions.Equal(int32(http.StatusOK), *store.updated[0].LastStatusCode)
+		assertions.NotNil(store.updated[0].DeliveryDate)
+	})
+
+	t.Run("Successful Process Due sends concurrently", func(t *testing.T) {
+		// The receiver answers only when both requests have arrived, so sequential sending would time out.
+		arrived := make(chan struct{}, 2)
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			arrived <- struct{}{}
+			for len(arrived) < 2 {
+				time.Sleep(time.Millisecond)
+			}
+		}))
+		defer receiver.Close()
+
+		first := newTestPendingDelivery(receiver.URL)
+		second := newTestPendingDelivery(receiver.URL)
+		second.Delivery.ID = 4
+
+		client := receiver.Client()
+		client.Timeout = time.Second
+
+		store := &testStore{pending: []*PendingDelivery{first, second}}
+		processed, err := NewWorker(store, client, 1, time.Minute, time.Second).ProcessDue(ctx, time.Now())
+		assertions.Nil(err)
+		assertions.Equal(2, processed)
+		assertions.Len(store.updated, 2)
+		for _, delivery := range store.updated {
+			assertions.Equal(model.WebhookDeliveryStatusSucceeded, delivery.Status)
+		}
+	})
+
+	t.Run("Successful Process Due retries with backoff and dead letter", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer receiver.Close()
+
+		pendingDelivery := newTestPendingDelivery(receiver.URL)
+		store := &testStore{pending: []*PendingDelivery{pendingDelivery}}
+		worker := NewWorker(store, receiver.Client(), 3, time.Minute, time.Second)
+
+		now := time.Now()
+		_, err := worker.ProcessDue(ctx, now)
+		assertions.Nil(err)
+		assertions.Equal(model.WebhookDeliveryStatusPending, store.updated[0].Status)
+		assertions.Equal(int32(http.StatusInternalServerError), *store.updated[0].LastStatusCode)
+		assertions.NotNil(store.updated[0].LastError)
+		assertions.WithinDuration(now.Add(time.Minute), store.updated[0].NextAttemptDate, 5*time.Second)
+
+		processed, err := worker.ProcessDue(ctx, now)
+		assertions.Nil(err)
+		assertions.Equal(0, processed)
+
+		_, err = worker.ProcessDue(ctx, pendingDelivery.Delivery.NextAttemptDate)
+		assertions.Nil(err)
+		assertions.Equal(int32(2), store.updated[1].Attempts)
+		assertions.WithinDuration(time.Now().Add(2*time.Minute), store.updated[1].NextAttemptDate, 5*time.Second)
+
+		_, err = worker.ProcessDue(ctx, pendingDelivery.Delivery.NextAttemptDate)
+		assertions.Nil(err)
+		assertions.Equal(int32(3), store.updated[2].Attempts)
+		assertions.Equal(model.WebhookDeliveryStatusDead, store.updated[2].Status)
+		assertions.Nil(store.updated[2].DeliveryDate)
+	})
+
+	t.Run("Unsuccessful Process Due unreachable receiver", func(t *testing.T) {
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		receiver.Close()
+
+		store := &testStore{pending: []*PendingDelivery{newTestPendingDelivery(receiver.URL)}}
+		worker := NewWorker(store, receiver.Client(), 1, time.Minute, time.Second)
+
+		_, err := worker.ProcessDue(ctx, time.Now())
+		assertions.Nil(err)
+		assertions.Equal(model.WebhookDeliveryStatusDead, store.updated[0].Status)
+		assertions.Nil(store.updated[0].LastStatusCode)
+		assertions.NotNil(store.updated[0].LastError)
+	})
+
+	t.Run("Successful Get Retry Delay is capped", func(t *testing.T) {
+		worker := NewWorker(&testStore{}, http.DefaultClient, 100, time.Minute, time.Second)
+
+		assertions.Equal(time.Minute, worker.getRetryDelay(1))
+		assertions.Equal(8*time.Minute, worker.getRetryDelay(4))
+		assertions.Equal(maxRetryDelay, worker.getRetryDelay(50))
+	})
+}
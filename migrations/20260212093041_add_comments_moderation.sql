@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN moderation_mode VARCHAR(20) NOT NULL DEFAULT 'OPEN';
+
+UPDATE posts SET moderation_mode = 'CLOSED'
+WHERE comments_enabled = FALSE;
+
+ALTER TABLE comments
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'APPROVED';
+
+CREATE INDEX post_id_status_idx ON comments(post_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS post_id_status_idx;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS status;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS moderation_mode;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS reactions (
+    reaction_id BIGSERIAL PRIMARY KEY,
+    target_type VARCHAR(20) NOT NULL,
+    target_id BIGINT NOT NULL,
+    user_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    create_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (target_type, target_id, user_id, kind)
+);
+
+CREATE INDEX reactions_target_idx ON reactions(target_type, target_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS reactions;
+-- +goose StatementEnd
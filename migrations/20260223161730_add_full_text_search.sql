@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'simple';
+
+ALTER TABLE posts
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector(search_language, title), 'A') || setweight(to_tsvector(search_language, text), 'B')
+) STORED;
+
+CREATE INDEX posts_search_vector_idx ON posts USING GIN (search_vector);
+
+ALTER TABLE comments
+ADD COLUMN search_language REGCONFIG NOT NULL DEFAULT 'simple';
+
+ALTER TABLE comments
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (to_tsvector(search_language, text)) STORED;
+
+CREATE INDEX comments_search_vector_idx ON comments USING GIN (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS comments_search_vector_idx;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS search_language;
+
+DROP INDEX IF EXISTS posts_search_vector_idx;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS search_language;
+-- +goose StatementEnd
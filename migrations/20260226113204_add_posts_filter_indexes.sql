@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX posts_create_date_idx ON posts(create_date, post_id);
+CREATE INDEX posts_author_id_create_date_idx ON posts(author_id, create_date, post_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS posts_author_id_create_date_idx;
+DROP INDEX IF EXISTS posts_create_date_idx;
+-- +goose StatementEnd
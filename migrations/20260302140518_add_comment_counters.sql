@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN comment_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE posts
+ADD COLUMN root_comment_count INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE comments
+ADD COLUMN reply_count INTEGER NOT NULL DEFAULT 0;
+
+UPDATE posts SET comment_count = counts.comment_count, root_comment_count = counts.root_comment_count
+FROM (
+    SELECT post_id, COUNT(*) AS comment_count, COUNT(*) FILTER (WHERE parent_id IS NULL) AS root_comment_count
+    FROM comments
+    WHERE status = 'APPROVED'
+    GROUP BY post_id
+) AS counts
+WHERE posts.post_id = counts.post_id;
+
+UPDATE comments SET reply_count = counts.reply_count
+FROM (
+    SELECT parent_id, COUNT(*) AS reply_count
+    FROM comments
+    WHERE status = 'APPROVED' AND parent_id IS NOT NULL
+    GROUP BY parent_id
+) AS counts
+WHERE comments.comment_id = counts.parent_id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE comments
+DROP COLUMN IF EXISTS reply_count;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS root_comment_count;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS comment_count;
+-- +goose StatementEnd
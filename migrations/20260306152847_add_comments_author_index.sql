@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX comments_author_id_create_date_idx ON comments(author_id, create_date, comment_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS comments_author_id_create_date_idx;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notifications (
+    notification_id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    post_id BIGINT NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    comment_id BIGINT NOT NULL REFERENCES comments(comment_id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL,
+    create_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX notifications_user_id_idx ON notifications(user_id, notification_id);
+
+CREATE INDEX notifications_comment_id_idx ON notifications(comment_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notifications;
+-- +goose StatementEnd
@@ -0,0 +1,51 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users
+ADD COLUMN username VARCHAR(64);
+
+CREATE UNIQUE INDEX users_username_idx ON users(LOWER(username));
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id BIGINT NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    tag VARCHAR(50) NOT NULL,
+    PRIMARY KEY (post_id, tag)
+);
+
+CREATE INDEX post_tags_tag_idx ON post_tags(tag, post_id);
+
+CREATE TABLE IF NOT EXISTS mentions (
+    post_id BIGINT NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    comment_id BIGINT REFERENCES comments(comment_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX mentions_post_user_idx ON mentions(post_id, user_id) WHERE comment_id IS NULL;
+
+CREATE UNIQUE INDEX mentions_comment_user_idx ON mentions(comment_id, user_id) WHERE comment_id IS NOT NULL;
+
+CREATE INDEX mentions_user_id_idx ON mentions(user_id);
+
+ALTER TABLE notifications
+ALTER COLUMN comment_id DROP NOT NULL;
+
+CREATE INDEX notifications_post_id_idx ON notifications(post_id) WHERE comment_id IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS notifications_post_id_idx;
+
+DELETE FROM notifications WHERE comment_id IS NULL;
+
+ALTER TABLE notifications
+ALTER COLUMN comment_id SET NOT NULL;
+
+DROP TABLE IF EXISTS mentions;
+
+DROP TABLE IF EXISTS post_tags;
+
+DROP INDEX IF EXISTS users_username_idx;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS username;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS post_revisions (
+    revision_id BIGSERIAL PRIMARY KEY,
+    post_id BIGINT NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    editor_id UUID NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    text VARCHAR(5000) NOT NULL,
+    create_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (post_id, revision_number)
+);
+
+CREATE TABLE IF NOT EXISTS comment_revisions (
+    revision_id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES comments(comment_id) ON DELETE CASCADE,
+    revision_number INTEGER NOT NULL,
+    editor_id UUID NOT NULL,
+    text VARCHAR(2000) NOT NULL,
+    create_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (comment_id, revision_number)
+);
+
+INSERT INTO post_revisions (post_id, revision_number, editor_id, title, text, create_date)
+SELECT post_id, 1, author_id, title, text, create_date
+FROM posts;
+
+INSERT INTO comment_revisions (comment_id, revision_number, editor_id, text, create_date)
+SELECT comment_id, 1, author_id, text, create_date
+FROM comments;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS comment_revisions;
+
+DROP TABLE IF EXISTS post_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE comments
+ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX posts_deleted_at_idx ON posts(deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE INDEX comments_deleted_at_idx ON comments(deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS comments_deleted_at_idx;
+
+DROP INDEX IF EXISTS posts_deleted_at_idx;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS bans (
+    ban_id BIGSERIAL PRIMARY KEY,
+    user_id UUID NOT NULL,
+    post_id BIGINT REFERENCES posts(post_id) ON DELETE CASCADE,
+    moderator_id UUID NOT NULL,
+    reason VARCHAR(500),
+    create_date TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE UNIQUE INDEX bans_user_global_idx ON bans(user_id) WHERE post_id IS NULL;
+
+CREATE UNIQUE INDEX bans_user_post_idx ON bans(user_id, post_id) WHERE post_id IS NOT NULL;
+
+CREATE INDEX bans_post_id_idx ON bans(post_id) WHERE post_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS bans;
+-- +goose StatementEnd
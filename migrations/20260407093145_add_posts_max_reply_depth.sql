@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN max_reply_depth INTEGER CHECK (max_reply_depth >= 0);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE posts
+DROP COLUMN IF EXISTS max_reply_depth;
+-- +goose StatementEnd
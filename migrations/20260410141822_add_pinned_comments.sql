@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE comments
+ADD COLUMN pinned_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX comments_pinned_idx ON comments(post_id, pinned_at, comment_id) WHERE pinned_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS comments_pinned_idx;
+
+ALTER TABLE comments
+DROP COLUMN IF EXISTS pinned_at;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE posts
+ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'PUBLISHED';
+
+ALTER TABLE posts
+ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE posts SET publish_at = create_date;
+
+CREATE INDEX posts_scheduled_idx ON posts(publish_at) WHERE status = 'DRAFT' AND publish_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS posts_scheduled_idx;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS publish_at;
+
+ALTER TABLE posts
+DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
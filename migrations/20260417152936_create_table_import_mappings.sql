@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS imported_posts (
+    source VARCHAR(200) NOT NULL,
+    source_id BIGINT NOT NULL,
+    post_id BIGINT NOT NULL REFERENCES posts(post_id) ON DELETE CASCADE,
+    PRIMARY KEY (source, source_id)
+);
+
+CREATE TABLE IF NOT EXISTS imported_comments (
+    source VARCHAR(200) NOT NULL,
+    source_id BIGINT NOT NULL,
+    comment_id BIGINT NOT NULL REFERENCES comments(comment_id) ON DELETE CASCADE,
+    PRIMARY KEY (source, source_id)
+);
+
+CREATE INDEX imported_posts_post_id_idx ON imported_posts(post_id);
+
+CREATE INDEX imported_comments_comment_id_idx ON imported_comments(comment_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS imported_comments;
+
+DROP TABLE IF EXISTS imported_posts;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE outbox
+ADD COLUMN lease_owner UUID,
+ADD COLUMN lease_until TIMESTAMP WITH TIME ZONE;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox
+DROP COLUMN IF EXISTS lease_until,
+DROP COLUMN IF EXISTS lease_owner;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notifications
+ADD COLUMN revision_number INT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notifications
+DROP COLUMN IF EXISTS revision_number;
+-- +goose StatementEnd